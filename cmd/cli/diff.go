@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"go-standards-mcp-server/internal/analyzer"
+	"go-standards-mcp-server/internal/config"
+	"go-standards-mcp-server/internal/lintconfig"
+	"go-standards-mcp-server/internal/storage"
+	"go.uber.org/zap"
+)
+
+// runDiff implements the diff subcommand and returns the exit code
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	format := fs.String("format", "text", "Output format: text or json")
+	failLooser := fs.Bool("fail-looser", false, "Exit with code 1 if the change loosens any check")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [-format text|json] [-fail-looser] <from> <to>\n", appName)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 1
+	}
+
+	a, err := newConfigAnalyzer()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	fromData, fromSource, err := a.EffectiveConfig(fs.Arg(0), "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	toData, toSource, err := a.EffectiveConfig(fs.Arg(1), "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %v\n", fs.Arg(1), err)
+		return 1
+	}
+
+	diff, err := lintconfig.Compare(fromData, toData, fromSource, toSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to compare configs: %v\n", err)
+		return 1
+	}
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(diff.Text())
+	}
+
+	if *failLooser && (diff.Verdict == lintconfig.EffectLooser || diff.Verdict == lintconfig.EffectMixed) {
+		return 1
+	}
+	return 0
+}
+
+// newConfigAnalyzer creates an analyzer for the config subcommands, with
+// the server's default config and stored configs
+func newConfigAnalyzer() (*analyzer.Analyzer, error) {
+	cfg, err := config.Load("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	a, err := analyzer.NewAnalyzer(cfg, zap.NewNop())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize analyzer: %w", err)
+	}
+	openConfigStorage(a)
+	return a, nil
+}
+
+// openConfigStorage makes stored configs available to the analyzer when
+// run from the server's directory
+func openConfigStorage(a *analyzer.Analyzer) {
+	if _, err := os.Stat(storage.DefaultConfigDir); err != nil {
+		return
+	}
+	if configs, err := storage.NewConfigStorage(storage.DefaultConfigDir); err == nil {
+		a.SetConfigStorage(configs)
+	}
+}
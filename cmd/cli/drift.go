@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"go-standards-mcp-server/internal/analyzer"
+)
+
+// runDrift implements the drift subcommand and returns the exit code
+func runDrift(args []string) int {
+	fs := flag.NewFlagSet("drift", flag.ContinueOnError)
+	ref := fs.String("config", "", "Registered config to compare with (default: the one each .golangci.yml was exported from)")
+	format := fs.String("format", "text", "Output format: text or json")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s drift [-config name] [-format text|json] <repo>...\n", appName)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 1
+	}
+
+	a, err := newConfigAnalyzer()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	exitCode := 0
+	reports := make([]*analyzer.DriftReport, 0, fs.NArg())
+	for _, repo := range fs.Args() {
+		report := a.CheckDrift(repo, *ref)
+		if report.Status != analyzer.DriftInSync {
+			exitCode = 1
+		}
+		reports = append(reports, report)
+	}
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(data))
+		return exitCode
+	}
+
+	for _, report := range reports {
+		fmt.Print(driftText(report))
+	}
+	return exitCode
+}
+
+// driftText formats a drift report for reading in a terminal or CI log
+func driftText(r *analyzer.DriftReport) string {
+	var sb strings.Builder
+	switch r.Status {
+	case analyzer.DriftInSync:
+		fmt.Fprintf(&sb, "%s: in sync with %s\n", r.Repo, r.Config)
+	case analyzer.DriftDrifted:
+		fmt.Fprintf(&sb, "%s: drifted from %s (%s)\n", r.Repo, r.Config, r.Diff.Verdict)
+	case analyzer.DriftError:
+		fmt.Fprintf(&sb, "%s: error: %s\n", r.Repo, r.Error)
+	default:
+		fmt.Fprintf(&sb, "%s: %s\n", r.Repo, r.Status)
+	}
+	for _, reason := range r.Reasons {
+		fmt.Fprintf(&sb, "  - %s\n", reason)
+	}
+	if r.Diff != nil {
+		for _, line := range strings.Split(strings.TrimRight(r.Diff.Text(), "\n"), "\n")[1:] {
+			if line == "" {
+				sb.WriteString("\n")
+				continue
+			}
+			fmt.Fprintf(&sb, "  %s\n", line)
+		}
+	}
+	return sb.String()
+}
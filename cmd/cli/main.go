@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"go-standards-mcp-server/internal/analyzer"
 	"go-standards-mcp-server/internal/config"
@@ -14,14 +15,19 @@ import (
 )
 
 var (
-	filePath   = flag.String("file", "", "Path to Go file to analyze")
-	projectDir = flag.String("project", "", "Path to Go project directory")
-	code       = flag.String("code", "", "Go code snippet to analyze")
-	standard   = flag.String("standard", "standard", "Analysis standard: strict, standard, or relaxed")
-	format     = flag.String("format", "json", "Output format: json or markdown")
-	configPath = flag.String("config", "", "Path to custom config file")
-	version    = flag.Bool("version", false, "Print version and exit")
-	help       = flag.Bool("help", false, "Show detailed help message")
+	filePath       = flag.String("file", "", "Path to Go file to analyze")
+	projectDir     = flag.String("project", "", "Path to Go project directory")
+	code           = flag.String("code", "", "Go code snippet to analyze")
+	standard       = flag.String("standard", "", "Analysis standard: strict, standard, or relaxed (default: from .go-standards.json, or standard)")
+	format         = flag.String("format", "json", "Output format: json or markdown")
+	configRef      = flag.String("config", "", "Lint config: stored config name, template name, or path to a golangci-lint config")
+	serverConfig   = flag.String("server-config", "", "Path to server config file")
+	baselineFile   = flag.String("baseline", "", "Path to baseline file (default: <project>/.go-standards-baseline.json)")
+	updateBaseline = flag.Bool("update-baseline", false, "Snapshot current issues into the baseline file")
+	withCoverage   = flag.Bool("coverage", false, "Run tests and enforce the standard's coverage threshold")
+	coverProfile   = flag.String("coverprofile", "", "Use an existing coverage profile instead of running tests")
+	version        = flag.Bool("version", false, "Print version and exit")
+	help           = flag.Bool("help", false, "Show detailed help message")
 )
 
 const (
@@ -30,6 +36,13 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiff(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "drift" {
+		os.Exit(runDrift(os.Args[2:]))
+	}
+
 	flag.Usage = printUsage
 	flag.Parse()
 
@@ -45,13 +58,13 @@ func main() {
 
 	// Validate input
 	if *filePath == "" && *projectDir == "" && *code == "" {
-		fmt.Fprintln(os.Stderr, "Error: Must specify one of -file, -project, or -code\n")
+		fmt.Fprint(os.Stderr, "Error: Must specify one of -file, -project, or -code\n\n")
 		printUsage()
 		os.Exit(1)
 	}
 
 	// Load configuration
-	cfg, err := config.Load(*configPath)
+	cfg, err := config.Load(*serverConfig)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
 		os.Exit(1)
@@ -66,6 +79,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to initialize analyzer: %v\n", err)
 		os.Exit(1)
 	}
+	openConfigStorage(a)
 
 	// Create analysis request
 	req := &models.AnalysisRequest{
@@ -73,7 +87,13 @@ func main() {
 		FilePath:   *filePath,
 		ProjectDir: *projectDir,
 		Standard:   *standard,
+		ConfigRef:  *configRef,
 		Format:     *format,
+
+		Baseline:       *baselineFile,
+		UpdateBaseline: *updateBaseline,
+		Coverage:       *withCoverage,
+		CoverProfile:   *coverProfile,
 	}
 
 	// Perform analysis
@@ -95,6 +115,16 @@ func main() {
 		fmt.Println(formatMarkdown(result))
 	}
 
+	// Exit with error code if any linter failed, since results are incomplete
+	if result.Status != "success" {
+		for _, run := range result.Linters {
+			if run.Status != "ok" {
+				fmt.Fprintf(os.Stderr, "Linter %s %s: %s\n", run.Name, run.Status, run.Error)
+			}
+		}
+		os.Exit(1)
+	}
+
 	// Exit with error code if issues found
 	if result.Summary.ErrorCount > 0 {
 		os.Exit(1)
@@ -111,6 +141,15 @@ func formatMarkdown(result *models.AnalysisResult) string {
 	md += fmt.Sprintf("- Errors: %d\n", result.Summary.ErrorCount)
 	md += fmt.Sprintf("- Warnings: %d\n", result.Summary.WarningCount)
 	md += fmt.Sprintf("- Files Analyzed: %d\n", result.Summary.FilesAnalyzed)
+	if result.Summary.BaselinedCount > 0 {
+		md += fmt.Sprintf("- Baselined: %d\n", result.Summary.BaselinedCount)
+	}
+	if result.Summary.SuppressedCount > 0 {
+		md += fmt.Sprintf("- Suppressed: %d\n", result.Summary.SuppressedCount)
+	}
+	if cov := result.Summary.Coverage; cov != nil {
+		md += fmt.Sprintf("- Coverage: %.1f%% (threshold %.0f%%, %d uncovered functions)\n", cov.Total, cov.Threshold, cov.UncoveredFunctions)
+	}
 	md += fmt.Sprintf("- Duration: %s\n\n", result.Summary.Duration)
 
 	if len(result.Issues) > 0 {
@@ -118,7 +157,51 @@ func formatMarkdown(result *models.AnalysisResult) string {
 		for i, issue := range result.Issues {
 			md += fmt.Sprintf("%d. [%s] %s\n", i+1, issue.Severity, issue.Message)
 			md += fmt.Sprintf("   File: %s:%d:%d\n", issue.File, issue.Line, issue.Column)
-			md += fmt.Sprintf("   Rule: %s (%s)\n\n", issue.Rule, issue.Source)
+			md += fmt.Sprintf("   Rule: %s (%s)\n", issue.Rule, issue.Source)
+			if v := issue.Vulnerability; v != nil {
+				md += fmt.Sprintf("   Vulnerable: %s@%s, fixed in %s\n", v.Module, v.FoundVersion, v.FixedVersion)
+				if len(v.CallStack) > 0 {
+					md += fmt.Sprintf("   Call stack: %s\n", strings.Join(v.CallStack, " -> "))
+				}
+			}
+			for _, r := range issue.Related {
+				md += fmt.Sprintf("   Related: %s:%d:%d %s\n", r.File, r.Line, r.Column, r.Message)
+			}
+			for _, fix := range issue.Fixes {
+				md += fmt.Sprintf("   Fix: %s\n", fix.Message)
+			}
+			md += "\n"
+		}
+	}
+
+	if len(result.Summary.Modules) > 1 {
+		md += "## Modules\n\n"
+		for _, m := range result.Summary.Modules {
+			md += fmt.Sprintf("- %s (%s): %d issues, %d errors, score %.1f\n", m.Path, m.Dir, m.TotalIssues, m.ErrorCount, m.Score)
+		}
+		md += "\n"
+	}
+
+	if result.Status != "success" {
+		md += "## Linter Failures\n\n"
+		for _, run := range result.Linters {
+			if run.Status == "ok" {
+				continue
+			}
+			md += fmt.Sprintf("- %s: %s (exit code %d, %s)\n", run.Name, run.Status, run.ExitCode, run.Duration)
+			if run.Stderr != "" {
+				md += fmt.Sprintf("  %s\n", run.Stderr)
+			}
+		}
+		md += "\n"
+	}
+
+	if len(result.Suppressed) > 0 {
+		md += "## Suppressed\n\n"
+		for i, sup := range result.Suppressed {
+			md += fmt.Sprintf("%d. [%s] %s\n", i+1, sup.Issue.Rule, sup.Issue.Message)
+			md += fmt.Sprintf("   File: %s:%d (%s scope)\n", sup.Issue.File, sup.Issue.Line, sup.Scope)
+			md += fmt.Sprintf("   Reason: %s\n\n", sup.Reason)
 		}
 	}
 
@@ -135,6 +218,8 @@ func formatMarkdown(result *models.AnalysisResult) string {
 
 func printUsage() {
 	fmt.Fprintf(os.Stderr, `Usage: %s [options]
+       %s diff [-format text|json] [-fail-looser] <from> <to>
+       %s drift [-config name] [-format text|json] <repo>...
 
 Go code quality analysis tool with multiple standards.
 
@@ -153,7 +238,8 @@ OPTIONS:
         Example: -code 'package main; func main() { }'
 
   -standard string
-        Analysis standard level (default: standard)
+        Analysis standard level (default: the standards declared in the
+        project's .go-standards.json, or standard)
         Options:
           strict   - Highest standards (complexity ≤ 5, coverage ≥ 85%%)
           standard - Balanced standards (complexity ≤ 10, coverage ≥ 70%%)
@@ -166,8 +252,30 @@ OPTIONS:
           markdown - Human-readable Markdown report
 
   -config string
-        Path to custom golangci-lint config file
+        Lint config to use instead of the standard's template, tried in
+        this order: a stored config name, a template name, or a path to a
+        golangci-lint config file or a directory containing one
         Example: -config .golangci.yml
+        Example: -config team-backend
+
+  -server-config string
+        Path to the server config file (default: configs/default.yaml)
+
+  -baseline string
+        Baseline file with known issues to suppress
+        (default: <project>/.go-standards-baseline.json if present)
+        Only new issues count towards the score and exit code
+
+  -update-baseline
+        Snapshot all current issues into the baseline file
+
+  -coverage
+        Run the project's tests and fail if coverage is below the
+        standard's threshold (strict 85%%, standard 70%%, relaxed 60%%)
+
+  -coverprofile string
+        Use an existing coverage profile instead of running tests
+        Example: -coverprofile coverage.out
 
   -version
         Print version information and exit
@@ -175,6 +283,29 @@ OPTIONS:
   -help
         Show this detailed help message
 
+DIFF:
+  Compare two lint configs by linters, thresholds and exclusions, and
+  report whether the change is stricter or looser. Each config is a stored
+  config name, a template name, or a path to a config file or to a
+  directory containing .golangci.yml.
+
+  -format string
+        Output format: text or json (default: text)
+
+  -fail-looser
+        Exit with code 1 if the change loosens any check
+
+DRIFT:
+  Check that the .golangci.yml committed to each repository still matches
+  the registered config it was exported from with manage_config, and
+  exit with code 1 if any has drifted, is missing, or was never exported.
+
+  -config string
+        Stored config or template to compare with instead, e.g. config:team
+
+  -format string
+        Output format: text or json (default: text)
+
 EXAMPLES:
   # Analyze a single file
   %s -file main.go
@@ -195,12 +326,29 @@ EXAMPLES:
   # Use custom config
   %s -project . -config .golangci.yml
 
+  # Use a config saved with manage_config
+  %s -project . -config config:team-backend
+
+  # Adopt strict mode on an existing project: record current issues,
+  # then only report new ones
+  %s -project . -standard strict -update-baseline
+  %s -project . -standard strict
+
+  # Enforce the coverage threshold in CI
+  %s -project . -coverage
+
+  # Review a lint config change against the team template
+  %s diff template:standard .golangci.yml
+
+  # Check that services still follow the team config
+  %s drift ./svc-orders ./svc-billing
+
 EXIT CODES:
-  0  Analysis successful, no errors found
-  1  Analysis failed or errors detected
+  0  Analysis successful, no new errors found
+  1  Analysis failed, a linter failed or timed out, or new errors detected
 
 For more information, visit: https://go-standards-mcp-server
-`, appName, appName, appName, appName, appName, appName)
+`, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName)
 }
 
 func printDetailedHelp() {
@@ -20,6 +20,7 @@ var (
 	mode       = flag.String("mode", "", "Server mode: stdio or http")
 	port       = flag.Int("port", 0, "HTTP server port")
 	logLevel   = flag.String("log-level", "", "Log level: debug, info, warn, error")
+	watch      = flag.Bool("watch", true, "Reload the config file and templates when they change")
 	version    = flag.Bool("version", false, "Print version and exit")
 )
 
@@ -45,18 +46,10 @@ func main() {
 	}
 
 	// Override config with command-line flags
-	if *mode != "" {
-		cfg.Server.Mode = *mode
-	}
-	if *port != 0 {
-		cfg.Server.Port = *port
-	}
-	if *logLevel != "" {
-		cfg.Log.Level = *logLevel
-	}
+	applyFlags(cfg)
 
 	// Initialize logger
-	logger, err := initLogger(cfg.Log)
+	logger, level, err := initLogger(cfg.Log)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -82,8 +75,16 @@ func main() {
 		logger.Fatal("Failed to initialize analyzer", zap.Error(err))
 	}
 
+	// Watch the config file and templates so changes apply without a restart
+	if *watch {
+		stop := make(chan struct{})
+		defer close(stop)
+		r := &reloader{current: cfg, analyzer: analyzer, level: level, logger: logger}
+		r.start(stop)
+	}
+
 	// Initialize MCP server
-	server, err := mcp.NewServer(cfg, logger, analyzer, sessionManager)
+	server, err := mcp.NewServer(logger, analyzer, sessionManager)
 	if err != nil {
 		logger.Fatal("Failed to initialize MCP server", zap.Error(err))
 	}
@@ -98,24 +99,40 @@ func main() {
 	}
 }
 
-// initLogger initializes the logger
-func initLogger(cfg config.LogConfig) (*zap.Logger, error) {
-	// Parse log level
-	level := zapcore.InfoLevel
-	switch cfg.Level {
+// applyFlags overrides config settings given on the command line
+func applyFlags(cfg *config.Config) {
+	if *mode != "" {
+		cfg.Server.Mode = *mode
+	}
+	if *port != 0 {
+		cfg.Server.Port = *port
+	}
+	if *logLevel != "" {
+		cfg.Log.Level = *logLevel
+	}
+}
+
+// parseLevel converts a configured log level, defaulting to info
+func parseLevel(name string) zapcore.Level {
+	switch name {
 	case "debug":
-		level = zapcore.DebugLevel
-	case "info":
-		level = zapcore.InfoLevel
+		return zapcore.DebugLevel
 	case "warn":
-		level = zapcore.WarnLevel
+		return zapcore.WarnLevel
 	case "error":
-		level = zapcore.ErrorLevel
+		return zapcore.ErrorLevel
 	}
+	return zapcore.InfoLevel
+}
+
+// initLogger initializes the logger and returns it with its level, which
+// a config reload can change
+func initLogger(cfg config.LogConfig) (*zap.Logger, zap.AtomicLevel, error) {
+	level := zap.NewAtomicLevelAt(parseLevel(cfg.Level))
 
 	// Create config
 	zapConfig := zap.Config{
-		Level:             zap.NewAtomicLevelAt(level),
+		Level:             level,
 		Development:       false,
 		DisableCaller:     false,
 		DisableStacktrace: false,
@@ -140,5 +157,6 @@ func initLogger(cfg config.LogConfig) (*zap.Logger, error) {
 	}
 
 	// Build logger
-	return zapConfig.Build()
+	logger, err := zapConfig.Build()
+	return logger, level, err
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-standards-mcp-server/internal/analyzer"
+	"go-standards-mcp-server/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// reloadDelay is how long files must be quiet after a change before they
+// are reloaded
+const reloadDelay = 200 * time.Millisecond
+
+// reloader applies changes to the config file and templates while the
+// server runs
+type reloader struct {
+	current  *config.Config // Last config applied
+	analyzer *analyzer.Analyzer
+	level    zap.AtomicLevel
+	logger   *zap.Logger
+}
+
+// start loads the templates and watches the config file and template
+// directory until stop is closed
+func (r *reloader) start(stop <-chan struct{}) {
+	if _, err := r.analyzer.LoadTemplates(); err != nil {
+		r.logger.Warn("Templates are read from disk on each use", zap.Error(err))
+	} else if dir, err := r.analyzer.TemplateDir(); err == nil {
+		isTemplate := func(file string) bool { return strings.HasSuffix(file, ".yaml") }
+		if err := config.Watch(dir, isTemplate, reloadDelay, r.reloadTemplates, stop, r.logger); err != nil {
+			r.logger.Warn("Failed to watch templates", zap.Error(err))
+		} else {
+			r.logger.Info("Watching templates", zap.String("dir", dir))
+		}
+	}
+
+	if r.current.File == "" {
+		r.logger.Info("No config file to watch, using defaults")
+		return
+	}
+	file := filepath.Clean(r.current.File)
+	isConfig := func(name string) bool { return name == file }
+	if err := config.Watch(filepath.Dir(file), isConfig, reloadDelay, r.reloadConfig, stop, r.logger); err != nil {
+		r.logger.Warn("Failed to watch config file", zap.Error(err))
+		return
+	}
+	r.logger.Info("Watching config file", zap.String("file", file))
+}
+
+// reloadConfig loads the config file and applies the settings that can
+// change while the server runs. An invalid config is rejected and the last
+// good one stays in place.
+func (r *reloader) reloadConfig() {
+	next, err := config.Load(r.current.File)
+	if err != nil {
+		r.logger.Error("Rejected config change, keeping the last good config",
+			zap.String("file", r.current.File), zap.Error(err))
+		return
+	}
+	applyFlags(next)
+
+	changes := config.Diff(r.current, next)
+	if len(changes) == 0 {
+		r.logger.Debug("Config file changed, settings are the same")
+		return
+	}
+
+	applied := config.KeepRestartOnly(r.current, next)
+	if err := r.analyzer.Reload(applied); err != nil {
+		r.logger.Error("Rejected config change, keeping the last good config",
+			zap.String("file", r.current.File), zap.Error(err))
+		return
+	}
+	r.level.SetLevel(parseLevel(applied.Log.Level))
+	r.current = applied
+
+	for _, change := range changes {
+		if change.RequiresRestart() {
+			r.logger.Warn("Config change takes effect after a restart", zap.String("setting", change.Path),
+				zap.String("from", change.From), zap.String("to", change.To))
+			continue
+		}
+		r.logger.Info("Config changed", zap.String("setting", change.Path),
+			zap.String("from", change.From), zap.String("to", change.To))
+	}
+}
+
+// reloadTemplates reloads the templates, keeping the last good version of
+// any that became invalid
+func (r *reloader) reloadTemplates() {
+	if _, err := r.analyzer.LoadTemplates(); err != nil {
+		r.logger.Error("Failed to reload templates", zap.Error(err))
+	}
+}
@@ -3,59 +3,140 @@
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"go-standards-mcp-server/internal/baseline"
+	"go-standards-mcp-server/internal/pathguard"
+	"go-standards-mcp-server/internal/storage"
 	"go-standards-mcp-server/internal/config"
+	"go-standards-mcp-server/internal/lintconfig"
+	"go-standards-mcp-server/internal/policy"
+	"go-standards-mcp-server/internal/suppression"
+	"go-standards-mcp-server/internal/workspace"
 	"go-standards-mcp-server/pkg/linters"
 	"go-standards-mcp-server/pkg/models"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// Analyzer handles code analysis operations
+// Analyzer handles code analysis operations. Exported methods work on a
+// snapshot of the settings, so a reload never changes them mid-analysis.
 type Analyzer struct {
-	config  *config.Config
-	logger  *zap.Logger
-	linters map[string]linters.Linter
+	config    *config.Config
+	logger    *zap.Logger
+	linters   map[string]linters.Linter
+	registry  *linters.Registry
+	guard     *pathguard.Guard
+	configs   *storage.ConfigStorage // Stored configs that custom configs may extend; nil if there is no config store
+	templates map[string][]byte        // Validated templates kept by LoadTemplates; nil reads them from disk on each use
+	policies  map[string][]policy.Rule // Policies declared next to the templates, kept with them
+	versions  *versionCache
+
+	mu *sync.RWMutex // Guards the fields above; shared with snapshots
+}
+
+// versionCache holds the versions linters reported
+type versionCache struct {
+	mu       sync.Mutex
+	versions map[string]string
 }
 
 // NewAnalyzer creates a new Analyzer instance
 func NewAnalyzer(cfg *config.Config, logger *zap.Logger) (*Analyzer, error) {
-	a := &Analyzer{
-		config:  cfg,
-		logger:  logger,
-		linters: make(map[string]linters.Linter),
+	a := &Analyzer{logger: logger, mu: &sync.RWMutex{}}
+	if err := a.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload replaces the config, path guard and linter set. Analyses already
+// running finish with the previous ones. If the new config cannot be
+// applied, the current one stays in place.
+func (a *Analyzer) Reload(cfg *config.Config) error {
+	next := &Analyzer{
+		config:   cfg,
+		logger:   a.logger,
+		linters:  make(map[string]linters.Linter),
+		registry: linters.NewRegistry(),
+		versions: &versionCache{versions: make(map[string]string)},
+	}
+
+	// Every path in a request must lie inside the allowed roots; in http mode
+	// nothing is allowed unless roots are configured
+	guard, err := pathguard.New(cfg.Security, cfg.Server.Mode == "http", a.logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize path guard: %w", err)
 	}
+	next.guard = guard
 
 	// Initialize linters
-	if err := a.initLinters(); err != nil {
-		return nil, fmt.Errorf("failed to initialize linters: %w", err)
+	if err := next.initLinters(); err != nil {
+		return fmt.Errorf("failed to initialize linters: %w", err)
 	}
 
-	return a, nil
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = next.config
+	a.linters = next.linters
+	a.registry = next.registry
+	a.guard = next.guard
+	a.versions = next.versions
+	return nil
+}
+
+// Config returns the current config, which changes when it is reloaded
+func (a *Analyzer) Config() *config.Config {
+	return a.snapshot().config
 }
 
-// initLinters initializes all configured linters
+// snapshot returns a copy of the analyzer that keeps the current settings
+// for the rest of a call
+func (a *Analyzer) snapshot() *Analyzer {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	s := *a
+	return &s
+}
+
+// initLinters registers the configured plugins and creates every enabled
+// linter whose tool is available
 func (a *Analyzer) initLinters() error {
-	// Initialize golangci-lint
-	if a.config.Linters.GolangciLint.Enabled {
-		golangci, err := linters.NewGolangciLint(a.logger)
-		if err != nil {
-			a.logger.Warn("Failed to initialize golangci-lint", zap.Error(err))
-		} else {
-			a.linters["golangci-lint"] = golangci
-			a.logger.Info("Initialized golangci-lint")
+	taxonomy, err := linters.DefaultTaxonomy().WithOverrides(a.config.Linters.Taxonomy)
+	if err != nil {
+		return fmt.Errorf("invalid linter taxonomy: %w", err)
+	}
+
+	for _, spec := range a.config.Linters.Plugins {
+		if err := a.registry.Register(linters.PluginRegistration(spec)); err != nil {
+			a.logger.Warn("Failed to register linter plugin",
+				zap.String("name", spec.Name),
+				zap.Error(err))
 		}
 	}
 
-	// Initialize other linters
-	if a.config.Linters.Govet.Enabled {
-		govet := linters.NewGoVet(a.logger)
-		a.linters["govet"] = govet
-		a.logger.Info("Initialized govet")
+	for _, name := range a.registry.Names() {
+		if !a.config.Linters.IsEnabled(name) {
+			continue
+		}
+		reg, _ := a.registry.Get(name)
+		linter, err := reg.New(a.logger, a.linterOptions())
+		if err != nil {
+			a.logger.Warn("Failed to initialize linter", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		if classifier, ok := linter.(linters.Classifier); ok {
+			classifier.SetTaxonomy(taxonomy)
+		}
+		a.linters[name] = linter
+		a.logger.Info("Initialized linter", zap.String("name", name))
 	}
 
 	if len(a.linters) == 0 {
@@ -65,8 +146,16 @@ func (a *Analyzer) initLinters() error {
 	return nil
 }
 
+// linterOptions returns the factory options derived from the config
+func (a *Analyzer) linterOptions() linters.Options {
+	return linters.Options{
+		VulnDBPath: a.config.Linters.Govulncheck.DBPath,
+	}
+}
+
 // Analyze performs code analysis based on the request
 func (a *Analyzer) Analyze(ctx context.Context, req *models.AnalysisRequest) (*models.AnalysisResult, error) {
+	a = a.snapshot()
 	startTime := time.Now()
 	analysisID := uuid.New().String()
 
@@ -74,6 +163,12 @@ func (a *Analyzer) Analyze(ctx context.Context, req *models.AnalysisRequest) (*m
 		zap.String("id", analysisID),
 		zap.String("standard", req.Standard))
 
+	// Reject paths outside the allowed roots before touching the filesystem
+	req, err := a.checkPaths(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prepare working directory
 	workDir, cleanup, err := a.prepareWorkDir(req)
 	if err != nil {
@@ -81,47 +176,135 @@ func (a *Analyzer) Analyze(ctx context.Context, req *models.AnalysisRequest) (*m
 	}
 	defer cleanup()
 
-	// Load configuration
-	configPath, err := a.loadConfig(req.Standard, req.Config)
+	// Pick up the standards file the repository declares, if any
+	proj, err := a.findProject(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, err
+	}
+
+	// Load the configuration of the project and of each directory override
+	profiles := a.profiles(req, proj)
+	for _, p := range profiles {
+		p.uncapped = req.AllIssues
+		if err := a.prepareProfile(ctx, p); err != nil {
+			if p.dir != "" {
+				return nil, fmt.Errorf("override %s: %w", p.dir, err)
+			}
+			return nil, err
+		}
 	}
+	base := profiles[0]
+	req.Standard = base.standard
 
-	// Run analysis
-	issues, err := a.runLinters(ctx, workDir, configPath)
+	// Bound the whole analysis by the configured timeout
+	if a.config.Analyzer.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.config.Analyzer.Timeout)
+		defer cancel()
+	}
+
+	// Run linters and tests in the sandbox when it is enabled
+	if sb := a.sandbox(); sb != nil {
+		ctx = linters.WithSandbox(ctx, sb)
+	}
+
+	// Discover modules so each one is analyzed from its own root
+	var modules []workspace.Module
+	if req.ProjectDir != "" {
+		modules, err = workspace.FindModules(workDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover modules: %w", err)
+		}
+	}
+
+	// Run analysis; the policy is applied per profile
+	issues, runs, err := a.runProfiles(ctx, workDir, modules, profiles, proj)
 	if err != nil {
-		a.logger.Error("Analysis failed", zap.Error(err))
-		return &models.AnalysisResult{
-			ID:        analysisID,
-			Status:    "error",
-			Issues:    []models.Issue{},
-			Summary:   models.Summary{},
-			CreatedAt: time.Now(),
-		}, err
+		return nil, err
+	}
+
+	// Measure test coverage against the standard's threshold
+	var extra []models.Issue
+	var coverageSummary *models.CoverageSummary
+	if a.coverageEnabled(req) {
+		covIssues, covSummary, covRuns := a.runCoverage(ctx, req, workDir, modules)
+		extra = append(extra, covIssues...)
+		runs = append(runs, covRuns...)
+		coverageSummary = covSummary
+	}
+
+	extra = append(extra, limitIssues(runs)...)
+	var projectRules []policy.Rule
+	if proj != nil {
+		projectRules = proj.standards.Policy
+	}
+	if err := a.applyPolicy(extra, req.Standard, projectRules); err != nil {
+		return nil, fmt.Errorf("failed to apply policy: %w", err)
+	}
+	issues = append(issues, extra...)
+
+	status := overallStatus(runs)
+	if status != "success" {
+		a.logger.Warn("Some linters did not complete",
+			zap.String("id", analysisID),
+			zap.String("status", status))
+	}
+
+	// Apply inline suppression directives, then split off issues already
+	// recorded in the baseline
+	var suppressed []models.SuppressedIssue
+	var baselined []models.Issue
+	var baselinePath string
+	if !req.AllIssues {
+		issues, suppressed = a.applySuppressions(issues, workDir)
+		baselinePath = a.resolveBaselinePath(req)
+		issues, baselined, err = a.applyBaseline(issues, workDir, baselinePath, req.UpdateBaseline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply baseline: %w", err)
+		}
 	}
 
 	// Calculate summary
 	summary := a.calculateSummary(issues, workDir, time.Since(startTime))
+	summary.BaselinedCount = len(baselined)
+	summary.SuppressedCount = len(suppressed)
+	summary.Modules = moduleBreakdown(issues, modules)
+	summary.Coverage = coverageSummary
+
+	// Without any successful linter the score would be meaningless
+	if status == "error" {
+		summary.Score = 0
+	}
 
 	// Generate suggestions
 	suggestions := a.generateSuggestions(issues)
 
 	result := &models.AnalysisResult{
 		ID:          analysisID,
-		Status:      "success",
+		Status:      status,
 		Issues:      issues,
 		Summary:     summary,
 		Suggestions: suggestions,
+		Baselined:   baselined,
+		Suppressed:  suppressed,
+		Linters:     runs,
 		Metadata: models.Metadata{
 			Standard:      req.Standard,
-			ToolsUsed:     a.getToolNames(),
+			ToolsUsed:     toolsUsed(runs),
+			ConfigHash:    base.hash,
+			ConfigSources: base.sources,
+			GoVersion:     a.goVersion(ctx),
 			ServerVersion: "1.0.0",
+			Baseline:      baselinePath,
+			Project:       projectFile(proj),
+			Overrides:     overrideSummary(profiles),
 		},
 		CreatedAt: time.Now(),
 	}
 
 	a.logger.Info("Analysis completed",
 		zap.String("id", analysisID),
+		zap.String("status", status),
 		zap.Int("issues", len(issues)),
 		zap.Float64("score", summary.Score),
 		zap.Duration("duration", summary.Duration))
@@ -129,6 +312,38 @@ func (a *Analyzer) Analyze(ctx context.Context, req *models.AnalysisRequest) (*m
 	return result, nil
 }
 
+// PathGuard returns the guard that checks client-supplied paths
+func (a *Analyzer) PathGuard() *pathguard.Guard {
+	return a.snapshot().guard
+}
+
+// checkPaths returns a copy of req with every path resolved by the path
+// guard, or an error if any of them is outside the allowed roots
+func (a *Analyzer) checkPaths(req *models.AnalysisRequest) (*models.AnalysisRequest, error) {
+	checked := *req
+	paths := []struct {
+		op   string
+		path *string
+	}{
+		{"analyze project_dir", &checked.ProjectDir},
+		{"analyze file_path", &checked.FilePath},
+		{"write baseline", &checked.Baseline},
+		{"read cover_profile", &checked.CoverProfile},
+	}
+
+	for _, p := range paths {
+		if *p.path == "" {
+			continue
+		}
+		resolved, err := a.guard.Resolve(p.op, *p.path)
+		if err != nil {
+			return nil, err
+		}
+		*p.path = resolved
+	}
+	return &checked, nil
+}
+
 // prepareWorkDir prepares the working directory for analysis
 func (a *Analyzer) prepareWorkDir(req *models.AnalysisRequest) (string, func(), error) {
 	// If analyzing a project directory, use it directly
@@ -164,29 +379,113 @@ func (a *Analyzer) prepareWorkDir(req *models.AnalysisRequest) (string, func(),
 	return "", nil, fmt.Errorf("no code, file, or directory specified")
 }
 
-// loadConfig loads the appropriate configuration
-func (a *Analyzer) loadConfig(standard, customConfig string) (string, error) {
+// loadConfig loads the appropriate configuration and returns its path with
+// the chain of templates and stored configs it was built from
+func (a *Analyzer) loadConfig(standard, customConfig string) (string, []string, error) {
 	if standard == "custom" && customConfig != "" {
-		// Save custom config to temp file
-		hash := fmt.Sprintf("%x", sha256.Sum256([]byte(customConfig)))
-		configPath := filepath.Join(a.config.Analyzer.TempDir, fmt.Sprintf("config-%s.yaml", hash[:8]))
-		
-		if err := os.WriteFile(configPath, []byte(customConfig), 0644); err != nil {
-			return "", fmt.Errorf("failed to write custom config: %w", err)
-		}
-		
-		return configPath, nil
+		return a.writeConfig([]byte(customConfig), "content")
 	}
 
-	// Use predefined template
-	// Try multiple paths to find the config file
-	possiblePaths := []string{
-		filepath.Join("configs", "templates", fmt.Sprintf("%s.yaml", standard)),
-		filepath.Join("..", "configs", "templates", fmt.Sprintf("%s.yaml", standard)),
-		filepath.Join(getExecutableDir(), "..", "configs", "templates", fmt.Sprintf("%s.yaml", standard)),
+	// Templates kept in memory are written out like custom configs
+	if a.templates != nil {
+		data, source, err := a.loadTemplate(standard)
+		if err != nil {
+			return "", nil, err
+		}
+		path, err := a.tempConfig(data)
+		if err != nil {
+			return "", nil, err
+		}
+		return path, []string{source}, nil
+	}
+
+	path, err := a.TemplatePath(standard)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, []string{"template:" + standard}, nil
+}
+
+// writeConfig merges a config labelled source over the templates or stored
+// configs it extends, and saves the result to a temp file
+func (a *Analyzer) writeConfig(content []byte, source string) (string, []string, error) {
+	resolution, err := a.ResolveConfig(content, source)
+	if err != nil {
+		return "", nil, err
+	}
+
+	configPath, err := a.tempConfig(resolution.Content)
+	if err != nil {
+		return "", nil, err
+	}
+	return configPath, resolution.Chain, nil
+}
+
+// tempConfig saves a config to a temp file named by its content hash
+func (a *Analyzer) tempConfig(content []byte) (string, error) {
+	hash := fmt.Sprintf("%x", sha256.Sum256(content))
+	configPath := filepath.Join(a.config.Analyzer.TempDir, fmt.Sprintf("config-%s.yaml", hash[:8]))
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write custom config: %w", err)
+	}
+	return configPath, nil
+}
+
+// SetConfigStorage makes stored custom configs available as bases for
+// configs that extend them
+func (a *Analyzer) SetConfigStorage(configs *storage.ConfigStorage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.configs = configs
+}
+
+// ResolveConfig merges a config labelled source with the chain of
+// templates and stored configs it extends
+func (a *Analyzer) ResolveConfig(content []byte, source string) (*lintconfig.Resolution, error) {
+	a = a.snapshot()
+	resolution, err := lintconfig.Resolve(content, source, a.loadBaseConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve extends: %w", err)
+	}
+	return resolution, nil
+}
+
+// ValidateConfig checks a config labelled source after merging it with the
+// configs it extends
+func (a *Analyzer) ValidateConfig(content []byte, source string) *lintconfig.Validation {
+	resolution, err := a.ResolveConfig(content, source)
+	if err != nil {
+		return lintconfig.Failed(err)
+	}
+	return lintconfig.Validate(resolution.Content)
+}
+
+// loadBaseConfig loads the config named by an extends value. Templates take
+// precedence over stored configs of the same name. Stored configs are
+// labelled with their current revision.
+func (a *Analyzer) loadBaseConfig(name string) ([]byte, string, error) {
+	if !validConfigName(name) {
+		return nil, "", fmt.Errorf("invalid config name: %q", name)
 	}
 
-	for _, templatePath := range possiblePaths {
+	if data, source, err := a.loadTemplate(name); err == nil {
+		return data, source, nil
+	}
+	if data, source, err := a.loadStoredConfig(name); err == nil {
+		return data, source, nil
+	}
+	return nil, "", fmt.Errorf("no template or stored config named %s", name)
+}
+
+// TemplatePath returns the path of a predefined config template
+func (a *Analyzer) TemplatePath(standard string) (string, error) {
+	if !validConfigName(standard) {
+		return "", fmt.Errorf("invalid template name: %q", standard)
+	}
+
+	// Try multiple paths to find the config file
+	for _, dir := range templateDirs() {
+		templatePath := filepath.Join(dir, fmt.Sprintf("%s.yaml", standard))
 		if _, err := os.Stat(templatePath); err == nil {
 			return templatePath, nil
 		}
@@ -195,6 +494,74 @@ func (a *Analyzer) loadConfig(standard, customConfig string) (string, error) {
 	return "", fmt.Errorf("template not found: %s (tried: configs/templates/%s.yaml)", standard, standard)
 }
 
+// templateDirs returns the directories templates are looked for in
+func templateDirs() []string {
+	return []string{
+		filepath.Join("configs", "templates"),
+		filepath.Join("..", "configs", "templates"),
+		filepath.Join(getExecutableDir(), "..", "configs", "templates"),
+	}
+}
+
+// validConfigName reports whether name can be used as a template or
+// stored config name without escaping its directory
+func validConfigName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, `/\`) && !strings.Contains(name, "..")
+}
+
+// migrateLintConfig returns a golangci-lint v2 version of the config if
+// the installed golangci-lint is v2 and the config uses the v1 layout
+func (a *Analyzer) migrateLintConfig(ctx context.Context, configPath string) (string, error) {
+	golangci, ok := a.linters["golangci-lint"].(*linters.GolangciLint)
+	if !ok || configPath == "" {
+		return configPath, nil
+	}
+
+	major, err := golangci.MajorVersion(ctx)
+	if err != nil {
+		a.logger.Warn("Failed to detect golangci-lint version", zap.Error(err))
+		return configPath, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config: %w", err)
+	}
+	version, err := lintconfig.Version(data)
+	if err != nil {
+		return "", err
+	}
+
+	if major < 2 {
+		if version >= 2 {
+			a.logger.Warn("Config uses the golangci-lint v2 layout but v1 is installed",
+				zap.String("config", configPath))
+		}
+		return configPath, nil
+	}
+	if version >= 2 {
+		return configPath, nil
+	}
+
+	migration, err := lintconfig.Migrate(data)
+	if err != nil {
+		return "", err
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256(migration.Content))
+	migratedPath := filepath.Join(a.config.Analyzer.TempDir, fmt.Sprintf("config-%s-v2.yaml", hash[:8]))
+	if err := os.WriteFile(migratedPath, migration.Content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write migrated config: %w", err)
+	}
+
+	a.logger.Info("Migrated config to golangci-lint v2 layout",
+		zap.String("config", configPath),
+		zap.Int("changes", len(migration.Changes)),
+		zap.Strings("warnings", migration.Warnings))
+
+	return migratedPath, nil
+}
+
 // getExecutableDir returns the directory of the executable
 func getExecutableDir() string {
 	ex, err := os.Executable()
@@ -204,24 +571,373 @@ func getExecutableDir() string {
 	return filepath.Dir(ex)
 }
 
-// runLinters runs all configured linters
-func (a *Analyzer) runLinters(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
+// runModules runs the linters in each module root and merges the results,
+// rewriting file paths to be relative to the project root. Without modules
+// the linters run directly in workDir.
+func (a *Analyzer) runModules(ctx context.Context, workDir, configPath string, modules []workspace.Module) ([]models.Issue, []models.LinterRun) {
+	if len(modules) == 0 {
+		return a.runLinters(ctx, workDir, configPath)
+	}
+
 	var allIssues []models.Issue
+	var allRuns []models.LinterRun
 
-	for name, linter := range a.linters {
-		a.logger.Debug("Running linter", zap.String("linter", name))
+	for _, module := range modules {
+		a.logger.Debug("Analyzing module",
+			zap.String("module", module.Path),
+			zap.String("dir", module.RelDir))
 
-		issues, err := linter.Run(ctx, workDir, configPath)
-		if err != nil {
-			a.logger.Warn("Linter failed", zap.String("linter", name), zap.Error(err))
-			continue
+		issues, runs := a.runLinters(ctx, module.Dir, configPath)
+		for i := range issues {
+			issues[i].Module = module.Path
+			issues[i].ModuleFile = filepath.ToSlash(filepath.Clean(issues[i].File))
+			issues[i].File = module.RepoPath(issues[i].File)
+			for j := range issues[i].Related {
+				issues[i].Related[j].File = module.RepoPath(issues[i].Related[j].File)
+			}
+			for j := range issues[i].Fixes {
+				for k := range issues[i].Fixes[j].Edits {
+					edit := &issues[i].Fixes[j].Edits[k]
+					edit.File = module.RepoPath(edit.File)
+				}
+			}
+		}
+		for i := range runs {
+			runs[i].Module = module.Path
 		}
 
 		allIssues = append(allIssues, issues...)
-		a.logger.Debug("Linter completed", zap.String("linter", name), zap.Int("issues", len(issues)))
+		allRuns = append(allRuns, runs...)
+	}
+
+	return allIssues, allRuns
+}
+
+// runLinters runs all configured linters and records the outcome of each
+func (a *Analyzer) runLinters(ctx context.Context, workDir, configPath string) ([]models.Issue, []models.LinterRun) {
+	var allIssues []models.Issue
+	runs := make([]models.LinterRun, 0, len(a.linters))
+
+	for _, name := range a.getToolNames() {
+		issues, run := a.runLinter(ctx, name, a.linters[name], workDir, configPath)
+		allIssues = append(allIssues, issues...)
+		runs = append(runs, run)
+	}
+
+	return allIssues, runs
+}
+
+// standaloneLinters lists the linters whose checks golangci-lint also runs.
+// They serve projects without golangci-lint and are skipped when it is
+// active, so the template's linter set and settings stay in charge.
+var standaloneLinters = map[string]bool{
+	"staticcheck": true,
+	"gosec":       true,
+}
+
+// deferredToGolangci reports whether a linter is skipped because
+// golangci-lint is active and runs its checks as the template configures
+func (a *Analyzer) deferredToGolangci(name string) bool {
+	_, active := a.linters["golangci-lint"]
+	return active && standaloneLinters[name]
+}
+
+// runLinter runs a single linter with its timeout applied
+func (a *Analyzer) runLinter(ctx context.Context, name string, linter linters.Linter, workDir, configPath string) ([]models.Issue, models.LinterRun) {
+	a.logger.Debug("Running linter", zap.String("linter", name))
+
+	linterCtx := ctx
+	if timeout := a.linterTimeout(name); timeout > 0 {
+		var cancel context.CancelFunc
+		linterCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	issues, err := linter.Run(linterCtx, workDir, configPath)
+	run := models.LinterRun{
+		Name:     name,
+		Status:   "ok",
+		Version:  a.linterVersion(ctx, name, linter),
+		Duration: time.Since(start),
+		Issues:   len(issues),
+	}
+
+	if err != nil {
+		run.Status = "failed"
+		if errors.Is(err, context.DeadlineExceeded) {
+			run.Status = "timeout"
+		}
+		run.Error = err.Error()
+		run.Limit = a.exceededLimit(err)
+
+		var runErr *linters.RunError
+		if errors.As(err, &runErr) {
+			run.ExitCode = runErr.ExitCode
+			run.Stderr = runErr.Stderr
+		}
+
+		a.logger.Warn("Linter failed",
+			zap.String("linter", name),
+			zap.String("status", run.Status),
+			zap.Error(err))
+		return nil, run
+	}
+
+	a.logger.Debug("Linter completed", zap.String("linter", name), zap.Int("issues", len(issues)))
+	return issues, run
+}
+
+// linterTimeout returns the per-linter timeout, or zero if none is configured
+func (a *Analyzer) linterTimeout(name string) time.Duration {
+	switch name {
+	case "golangci-lint":
+		return a.config.Linters.GolangciLint.Timeout
+	case "govulncheck":
+		return a.config.Linters.Govulncheck.Timeout
+	}
+	if plugin, ok := a.linters[name].(*linters.Plugin); ok {
+		return plugin.Timeout()
+	}
+	return 0
+}
+
+// linterVersion returns the cached version of a linter, probed with the
+// version command of its registration if it has one
+func (a *Analyzer) linterVersion(ctx context.Context, name string, linter linters.Linter) string {
+	a.versions.mu.Lock()
+	defer a.versions.mu.Unlock()
+
+	if version, ok := a.versions.versions[name]; ok {
+		return version
+	}
+
+	reg, ok := a.registry.Get(name)
+	if !ok {
+		return ""
+	}
+	command := reg.CapabilitiesFor(a.linterOptions()).VersionCommand
+	if len(command) == 0 {
+		return ""
+	}
+
+	parse := linters.ParseVersion
+	if parser, ok := linter.(linters.VersionParser); ok {
+		parse = parser.ParseVersion
+	}
+
+	version, err := linters.ProbeVersion(ctx, command, parse)
+	if err != nil {
+		a.logger.Debug("Failed to get linter version", zap.String("linter", name), zap.Error(err))
+		return ""
+	}
+
+	a.versions.versions[name] = version
+	return version
+}
+
+// goVersion returns the cached version of the Go toolchain used for analysis
+func (a *Analyzer) goVersion(ctx context.Context) string {
+	a.versions.mu.Lock()
+	defer a.versions.mu.Unlock()
+
+	if version, ok := a.versions.versions["go"]; ok {
+		return version
+	}
+
+	version, err := linters.GoVersion(ctx)
+	if err != nil {
+		a.logger.Debug("Failed to get Go version", zap.Error(err))
+		return ""
+	}
+
+	a.versions.versions["go"] = version
+	return version
+}
+
+// toolsUsed returns the tools that ran as name@version, sorted and without
+// duplicates from multi-module runs
+func toolsUsed(runs []models.LinterRun) []string {
+	seen := make(map[string]bool)
+	tools := make([]string, 0, len(runs))
+	for _, run := range runs {
+		tool := run.Name
+		if run.Version != "" {
+			tool += "@" + run.Version
+		}
+		if !seen[tool] {
+			seen[tool] = true
+			tools = append(tools, tool)
+		}
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// sandbox returns the sandbox for linter and test runs, or nil if it is
+// disabled. It is always enabled in http mode, where analysis requests
+// come from the network.
+func (a *Analyzer) sandbox() *linters.Sandbox {
+	sb := a.config.Analyzer.Sandbox
+	if !sb.Enabled && a.config.Server.Mode != "http" {
+		return nil
+	}
+	sb.Enabled = true
+	if sb.RootDir == "" {
+		sb.RootDir = a.config.Analyzer.TempDir
+	}
+	return &sb
+}
+
+// exceededLimit returns the sandbox limit that stopped a run, if any.
+// Timeouts count as the time limit when the sandbox is enabled.
+func (a *Analyzer) exceededLimit(err error) string {
+	var limitErr *linters.LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.Limit
+	}
+	if errors.Is(err, context.DeadlineExceeded) && a.sandbox() != nil {
+		return linters.LimitTime
+	}
+	return ""
+}
+
+// limitIssues reports runs stopped by a sandbox limit as issues, so they
+// are visible in the results rather than only in the linter runs
+func limitIssues(runs []models.LinterRun) []models.Issue {
+	var issues []models.Issue
+	for _, run := range runs {
+		if run.Limit == "" {
+			continue
+		}
+		issues = append(issues, models.Issue{
+			Severity:    "error",
+			Category:    "sandbox",
+			Subcategory: run.Limit,
+			Rule:        "sandbox-limit",
+			Message:     fmt.Sprintf("%s exceeded the sandbox %s limit: %s", run.Name, run.Limit, run.Error),
+			Source:      run.Name,
+			Module:      run.Module,
+		})
+	}
+	return issues
+}
+
+// overallStatus derives the analysis status from the linter outcomes:
+// success if all linters completed, partial if some did, error if none did
+func overallStatus(runs []models.LinterRun) string {
+	ok := 0
+	for _, run := range runs {
+		if run.Status == "ok" {
+			ok++
+		}
+	}
+
+	switch {
+	case ok == len(runs):
+		return "success"
+	case ok > 0:
+		return "partial"
+	default:
+		return "error"
+	}
+}
+
+// applyPolicy applies the severity and category overrides. Rules are
+// evaluated in order: the project's standards file, the server's rules for
+// the standard, the policy declared next to the template, then the server's
+// global rules.
+func (a *Analyzer) applyPolicy(issues []models.Issue, standard string, projectRules []policy.Rule) error {
+	templateRules, err := a.templatePolicy(standard)
+	if err != nil {
+		return err
+	}
+	p, err := policy.New(projectRules, a.config.Policy.Standards[standard], templateRules, a.config.Policy.Rules)
+	if err != nil {
+		return err
+	}
+
+	if changed := p.Apply(issues); changed > 0 {
+		a.logger.Debug("Policy applied", zap.Int("changed", changed))
+	}
+	return nil
+}
+
+// applySuppressions removes issues silenced by //standards:ignore directives
+func (a *Analyzer) applySuppressions(issues []models.Issue, workDir string) ([]models.Issue, []models.SuppressedIssue) {
+	cfg := a.config.Analyzer.Suppression
+	if !cfg.Enabled {
+		return issues, nil
+	}
+
+	s := suppression.NewSuppressor(workDir, suppression.Policy{
+		RequireReason:       cfg.RequireReason,
+		ForbiddenCategories: cfg.ForbiddenCategories,
+	})
+	kept, suppressed := s.Apply(issues)
+
+	if len(suppressed) > 0 {
+		a.logger.Debug("Suppressed issues",
+			zap.Int("count", len(suppressed)))
+	}
+
+	return kept, suppressed
+}
+
+// resolveBaselinePath returns the baseline file to use for the request, or
+// an empty string if baselining does not apply
+func (a *Analyzer) resolveBaselinePath(req *models.AnalysisRequest) string {
+	if req.Baseline != "" {
+		return req.Baseline
+	}
+	if req.ProjectDir == "" {
+		return ""
 	}
 
-	return allIssues, nil
+	path := baseline.DefaultPath(req.ProjectDir)
+	if req.UpdateBaseline {
+		return path
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// applyBaseline fingerprints the issues and splits them into new and
+// baselined issues. When update is set, the baseline is first rewritten
+// from the current issues.
+func (a *Analyzer) applyBaseline(issues []models.Issue, workDir, path string, update bool) ([]models.Issue, []models.Issue, error) {
+	baseline.Annotate(issues, workDir)
+
+	if path == "" {
+		if update {
+			return nil, nil, fmt.Errorf("baseline path is required when analyzing a code snippet")
+		}
+		return issues, nil, nil
+	}
+
+	if update {
+		if err := baseline.New(issues, workDir).Save(path); err != nil {
+			return nil, nil, err
+		}
+		a.logger.Info("Baseline updated",
+			zap.String("path", path),
+			zap.Int("issues", len(issues)))
+	}
+
+	b, err := baseline.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fresh, baselined := b.Filter(issues, workDir)
+	a.logger.Debug("Baseline applied",
+		zap.String("path", path),
+		zap.Int("new", len(fresh)),
+		zap.Int("baselined", len(baselined)))
+
+	return fresh, baselined, nil
 }
 
 // calculateSummary calculates analysis summary statistics
@@ -254,19 +970,63 @@ func (a *Analyzer) calculateSummary(issues []models.Issue, workDir string, durat
 	}
 
 	// Calculate quality score (0-100)
-	// Simple scoring: start at 100, deduct points for issues
+	summary.Score = calculateScore(summary.ErrorCount, summary.WarningCount, summary.InfoCount)
+
+	return summary
+}
+
+// calculateScore computes the quality score (0-100)
+// Simple scoring: start at 100, deduct points for issues
+func calculateScore(errors, warnings, infos int) float64 {
 	score := 100.0
-	score -= float64(summary.ErrorCount) * 5.0
-	score -= float64(summary.WarningCount) * 2.0
-	score -= float64(summary.InfoCount) * 0.5
+	score -= float64(errors) * 5.0
+	score -= float64(warnings) * 2.0
+	score -= float64(infos) * 0.5
 
 	if score < 0 {
 		score = 0
 	}
 
-	summary.Score = score
+	return score
+}
 
-	return summary
+// moduleBreakdown summarizes issues per module
+func moduleBreakdown(issues []models.Issue, modules []workspace.Module) []models.ModuleSummary {
+	if len(modules) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(modules))
+	breakdown := make([]models.ModuleSummary, len(modules))
+	for i, module := range modules {
+		index[module.Path] = i
+		breakdown[i] = models.ModuleSummary{
+			Path: module.Path,
+			Dir:  module.RelDir,
+		}
+	}
+
+	for _, issue := range issues {
+		i, ok := index[issue.Module]
+		if !ok {
+			continue
+		}
+		breakdown[i].TotalIssues++
+		switch issue.Severity {
+		case "error":
+			breakdown[i].ErrorCount++
+		case "warning":
+			breakdown[i].WarningCount++
+		case "info":
+			breakdown[i].InfoCount++
+		}
+	}
+
+	for i := range breakdown {
+		breakdown[i].Score = calculateScore(breakdown[i].ErrorCount, breakdown[i].WarningCount, breakdown[i].InfoCount)
+	}
+
+	return breakdown
 }
 
 // countGoFiles counts the number of Go files in a directory
@@ -333,7 +1093,11 @@ func (a *Analyzer) generateSuggestions(issues []models.Issue) []models.Suggestio
 func (a *Analyzer) getToolNames() []string {
 	names := make([]string, 0, len(a.linters))
 	for name := range a.linters {
+		if a.deferredToGolangci(name) {
+			continue
+		}
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
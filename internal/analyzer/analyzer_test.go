@@ -2,10 +2,18 @@
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"go-standards-mcp-server/internal/config"
+	"go-standards-mcp-server/internal/git"
+	"go-standards-mcp-server/internal/pathguard"
+	"go-standards-mcp-server/pkg/linters"
 	"go-standards-mcp-server/pkg/models"
 	"go.uber.org/zap"
 )
@@ -115,3 +123,279 @@ func TestAnalyzer_calculateSummary(t *testing.T) {
 		t.Errorf("Score out of range: %f", summary.Score)
 	}
 }
+
+func TestOverallStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		runs []models.LinterRun
+		want string
+	}{
+		{"all ok", []models.LinterRun{{Status: "ok"}, {Status: "ok"}}, "success"},
+		{"one failed", []models.LinterRun{{Status: "ok"}, {Status: "failed"}}, "partial"},
+		{"all failed", []models.LinterRun{{Status: "timeout"}, {Status: "failed"}}, "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overallStatus(tt.runs); got != tt.want {
+				t.Errorf("overallStatus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolsUsed(t *testing.T) {
+	runs := []models.LinterRun{
+		{Name: "govet", Module: "a", Version: "1.24.1"},
+		{Name: "golangci-lint", Module: "a", Version: "2.1.6"},
+		{Name: "govet", Module: "b", Version: "1.24.1"},
+		{Name: "lint-plugin"},
+	}
+	want := []string{"golangci-lint@2.1.6", "govet@1.24.1", "lint-plugin"}
+	if got := toolsUsed(runs); !reflect.DeepEqual(got, want) {
+		t.Errorf("toolsUsed() = %v, want %v", got, want)
+	}
+}
+
+func TestProfileFor(t *testing.T) {
+	profiles := []*profile{{}, {dir: "pkg"}, {dir: "pkg/legacy"}, {dir: "cmd"}}
+
+	tests := []struct {
+		file string
+		want int
+	}{
+		{"main.go", 0},
+		{"pkg/a/a.go", 1},
+		{"pkg/legacy/old.go", 2},
+		{"pkg/legacyx/new.go", 1},
+		{"cmd/tool/main.go", 3},
+		{"cmdline/x.go", 0},
+	}
+
+	for _, tt := range tests {
+		if got := profileFor(tt.file, profiles); got != tt.want {
+			t.Errorf("profileFor(%q) = %d, want %d", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	a := &Analyzer{}
+	proj := &project{
+		root: "/repo",
+		standards: &git.Standards{
+			Standard:  "strict",
+			Overrides: []git.DirectoryStandard{{Path: "legacy/", Standard: "relaxed"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		req  models.AnalysisRequest
+		proj *project
+		want []profile
+	}{
+		{"default", models.AnalysisRequest{}, nil, []profile{{standard: "standard"}}},
+		{"inline config", models.AnalysisRequest{Standard: "custom", Config: "version: \"2\""}, nil, []profile{{standard: "custom", content: "version: \"2\""}}},
+		{"config ref", models.AnalysisRequest{ConfigRef: "team"}, proj, []profile{{standard: "custom", config: "team"}}},
+		{"config ref with standard", models.AnalysisRequest{Standard: "strict", ConfigRef: "team"}, nil, []profile{{standard: "strict", config: "team"}}},
+		{"standards file", models.AnalysisRequest{}, proj, []profile{{standard: "strict", root: "/repo"}, {dir: "legacy", standard: "relaxed", root: "/repo"}}},
+	}
+
+	for _, tt := range tests {
+		var got []profile
+		for _, p := range a.profiles(&tt.req, tt.proj) {
+			got = append(got, *p)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: profiles() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// recordingLinter reports fixed issues and keeps the config it ran with
+type recordingLinter struct {
+	issues []models.Issue
+	config []byte
+}
+
+func (l *recordingLinter) Name() string      { return "golangci-lint" }
+func (l *recordingLinter) IsAvailable() bool { return true }
+
+func (l *recordingLinter) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	l.config = data
+	return append([]models.Issue(nil), l.issues...), nil
+}
+
+func TestAnalyze_AllIssues(t *testing.T) {
+	project := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.21\n",
+		"main.go": "package main\n\nfunc main() {\n\tf() //standards:ignore errcheck -- best effort\n\tg()\n\th()\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fake := &recordingLinter{issues: []models.Issue{
+		{File: "main.go", Line: 4, Rule: "errcheck", Source: "golangci-lint", Message: "f not checked", Severity: "warning"},
+		{File: "main.go", Line: 5, Rule: "errcheck", Source: "golangci-lint", Message: "g not checked", Severity: "warning"},
+		{File: "main.go", Line: 6, Rule: "errcheck", Source: "golangci-lint", Message: "h not checked", Severity: "warning"},
+	}}
+	cfg := &config.Config{}
+	cfg.Analyzer.TempDir = t.TempDir()
+	cfg.Analyzer.Suppression.Enabled = true
+	guard, err := pathguard.New(pathguard.Config{}, false, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Analyzer{
+		config:    cfg,
+		logger:    zap.NewNop(),
+		linters:   map[string]linters.Linter{fake.Name(): fake},
+		registry:  linters.NewRegistry(),
+		guard:     guard,
+		templates: map[string][]byte{"capped": []byte("version: \"2\"\nissues:\n  max-issues-per-linter: 1\n  max-same-issues: 1\n")},
+		versions:  &versionCache{versions: map[string]string{"go": "go1.24"}},
+		mu:        &sync.RWMutex{},
+	}
+	analyze := func(req models.AnalysisRequest) *models.AnalysisResult {
+		t.Helper()
+		req.ProjectDir = project
+		req.Standard = "capped"
+		result, err := a.Analyze(context.Background(), &req)
+		if err != nil {
+			t.Fatalf("Analyze() error = %v", err)
+		}
+		return result
+	}
+
+	// f is suppressed inline; record g and h in the baseline
+	analyze(models.AnalysisRequest{UpdateBaseline: true})
+	if result := analyze(models.AnalysisRequest{}); len(result.Issues) != 0 || len(result.Suppressed) != 1 || len(result.Baselined) != 2 {
+		t.Fatalf("capped analysis = %d issues, %d suppressed, %d baselined; want 0, 1, 2",
+			len(result.Issues), len(result.Suppressed), len(result.Baselined))
+	}
+	if !strings.Contains(string(fake.config), "max-same-issues: 1") {
+		t.Errorf("capped analysis ran with\n%s\nwant the template's caps", fake.config)
+	}
+
+	result := analyze(models.AnalysisRequest{AllIssues: true})
+	if len(result.Issues) != 3 || len(result.Suppressed) != 0 || len(result.Baselined) != 0 {
+		t.Errorf("all issues = %d issues, %d suppressed, %d baselined; want 3, 0, 0",
+			len(result.Issues), len(result.Suppressed), len(result.Baselined))
+	}
+	for _, want := range []string{"max-issues-per-linter: 0", "max-same-issues: 0"} {
+		if !strings.Contains(string(fake.config), want) {
+			t.Errorf("all issues ran with\n%s\nwant %s", fake.config, want)
+		}
+	}
+}
+
+// namedLinter reports fixed issues under its name
+type namedLinter struct {
+	name   string
+	issues []models.Issue
+	ran    bool
+}
+
+func (l *namedLinter) Name() string      { return l.name }
+func (l *namedLinter) IsAvailable() bool { return true }
+
+func (l *namedLinter) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
+	l.ran = true
+	return append([]models.Issue(nil), l.issues...), nil
+}
+
+func TestRunLinters_Standalone(t *testing.T) {
+	tests := []struct {
+		name       string
+		golangci   bool
+		wantRan    bool
+		wantIssues []string // Rule of each issue
+	}{
+		// The template enables stylecheck but not gosec, so only the
+		// stylecheck finding is reported and standalone gosec does not run
+		{"golangci-lint active", true, false, []string{"stylecheck"}},
+		{"without golangci-lint", false, true, []string{"G104"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gosec := &namedLinter{name: "gosec", issues: []models.Issue{{Rule: "G104", Source: "gosec"}}}
+			a := &Analyzer{
+				config:   &config.Config{},
+				logger:   zap.NewNop(),
+				linters:  map[string]linters.Linter{"gosec": gosec},
+				versions: &versionCache{versions: map[string]string{"gosec": "2.18.2", "golangci-lint": "2.1.0"}},
+			}
+			if tt.golangci {
+				a.linters["golangci-lint"] = &namedLinter{
+					name:   "golangci-lint",
+					issues: []models.Issue{{Rule: "stylecheck", Source: "golangci-lint"}},
+				}
+			}
+
+			issues, _ := a.runLinters(context.Background(), t.TempDir(), "")
+			if gosec.ran != tt.wantRan {
+				t.Errorf("standalone gosec ran = %v, want %v", gosec.ran, tt.wantRan)
+			}
+			var rules []string
+			for _, issue := range issues {
+				rules = append(rules, issue.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantIssues) {
+				t.Errorf("issues = %v, want %v", rules, tt.wantIssues)
+			}
+		})
+	}
+}
+
+func TestListLinters_VersionCommand(t *testing.T) {
+	// The fake tool prints its version to stderr, like some real linters
+	command := filepath.Join(t.TempDir(), "fakelint")
+	script := "#!/bin/sh\n[ \"$1\" = --version ] && echo \"fakelint v1.2.3\" >&2\n"
+	if err := os.WriteFile(command, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := linters.NewRegistry()
+	if err := registry.Register(linters.PluginRegistration(linters.PluginSpec{
+		Name:        "fakelint",
+		Command:     command,
+		Format:      linters.PluginFormatRegex,
+		Pattern:     `^(?P<file>[^:]+):(?P<line>\d+): (?P<message>.*)$`,
+		VersionArgs: []string{"--version"},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	a := &Analyzer{
+		config:   &config.Config{},
+		logger:   zap.NewNop(),
+		linters:  map[string]linters.Linter{},
+		registry: registry,
+		versions: &versionCache{versions: map[string]string{}},
+		mu:       &sync.RWMutex{},
+	}
+
+	for _, info := range a.ListLinters(context.Background()) {
+		if info.Name != "fakelint" {
+			continue
+		}
+		wantCommand := []string{command, "--version"}
+		if !reflect.DeepEqual(info.Capabilities.VersionCommand, wantCommand) {
+			t.Errorf("VersionCommand = %v, want %v", info.Capabilities.VersionCommand, wantCommand)
+		}
+		if info.Version != "1.2.3" {
+			t.Errorf("Version = %q, want 1.2.3 (error: %s)", info.Version, info.Error)
+		}
+		return
+	}
+	t.Error("fakelint not listed")
+}
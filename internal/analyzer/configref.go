@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-standards-mcp-server/internal/lintconfig"
+)
+
+// projectConfigNames are the golangci-lint config files looked for in a
+// project directory, in golangci-lint's order of precedence
+var projectConfigNames = []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"}
+
+// LoadConfigRef loads the lint config a reference names: a stored config,
+// a template, or a path to a config file or to a directory containing one,
+// tried in that order. A "config:" or "template:" prefix selects one kind.
+// Paths are checked against the allowed roots, and TOML and JSON
+// files are converted to YAML.
+func (a *Analyzer) LoadConfigRef(ref string) ([]byte, string, error) {
+	a = a.snapshot()
+	if ref == "" {
+		return nil, "", fmt.Errorf("config reference cannot be empty")
+	}
+
+	if name, ok := strings.CutPrefix(ref, "config:"); ok {
+		return a.loadStoredConfig(name)
+	}
+	if name, ok := strings.CutPrefix(ref, "template:"); ok {
+		return a.loadTemplate(name)
+	}
+
+	if validConfigName(ref) && !strings.HasPrefix(ref, ".") {
+		if data, source, err := a.loadStoredConfig(ref); err == nil {
+			return data, source, nil
+		}
+		if data, source, err := a.loadTemplate(ref); err == nil {
+			return data, source, nil
+		}
+	}
+
+	path, err := a.guard.Resolve("load config", ref)
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", a.notFound(ref, path)
+		}
+		return nil, "", fmt.Errorf("failed to access config: %w", err)
+	}
+	if info.IsDir() {
+		found := findProjectConfigs(path)
+		if len(found) == 0 {
+			return nil, "", fmt.Errorf("no golangci-lint config found in %s", ref)
+		}
+		path = found[0]
+	}
+	return readConfigFile(path)
+}
+
+// findProjectConfigs returns the golangci-lint config files in dir, in
+// order of precedence
+func findProjectConfigs(dir string) []string {
+	var found []string
+	for _, name := range projectConfigNames {
+		candidate := filepath.Join(dir, name)
+		if _, err := os.Stat(candidate); err == nil {
+			found = append(found, candidate)
+		}
+	}
+	return found
+}
+
+// readConfigFile reads a golangci-lint config file as YAML
+func readConfigFile(path string) ([]byte, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read config: %w", err)
+	}
+	data, err = lintconfig.ToYAML(data, lintconfig.FormatOf(path))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return data, path, nil
+}
+
+// notFound explains where a config reference was looked for
+func (a *Analyzer) notFound(ref, path string) error {
+	var tried []string
+	if validConfigName(ref) && !strings.HasPrefix(ref, ".") {
+		if a.configs != nil {
+			tried = append(tried, "stored configs")
+		}
+		tried = append(tried, "templates")
+	}
+	tried = append(tried, "file "+path)
+	return fmt.Errorf("config %q not found (tried %s)", ref, strings.Join(tried, ", "))
+}
+
+// EffectiveConfig returns the config that inline content or, if content
+// is empty, a reference resolves to, merged with the configs it extends
+func (a *Analyzer) EffectiveConfig(ref, content string) ([]byte, string, error) {
+	a = a.snapshot()
+	data, source := []byte(content), "content"
+	if content == "" {
+		var err error
+		data, source, err = a.LoadConfigRef(ref)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	resolution, err := a.ResolveConfig(data, source)
+	if err != nil {
+		return nil, "", err
+	}
+	return resolution.Content, source, nil
+}
+
+// loadStoredConfig loads a config from the config store, labelled with
+// its revision
+func (a *Analyzer) loadStoredConfig(name string) ([]byte, string, error) {
+	if !validConfigName(name) {
+		return nil, "", fmt.Errorf("invalid config name: %q", name)
+	}
+	if a.configs == nil {
+		return nil, "", fmt.Errorf("no config store available for config %s", name)
+	}
+	stored, err := a.configs.Get(name)
+	if err != nil {
+		return nil, "", err
+	}
+	return []byte(stored.Content), fmt.Sprintf("config:%s@%d", name, stored.Revision), nil
+}
+
+// loadTemplate loads a predefined config template
+func (a *Analyzer) loadTemplate(name string) ([]byte, string, error) {
+	if a.templates != nil {
+		data, ok := a.templates[name]
+		if !ok {
+			return nil, "", fmt.Errorf("template not found: %s", name)
+		}
+		return data, "template:" + name, nil
+	}
+
+	path, err := a.TemplatePath(name)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read template: %w", err)
+	}
+	return data, "template:" + name, nil
+}
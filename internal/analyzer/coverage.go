@@ -0,0 +1,260 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-standards-mcp-server/internal/coverage"
+	"go-standards-mcp-server/internal/workspace"
+	"go-standards-mcp-server/pkg/linters"
+	"go-standards-mcp-server/pkg/models"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// coverageStageName is the name the coverage stage is recorded under
+const coverageStageName = "coverage"
+
+// coverageEnabled reports whether the coverage stage applies to the request
+func (a *Analyzer) coverageEnabled(req *models.AnalysisRequest) bool {
+	if req.CoverProfile != "" {
+		return true
+	}
+	return req.ProjectDir != "" && (req.Coverage || a.config.Analyzer.Coverage.Enabled)
+}
+
+// runCoverage measures test coverage for each module, either by running
+// go test or from an existing profile, and reports uncovered functions and
+// threshold violations as issues
+func (a *Analyzer) runCoverage(ctx context.Context, req *models.AnalysisRequest, workDir string, modules []workspace.Module) ([]models.Issue, *models.CoverageSummary, []models.LinterRun) {
+	targets := modules
+	if len(targets) == 0 {
+		// Without a go.mod functions cannot be resolved, but package and
+		// total coverage from a supplied profile are still meaningful
+		targets = []workspace.Module{{Dir: workDir, RelDir: "."}}
+	}
+
+	var profile *coverage.Profile
+	if req.CoverProfile != "" {
+		var err error
+		profile, err = coverage.LoadProfile(req.CoverProfile)
+		if err != nil {
+			return nil, nil, []models.LinterRun{{
+				Name:   coverageStageName,
+				Status: "failed",
+				Error:  err.Error(),
+			}}
+		}
+	}
+
+	// go test runs the project's own code, which in http mode must not get
+	// network access or act as the server's user
+	if profile == nil && a.config.Server.Mode == "http" && !a.config.Analyzer.Sandbox.Isolate {
+		return nil, nil, []models.LinterRun{{
+			Name:   coverageStageName,
+			Status: "failed",
+			Error:  "running tests in http mode requires analyzer.sandbox.isolate; supply cover_profile instead",
+		}}
+	}
+
+	// A supplied profile may cover nested modules, whose files must only
+	// count for the innermost one
+	var moduleProfiles map[string]*coverage.Profile
+	if profile != nil && len(modules) > 0 {
+		modulePaths := make([]string, 0, len(modules))
+		for _, module := range modules {
+			modulePaths = append(modulePaths, module.Path)
+		}
+		moduleProfiles = coverage.Split(profile, modulePaths)
+	}
+
+	var runs []models.LinterRun
+	var reports []moduleCoverage
+	for _, module := range targets {
+		moduleProfile := profile
+		if moduleProfiles != nil {
+			moduleProfile = moduleProfiles[module.Path]
+		}
+		if moduleProfile == nil {
+			var run models.LinterRun
+			moduleProfile, run = a.runModuleTests(ctx, module)
+			runs = append(runs, run)
+			if moduleProfile == nil {
+				continue
+			}
+		}
+
+		reports = append(reports, moduleCoverage{
+			module: module,
+			report: coverage.Compute(moduleProfile, module.Dir, module.Path),
+		})
+	}
+
+	threshold, hasThreshold := a.coverageThreshold(req.Standard)
+	issues, summary := coverageIssues(reports, threshold, hasThreshold)
+
+	a.logger.Debug("Coverage computed",
+		zap.Float64("total", summary.Total),
+		zap.Float64("threshold", threshold),
+		zap.Int("uncovered_functions", summary.UncoveredFunctions))
+
+	return issues, summary, runs
+}
+
+// moduleCoverage pairs a module with its coverage report
+type moduleCoverage struct {
+	module workspace.Module
+	report *coverage.Report
+}
+
+// runModuleTests runs go test with coverage in a module and loads the
+// resulting profile
+func (a *Analyzer) runModuleTests(ctx context.Context, module workspace.Module) (*coverage.Profile, models.LinterRun) {
+	run := models.LinterRun{
+		Name:    coverageStageName,
+		Module:  module.Path,
+		Status:  "ok",
+		Version: a.goVersion(ctx),
+	}
+
+	if timeout := a.config.Analyzer.Coverage.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	profilePath, err := filepath.Abs(filepath.Join(a.config.Analyzer.TempDir, fmt.Sprintf("cover-%s.out", uuid.New().String())))
+	if err != nil {
+		run.Status = "failed"
+		run.Error = err.Error()
+		return nil, run
+	}
+	defer os.Remove(profilePath)
+
+	start := time.Now()
+	output, err := coverage.RunTests(ctx, module.Dir, profilePath)
+	run.Duration = time.Since(start)
+
+	// Failing tests still produce a profile, but the stage is not clean
+	if err != nil {
+		run.Status = "failed"
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			run.Status = "timeout"
+		}
+		run.Error = fmt.Sprintf("go test failed: %v", err)
+		run.Limit = a.exceededLimit(err)
+		run.Stderr = linters.Excerpt(output, linters.MaxStderrExcerpt)
+		a.logger.Warn("Coverage tests failed",
+			zap.String("module", module.Path),
+			zap.Error(err))
+	}
+
+	profile, err := coverage.LoadProfile(profilePath)
+	if err != nil {
+		if run.Status == "ok" {
+			run.Status = "failed"
+			run.Error = err.Error()
+		}
+		return nil, run
+	}
+
+	return profile, run
+}
+
+// coverageThreshold returns the minimum coverage required by the standard
+func (a *Analyzer) coverageThreshold(standard string) (float64, bool) {
+	threshold, ok := a.config.Analyzer.Coverage.Thresholds[strings.ToLower(standard)]
+	return threshold, ok
+}
+
+// coverageIssues turns coverage reports into issues and a summary
+func coverageIssues(reports []moduleCoverage, threshold float64, hasThreshold bool) ([]models.Issue, *models.CoverageSummary) {
+	summary := &models.CoverageSummary{
+		Threshold: threshold,
+		Packages:  []models.PackageCoverage{},
+	}
+	var issues []models.Issue
+
+	for _, mc := range reports {
+		summary.Statements += mc.report.Statements
+		summary.Covered += mc.report.Covered
+
+		for _, pkg := range mc.report.Packages {
+			summary.Packages = append(summary.Packages, models.PackageCoverage{
+				Path:       pkg.Path,
+				Coverage:   pkg.Percent(),
+				Statements: pkg.Statements,
+				Covered:    pkg.Covered,
+			})
+
+			if hasThreshold && pkg.Statements > 0 && pkg.Percent() < threshold {
+				issues = append(issues, models.Issue{
+					File:     packageDir(mc.module, pkg.Path),
+					Severity: "warning",
+					Category: "testing",
+					Rule:     "package-coverage",
+					Message:  fmt.Sprintf("package %s coverage %.1f%% is below the required %.0f%%", pkg.Path, pkg.Percent(), threshold),
+					Source:   coverageStageName,
+					Module:   mc.module.Path,
+				})
+			}
+		}
+
+		for _, fn := range mc.report.Functions {
+			if fn.Statements == 0 || fn.Covered > 0 {
+				continue
+			}
+			summary.UncoveredFunctions++
+			issues = append(issues, models.Issue{
+				File:       mc.module.RepoPath(fn.File),
+				Line:       fn.Line,
+				Severity:   "info",
+				Category:   "testing",
+				Rule:       "uncovered-function",
+				Message:    fmt.Sprintf("function %s is not covered by tests", fn.Name),
+				Source:     coverageStageName,
+				Module:     mc.module.Path,
+				ModuleFile: fn.File,
+			})
+		}
+	}
+
+	summary.Total = 100
+	if summary.Statements > 0 {
+		summary.Total = float64(summary.Covered) / float64(summary.Statements) * 100
+	}
+	summary.Passed = !hasThreshold || summary.Total >= threshold
+
+	if !summary.Passed {
+		issues = append(issues, models.Issue{
+			Severity: "error",
+			Category: "testing",
+			Rule:     "coverage-threshold",
+			Message:  fmt.Sprintf("total coverage %.1f%% is below the required %.0f%%", summary.Total, threshold),
+			Source:   coverageStageName,
+		})
+	}
+
+	return issues, summary
+}
+
+// packageDir maps a package import path to its directory relative to the
+// project root, falling back to the import path
+func packageDir(module workspace.Module, pkgPath string) string {
+	if module.Path == "" {
+		return pkgPath
+	}
+	if pkgPath == module.Path {
+		return module.RepoPath(".")
+	}
+	if rel := strings.TrimPrefix(pkgPath, module.Path+"/"); rel != pkgPath {
+		return module.RepoPath(rel)
+	}
+	return pkgPath
+}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-standards-mcp-server/internal/config"
+	"go-standards-mcp-server/internal/workspace"
+	"go-standards-mcp-server/pkg/models"
+	"go.uber.org/zap"
+)
+
+func TestRunCoverageRequiresIsolation(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.Mode = "http"
+	a := &Analyzer{config: cfg, logger: zap.NewNop(), mu: &sync.RWMutex{}}
+
+	req := &models.AnalysisRequest{ProjectDir: t.TempDir(), Coverage: true}
+	issues, summary, runs := a.runCoverage(context.Background(), req, req.ProjectDir, nil)
+	if len(issues) != 0 || summary != nil {
+		t.Errorf("runCoverage() = %v, %v; want no coverage without isolation", issues, summary)
+	}
+	if len(runs) != 1 || runs[0].Status != "failed" || !strings.Contains(runs[0].Error, "analyzer.sandbox.isolate") {
+		t.Errorf("runs = %+v, want one failed run naming analyzer.sandbox.isolate", runs)
+	}
+}
+
+func TestRunCoverageNestedModules(t *testing.T) {
+	project := t.TempDir()
+	profile := filepath.Join(project, "cover.out")
+	content := "mode: set\nexample.com/app/main.go:3.13,5.2 2 1\nexample.com/app/tools/gen.go:3.13,5.2 3 0\n"
+	if err := os.WriteFile(profile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modules := []workspace.Module{
+		{Path: "example.com/app", Dir: project, RelDir: "."},
+		{Path: "example.com/app/tools", Dir: filepath.Join(project, "tools"), RelDir: "tools"},
+	}
+	a := &Analyzer{config: &config.Config{}, logger: zap.NewNop(), mu: &sync.RWMutex{}}
+
+	req := &models.AnalysisRequest{ProjectDir: project, CoverProfile: profile}
+	_, summary, _ := a.runCoverage(context.Background(), req, project, modules)
+	if summary == nil || summary.Statements != 5 || summary.Covered != 2 {
+		t.Errorf("summary = %+v, want 5 statements with 2 covered, each counted once", summary)
+	}
+}
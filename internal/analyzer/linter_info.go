@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"context"
+
+	"go-standards-mcp-server/pkg/linters"
+)
+
+// LinterInfo describes a registered linter and its state on this server
+type LinterInfo struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Enabled      bool                 `json:"enabled"`   // Enabled in the server config
+	Available    bool                 `json:"available"` // Tool found on this machine
+	Active       bool                 `json:"active"`    // Runs during analysis
+	Version      string               `json:"version,omitempty"`
+	Error        string               `json:"error,omitempty"` // Why the linter is unavailable
+	Capabilities linters.Capabilities `json:"capabilities"`
+}
+
+// ListLinters reports every registered linter with its availability,
+// version and capabilities. Disabled linters are probed too, so the list
+// shows what could be enabled. Version commands run in the same sandbox
+// as the analysis.
+func (a *Analyzer) ListLinters(ctx context.Context) []LinterInfo {
+	a = a.snapshot()
+	if sb := a.sandbox(); sb != nil {
+		ctx = linters.WithSandbox(ctx, sb)
+	}
+	names := a.registry.Names()
+	infos := make([]LinterInfo, 0, len(names))
+
+	for _, name := range names {
+		reg, _ := a.registry.Get(name)
+		info := LinterInfo{
+			Name:         name,
+			Description:  reg.Description,
+			Enabled:      a.config.Linters.IsEnabled(name),
+			Capabilities: reg.CapabilitiesFor(a.linterOptions()),
+		}
+
+		linter, active := a.linters[name]
+		info.Active = active && !a.deferredToGolangci(name)
+		if !active {
+			var err error
+			linter, err = reg.New(a.logger, a.linterOptions())
+			if err != nil {
+				info.Error = err.Error()
+				infos = append(infos, info)
+				continue
+			}
+		}
+
+		info.Available = linter.IsAvailable()
+		if info.Available {
+			info.Version = a.linterVersion(ctx, name, linter)
+		}
+		infos = append(infos, info)
+	}
+
+	return infos
+}
@@ -0,0 +1,311 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-standards-mcp-server/internal/git"
+	"go-standards-mcp-server/internal/lintconfig"
+	"go-standards-mcp-server/internal/policy"
+	"go-standards-mcp-server/internal/workspace"
+	"go-standards-mcp-server/pkg/models"
+	"go.uber.org/zap"
+)
+
+// profile is a standard and lint config applied to all or part of a project
+type profile struct {
+	dir      string // Directory relative to the project root; empty for the whole project
+	standard string
+	config   string // Lint config reference
+	root     string // Directory a relative config path is resolved against first
+	content  string // Inline lint config from the request
+	uncapped bool   // Lift the config's issue caps
+
+	configPath string   // Prepared config file
+	sources    []string // Config chain the file was built from
+	hash       string   // SHA-256 of the prepared config
+}
+
+// project holds the standards file found for an analysis
+type project struct {
+	standards *git.Standards
+	root      string // Directory containing the standards file
+	file      string
+}
+
+// findProject looks for a standards file from the analyzed directory up to
+// the repository root. A file outside the user's allowed roots is ignored.
+func (a *Analyzer) findProject(req *models.AnalysisRequest) (*project, error) {
+	dir := req.ProjectDir
+	if dir == "" && req.FilePath != "" {
+		dir = filepath.Dir(req.FilePath)
+	}
+	if dir == "" {
+		return nil, nil
+	}
+
+	standards, file, err := git.FindStandards(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project standards: %w", err)
+	}
+	if standards == nil {
+		return nil, nil
+	}
+	if _, err := a.guard.Resolve("read project standards", file); err != nil {
+		a.logger.Warn("Ignoring project standards outside the allowed roots", zap.String("file", file))
+		return nil, nil
+	}
+
+	a.logger.Debug("Using project standards", zap.String("file", file))
+	return &project{standards: standards, root: filepath.Dir(file), file: file}, nil
+}
+
+// profiles returns the profile for the whole project followed by one per
+// directory override. A standard or config reference given in the request
+// replaces those of the standards file, including its overrides.
+func (a *Analyzer) profiles(req *models.AnalysisRequest, proj *project) []*profile {
+	if req.Standard != "" || req.ConfigRef != "" || proj == nil {
+		base := &profile{
+			standard: profileStandard(req.Standard, req.ConfigRef),
+			config:   req.ConfigRef,
+		}
+		if base.standard == "custom" && req.ConfigRef == "" {
+			base.content = req.Config
+		}
+		return []*profile{base}
+	}
+
+	s := proj.standards
+	profiles := []*profile{{standard: profileStandard(s.Standard, s.Config), config: s.Config, root: proj.root}}
+	for _, o := range s.Overrides {
+		profiles = append(profiles, &profile{
+			dir:      filepath.ToSlash(filepath.Clean(o.Path)),
+			standard: profileStandard(o.Standard, o.Config),
+			config:   o.Config,
+			root:     proj.root,
+		})
+	}
+	return profiles
+}
+
+// profileStandard returns the standard name for a profile: the declared
+// one, "custom" for a lint config alone, or the default
+func profileStandard(standard, config string) string {
+	switch {
+	case standard != "":
+		return standard
+	case config != "":
+		return "custom"
+	}
+	return "standard"
+}
+
+// prepareProfile loads, resolves and migrates the lint config of a profile
+func (a *Analyzer) prepareProfile(ctx context.Context, p *profile) error {
+	var err error
+	switch {
+	case p.content != "":
+		p.configPath, p.sources, err = a.loadConfig("custom", p.content)
+	case p.config != "":
+		var data []byte
+		var source string
+		data, source, err = a.loadProjectConfig(p.root, p.config)
+		if err == nil {
+			p.configPath, p.sources, err = a.writeConfig(data, source)
+		}
+	default:
+		p.configPath, p.sources, err = a.loadConfig(p.standard, "")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// golangci-lint v2 rejects v1 configs, so convert them when v2 is installed
+	p.configPath, err = a.migrateLintConfig(ctx, p.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to migrate config: %w", err)
+	}
+
+	// Report every issue golangci-lint finds, for counts that must be complete
+	if p.uncapped {
+		data, err := os.ReadFile(p.configPath)
+		if err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+		if data, err = lintconfig.Uncap(data); err != nil {
+			return fmt.Errorf("failed to lift issue caps: %w", err)
+		}
+		if p.configPath, err = a.tempConfig(data); err != nil {
+			return err
+		}
+	}
+
+	// Linters run from the module root, so the config path must be absolute
+	if absPath, err := filepath.Abs(p.configPath); err == nil {
+		p.configPath = absPath
+	}
+
+	// Record exactly which config the linters ran with
+	data, err := os.ReadFile(p.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	p.hash = fmt.Sprintf("%x", sha256.Sum256(data))
+	return nil
+}
+
+// loadProjectConfig loads a lint config reference. In a standards file, a
+// path relative to the project root takes precedence over stored configs
+// and templates of the same name.
+func (a *Analyzer) loadProjectConfig(root, ref string) ([]byte, string, error) {
+	if root != "" && !filepath.IsAbs(ref) {
+		if candidate := filepath.Join(root, ref); fileExists(candidate) {
+			ref = candidate
+		}
+	}
+	return a.LoadConfigRef(ref)
+}
+
+// projectFile returns the standards file used, or ""
+func projectFile(proj *project) string {
+	if proj == nil {
+		return ""
+	}
+	return proj.file
+}
+
+// overrideSummary describes the directory overrides as "dir: standard"
+func overrideSummary(profiles []*profile) []string {
+	var summary []string
+	for _, p := range profiles[1:] {
+		label := p.standard
+		if p.config != "" {
+			label = p.config
+		}
+		summary = append(summary, p.dir+": "+label)
+	}
+	return summary
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// runProfiles runs the linters once per profile and keeps from each run
+// the issues in files the profile applies to. Issues in excluded or
+// generated files are dropped, and each profile's policy is applied.
+func (a *Analyzer) runProfiles(ctx context.Context, workDir string, modules []workspace.Module, profiles []*profile, proj *project) ([]models.Issue, []models.LinterRun, error) {
+	var allIssues []models.Issue
+	var allRuns []models.LinterRun
+	filter := newProjectFilter(workDir, proj)
+
+	for i, p := range profiles {
+		issues, runs := a.runModules(ctx, workDir, p.configPath, modules)
+		if len(profiles) > 1 {
+			for j := range runs {
+				runs[j].Standard = p.standard
+			}
+		}
+
+		kept := issues[:0]
+		for _, issue := range issues {
+			if filter.keep(issue, i, profiles) {
+				kept = append(kept, issue)
+			}
+		}
+		if err := a.applyPolicy(kept, p.standard, filter.rules()); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply policy: %w", err)
+		}
+
+		allIssues = append(allIssues, kept...)
+		allRuns = append(allRuns, runs...)
+	}
+
+	if filter.dropped > 0 {
+		a.logger.Debug("Dropped issues in excluded or generated files", zap.Int("count", filter.dropped))
+	}
+	return allIssues, allRuns, nil
+}
+
+// projectFilter decides which profile an issue belongs to and whether the
+// standards file excludes it
+type projectFilter struct {
+	workDir   string
+	proj      *project
+	generated map[string]bool // Cache of generated file checks
+	dropped   int
+}
+
+// newProjectFilter creates a filter for issues found in workDir
+func newProjectFilter(workDir string, proj *project) *projectFilter {
+	return &projectFilter{workDir: workDir, proj: proj, generated: make(map[string]bool)}
+}
+
+// rules returns the policy rules of the standards file
+func (f *projectFilter) rules() []policy.Rule {
+	if f.proj == nil {
+		return nil
+	}
+	return f.proj.standards.Policy
+}
+
+// keep reports whether an issue found by profiles[index] is reported
+func (f *projectFilter) keep(issue models.Issue, index int, profiles []*profile) bool {
+	if issue.File == "" {
+		// Issues without a file are reported once, by the first profile
+		return index == 0
+	}
+	if f.proj == nil {
+		return true
+	}
+
+	abs := issue.File
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(f.workDir, abs)
+	}
+	rel, err := filepath.Rel(f.proj.root, abs)
+	if err != nil {
+		return index == 0
+	}
+
+	if owner := profileFor(rel, profiles); owner != index {
+		return false
+	}
+	if f.proj.standards.Excluded(rel) || (f.proj.standards.ExcludeGenerated() && f.isGenerated(abs)) {
+		f.dropped++
+		return false
+	}
+	return true
+}
+
+// isGenerated reports whether a file is generated, caching the answer
+func (f *projectFilter) isGenerated(file string) bool {
+	generated, ok := f.generated[file]
+	if !ok {
+		generated = git.IsGenerated(file)
+		f.generated[file] = generated
+	}
+	return generated
+}
+
+// profileFor returns the index of the profile whose directory is the
+// longest prefix of a project-relative file, or 0 for the whole project
+func profileFor(file string, profiles []*profile) int {
+	file = filepath.ToSlash(file)
+	best, bestLen := 0, -1
+	for i, p := range profiles {
+		if p.dir == "" {
+			continue
+		}
+		if (file == p.dir || strings.HasPrefix(file, p.dir+"/")) && len(p.dir) > bestLen {
+			best, bestLen = i, len(p.dir)
+		}
+	}
+	return best
+}
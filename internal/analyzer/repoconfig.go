@@ -0,0 +1,226 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go-standards-mcp-server/internal/lintconfig"
+)
+
+// Drift statuses of a repository's committed lint config
+const (
+	DriftInSync       = "in-sync"
+	DriftDrifted      = "drifted"
+	DriftMissing      = "missing"      // No golangci-lint config committed
+	DriftUnregistered = "unregistered" // Not exported from a registered config, and none was named
+	DriftError        = "error"
+)
+
+// RepoConfig is the golangci-lint config committed to a repository
+type RepoConfig struct {
+	File       string                 `json:"file"`
+	Format     string                 `json:"format"`               // Format of the file: yaml, json, or toml
+	Content    []byte                 `json:"-"`                    // The config as YAML, without a provenance header
+	Provenance *lintconfig.Provenance `json:"provenance,omitempty"` // Set if the file was exported from a registered config
+	Ignored    []string               `json:"ignored,omitempty"`    // Other config files golangci-lint does not read
+}
+
+// ExportResult describes a config written into a repository
+type ExportResult struct {
+	File     string   `json:"file"`
+	Source   string   `json:"source"` // Registered config written, with its revision
+	Hash     string   `json:"hash"`
+	Replaced bool     `json:"replaced"` // Whether an earlier export was overwritten
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// DriftReport compares a repository's committed lint config with the
+// registered config it should match
+type DriftReport struct {
+	Repo     string           `json:"repo"`
+	File     string           `json:"file,omitempty"`
+	Config   string           `json:"config,omitempty"`   // Registered config compared against, with its revision
+	Exported string           `json:"exported,omitempty"` // Config named in the file's provenance header
+	Status   string           `json:"status"`             // in-sync, drifted, missing, unregistered, or error
+	Reasons  []string         `json:"reasons,omitempty"`
+	Diff     *lintconfig.Diff `json:"diff,omitempty"` // From the registered config to the committed one
+	Error    string           `json:"error,omitempty"`
+}
+
+// ReadRepoConfig reads the golangci-lint config that takes effect in a
+// repository directory, or the config file path names, converted to YAML
+func (a *Analyzer) ReadRepoConfig(path string) (*RepoConfig, error) {
+	a = a.snapshot()
+	resolved, err := a.guard.Resolve("read repository config", path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access %s: %w", path, err)
+	}
+
+	file, ignored := resolved, []string(nil)
+	if info.IsDir() {
+		found := findProjectConfigs(resolved)
+		if len(found) == 0 {
+			return nil, fmt.Errorf("no golangci-lint config found in %s", path)
+		}
+		file = found[0]
+		for _, other := range found[1:] {
+			ignored = append(ignored, filepath.Base(other))
+		}
+	}
+
+	data, _, err := readConfigFile(file)
+	if err != nil {
+		return nil, err
+	}
+	provenance, body := lintconfig.ParseProvenance(data)
+	return &RepoConfig{
+		File:       file,
+		Format:     lintconfig.FormatOf(file),
+		Content:    body,
+		Provenance: provenance,
+		Ignored:    ignored,
+	}, nil
+}
+
+// ExportConfig writes the config ref resolves to into a repository
+// directory as .golangci.yml, with a provenance header so that plain
+// golangci-lint and editors follow the registered rules. A file that was
+// not exported, or was edited since, is only replaced if force is set.
+func (a *Analyzer) ExportConfig(ref, repo string, force bool) (*ExportResult, error) {
+	a = a.snapshot()
+	dir, err := a.guard.Resolve("export config", repo)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("repository directory not found: %s", repo)
+	}
+
+	data, source, err := a.EffectiveConfig(ref, "")
+	if err != nil {
+		return nil, err
+	}
+	if validation := lintconfig.Validate(data); !validation.Valid {
+		return nil, fmt.Errorf("config %s is not valid: %s", source, strings.Join(validation.Errors, "; "))
+	}
+
+	file := filepath.Join(dir, projectConfigNames[0])
+	result := &ExportResult{File: file, Source: source, Hash: lintconfig.ContentHash(data)}
+	if existing, err := os.ReadFile(file); err == nil {
+		provenance, body := lintconfig.ParseProvenance(existing)
+		switch {
+		case provenance == nil && !force:
+			return nil, fmt.Errorf("%s was not exported from a registered config; import it first or set force to replace it", file)
+		case provenance != nil && provenance.Edited(body) && !force:
+			return nil, fmt.Errorf("%s was edited since it was exported from %s; set force to replace it", file, provenance.Source)
+		}
+		result.Replaced = true
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	if err := os.WriteFile(file, lintconfig.AddProvenance(data, source), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+	for _, other := range findProjectConfigs(dir)[1:] {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("%s is ignored by golangci-lint in favor of %s and can be removed", filepath.Base(other), projectConfigNames[0]))
+	}
+
+	a.logger.Info("Exported config",
+		zap.String("source", source),
+		zap.String("file", file),
+		zap.Bool("replaced", result.Replaced))
+	return result, nil
+}
+
+// CheckDrift compares the lint config committed to a repository with the
+// registered config ref names or, if ref is empty, the config the file
+// was exported from
+func (a *Analyzer) CheckDrift(repo, ref string) *DriftReport {
+	a = a.snapshot()
+	report := &DriftReport{Repo: repo}
+	fail := func(err error) *DriftReport {
+		report.Status = DriftError
+		report.Error = err.Error()
+		return report
+	}
+
+	dir, err := a.guard.Resolve("check config drift", repo)
+	if err != nil {
+		return fail(err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fail(fmt.Errorf("repository directory not found: %s", repo))
+	}
+	if len(findProjectConfigs(dir)) == 0 {
+		report.Status = DriftMissing
+		report.Reasons = []string{"no golangci-lint config is committed"}
+		return report
+	}
+
+	committed, err := a.ReadRepoConfig(dir)
+	if err != nil {
+		return fail(err)
+	}
+	report.File = committed.File
+	for _, name := range committed.Ignored {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("%s is ignored by golangci-lint", name))
+	}
+
+	if committed.Provenance != nil {
+		report.Exported = committed.Provenance.Source
+		if ref == "" {
+			ref = committed.Provenance.Ref()
+		}
+	}
+	if ref == "" {
+		report.Status = DriftUnregistered
+		report.Reasons = append(report.Reasons, "the config was not exported from a registered config; name one to compare against")
+		return report
+	}
+
+	registered, source, err := a.EffectiveConfig(ref, "")
+	if err != nil {
+		return fail(err)
+	}
+	report.Config = source
+
+	if p := committed.Provenance; p != nil {
+		if p.Edited(committed.Content) {
+			report.Reasons = append(report.Reasons, "edited since it was exported")
+		}
+		exportedRef, _, _ := strings.Cut(source, "@")
+		switch {
+		case p.Ref() != exportedRef:
+			report.Reasons = append(report.Reasons, fmt.Sprintf("exported from %s, not %s", p.Source, exportedRef))
+		case p.Source != source:
+			report.Reasons = append(report.Reasons, fmt.Sprintf("registered config changed since the export (%s → %s)", p.Source, source))
+		}
+	}
+
+	// A committed config may extend others like a registered one
+	resolution, err := a.ResolveConfig(committed.Content, committed.File)
+	if err != nil {
+		return fail(err)
+	}
+	diff, err := lintconfig.Compare(registered, resolution.Content, source, filepath.Base(committed.File))
+	if err != nil {
+		return fail(err)
+	}
+	if diff.Verdict == "identical" {
+		report.Status = DriftInSync
+		return report
+	}
+	report.Status = DriftDrifted
+	report.Diff = diff
+	return report
+}
@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-standards-mcp-server/internal/pathguard"
+	"go-standards-mcp-server/internal/storage"
+	"go.uber.org/zap"
+)
+
+func TestExportAndCheckDrift(t *testing.T) {
+	guard, err := pathguard.New(pathguard.Config{}, false, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Analyzer{logger: zap.NewNop(), guard: guard, mu: &sync.RWMutex{}}
+	configs, err := storage.NewConfigStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.SetConfigStorage(configs)
+
+	team := "version: \"2\"\nlinters:\n  default: none\n  enable: [errcheck, gocyclo]\n  settings:\n    gocyclo:\n      min-complexity: 10\n"
+	if err := configs.Save("team", team, "", nil, storage.Change{}); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := t.TempDir()
+	file := filepath.Join(repo, ".golangci.yml")
+	if report := a.CheckDrift(repo, ""); report.Status != DriftMissing {
+		t.Errorf("status before export = %s, want %s", report.Status, DriftMissing)
+	}
+
+	if _, err := a.ExportConfig("config:team", repo, false); err != nil {
+		t.Fatalf("ExportConfig() error = %v", err)
+	}
+	report := a.CheckDrift(repo, "")
+	if report.Status != DriftInSync || report.Config != "config:team@1" || len(report.Reasons) != 0 {
+		t.Errorf("after export: %+v, want in sync with config:team@1", report)
+	}
+
+	// The registered config is loosened after the export
+	looser := strings.Replace(team, "min-complexity: 10", "min-complexity: 20", 1)
+	if err := configs.Save("team", looser, "", nil, storage.Change{}); err != nil {
+		t.Fatal(err)
+	}
+	report = a.CheckDrift(repo, "")
+	if report.Status != DriftDrifted || report.Diff == nil || report.Diff.Verdict != "stricter" {
+		t.Errorf("after team change: %+v, want drifted and stricter than the team config", report)
+	}
+	if len(report.Reasons) != 1 || !strings.Contains(report.Reasons[0], "config:team@1 → config:team@2") {
+		t.Errorf("reasons = %v, want the revision change", report.Reasons)
+	}
+
+	// A hand-edited export is only replaced with force
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, append(data, "# local tweak\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.ExportConfig("config:team", repo, false); err == nil {
+		t.Error("ExportConfig() replaced an edited file without force")
+	}
+	result, err := a.ExportConfig("config:team", repo, true)
+	if err != nil || !result.Replaced || result.Source != "config:team@2" {
+		t.Errorf("ExportConfig(force) = %+v, %v", result, err)
+	}
+	if report := a.CheckDrift(repo, ""); report.Status != DriftInSync {
+		t.Errorf("after re-export: %+v, want in sync", report)
+	}
+
+	// A config written by hand has nothing to compare with unless named
+	plain := t.TempDir()
+	if err := os.WriteFile(filepath.Join(plain, ".golangci.toml"), []byte("version = \"2\"\n\n[linters]\ndefault = \"none\"\nenable = [\"errcheck\", \"gocyclo\"]\n\n[linters.settings.gocyclo]\nmin-complexity = 20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if report := a.CheckDrift(plain, ""); report.Status != DriftUnregistered {
+		t.Errorf("hand-written config: %+v, want %s", report, DriftUnregistered)
+	}
+	if report := a.CheckDrift(plain, "team"); report.Status != DriftInSync {
+		t.Errorf("hand-written TOML config compared with team: %+v, want in sync", report)
+	}
+}
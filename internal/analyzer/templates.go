@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go-standards-mcp-server/internal/lintconfig"
+	"go-standards-mcp-server/internal/policy"
+	"go.uber.org/zap"
+)
+
+// policySuffix names the file next to a template that declares the
+// template's severity policy, e.g. strict.policy.yaml
+const policySuffix = ".policy.yaml"
+
+// TemplateDir returns the directory the predefined templates are read from
+func (a *Analyzer) TemplateDir() (string, error) {
+	for _, dir := range templateDirs() {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("template directory not found (tried: configs/templates)")
+}
+
+// LoadTemplates reads and validates the predefined templates and keeps them
+// in memory, so that later edits only take effect once they are valid. A
+// template that fails validation keeps its previous version, or is left
+// out if it has none. A template's policy file is loaded and rejected with
+// it. It returns the names of the rejected templates.
+func (a *Analyzer) LoadTemplates() ([]string, error) {
+	dir, err := a.TemplateDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory: %w", err)
+	}
+
+	snap := a.snapshot()
+	current := snap.templates
+	next := make(map[string][]byte)
+	nextPolicies := make(map[string][]policy.Rule)
+	var rejected []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if !ok || entry.IsDir() || !validConfigName(name) || strings.HasSuffix(entry.Name(), policySuffix) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			a.logger.Warn("Failed to read template", zap.String("template", name), zap.Error(err))
+			continue
+		}
+		errs := lintconfig.Validate(data).Errors
+		rules, err := readTemplatePolicy(dir, name)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		if len(errs) > 0 {
+			rejected = append(rejected, name)
+			a.logger.Error("Rejected invalid template, keeping the last good version",
+				zap.String("template", name),
+				zap.Bool("has_previous", current[name] != nil),
+				zap.Strings("errors", errs))
+			if previous, ok := current[name]; ok {
+				next[name] = previous
+				nextPolicies[name] = snap.policies[name]
+			}
+			continue
+		}
+		next[name] = data
+		nextPolicies[name] = rules
+	}
+
+	// Log what changed, except on the first load
+	if current != nil {
+		for _, name := range sortedNames(next) {
+			previous, ok := current[name]
+			switch {
+			case !ok:
+				a.logger.Info("Template added", zap.String("template", name))
+			case !bytes.Equal(previous, next[name]) || !reflect.DeepEqual(snap.policies[name], nextPolicies[name]):
+				a.logger.Info("Template updated", zap.String("template", name))
+			}
+		}
+		for _, name := range sortedNames(current) {
+			if _, ok := next[name]; !ok {
+				a.logger.Info("Template removed", zap.String("template", name))
+			}
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.templates = next
+	a.policies = nextPolicies
+	return rejected, nil
+}
+
+// templatePolicy returns the severity policy declared next to a template,
+// nil if there is none or standard is not a template
+func (a *Analyzer) templatePolicy(standard string) ([]policy.Rule, error) {
+	if a.templates != nil {
+		return a.policies[standard], nil
+	}
+	path, err := a.TemplatePath(standard)
+	if err != nil {
+		return nil, nil
+	}
+	return readTemplatePolicy(filepath.Dir(path), standard)
+}
+
+// readTemplatePolicy reads the policy file of a template in dir, nil if it
+// has none
+func readTemplatePolicy(dir, name string) ([]policy.Rule, error) {
+	path := filepath.Join(dir, name+policySuffix)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template policy: %w", err)
+	}
+	rules, err := policy.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy in %s: %w", filepath.Base(path), err)
+	}
+	return rules, nil
+}
+
+// sortedNames returns the template names in order
+func sortedNames(templates map[string][]byte) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"go-standards-mcp-server/internal/config"
+	"go-standards-mcp-server/internal/policy"
+	"go-standards-mcp-server/pkg/models"
+	"go.uber.org/zap"
+)
+
+func TestLoadTemplates(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dir := filepath.Join("configs", "templates")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	good := "version: \"2\"\nlinters:\n  enable: [errcheck]\n"
+	bad := "version: \"3\"\n"
+	write("team", good)
+	write("broken", bad)
+
+	a := &Analyzer{logger: zap.NewNop(), mu: &sync.RWMutex{}}
+	rejected, err := a.LoadTemplates()
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	if !reflect.DeepEqual(rejected, []string{"broken"}) {
+		t.Errorf("rejected = %v, want [broken]", rejected)
+	}
+	if _, _, err := a.loadTemplate("broken"); err == nil {
+		t.Error("invalid template without a previous version was loaded")
+	}
+
+	// An invalid edit keeps the last good version
+	write("team", bad)
+	if _, err := a.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	data, source, err := a.loadTemplate("team")
+	if err != nil || string(data) != good || source != "template:team" {
+		t.Errorf("loadTemplate(team) = %q, %s, %v; want the last good version", data, source, err)
+	}
+
+	// A valid edit replaces it
+	updated := "version: \"2\"\nlinters:\n  enable: [errcheck, govet]\n"
+	write("team", updated)
+	if _, err := a.LoadTemplates(); err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	if data, _, _ := a.loadTemplate("team"); string(data) != updated {
+		t.Errorf("loadTemplate(team) = %q, want the updated version", data)
+	}
+}
+
+func TestTemplatePolicy(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dir := filepath.Join("configs", "templates")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("team.yaml", "version: \"2\"\nlinters:\n  enable: [gosec, misspell]\n")
+	write("team.policy.yaml", "rules:\n  - linter: gosec\n    severity: error\n  - linter: misspell\n    severity: info\n")
+
+	cfg := &config.Config{}
+	cfg.Policy.Standards = map[string][]policy.Rule{"team": {{Linter: "misspell", Severity: "warning"}}}
+	cfg.Policy.Rules = []policy.Rule{{Linter: "gosec", Severity: "info", Category: "audit"}}
+	a := &Analyzer{config: cfg, logger: zap.NewNop(), mu: &sync.RWMutex{}}
+
+	severities := func(standard string, project []policy.Rule) []string {
+		t.Helper()
+		issues := []models.Issue{
+			{Source: "gosec", Rule: "G104", Severity: "warning", Category: "security"},
+			{Source: "golangci-lint", Rule: "misspell", Severity: "warning", Category: "style"},
+		}
+		if err := a.snapshot().applyPolicy(issues, standard, project); err != nil {
+			t.Fatalf("applyPolicy() error = %v", err)
+		}
+		return []string{issues[0].Severity, issues[1].Severity}
+	}
+
+	tests := []struct {
+		name     string
+		standard string
+		project  []policy.Rule
+		want     []string
+	}{
+		// The server's per-standard rule beats the template for misspell, the
+		// template beats the server's global rule for gosec
+		{"template between standard and global rules", "team", nil, []string{"error", "warning"}},
+		{"project rules come first", "team", []policy.Rule{{Linter: "gosec", Severity: "warning"}}, []string{"warning", "warning"}},
+		{"no template policy", "other", nil, []string{"info", "warning"}},
+	}
+
+	// Templates read from disk on each use and kept by LoadTemplates
+	for _, mode := range []string{"disk", "loaded"} {
+		if mode == "loaded" {
+			if rejected, err := a.LoadTemplates(); err != nil || len(rejected) > 0 {
+				t.Fatalf("LoadTemplates() = %v, %v", rejected, err)
+			}
+		}
+		for _, tt := range tests {
+			if got := severities(tt.standard, tt.project); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s, %s: severities = %v, want %v", mode, tt.name, got, tt.want)
+			}
+		}
+	}
+
+	// An invalid policy rejects the template and keeps the last good version
+	write("team.policy.yaml", "rules:\n  - linter: gosec\n    severity: fatal\n")
+	rejected, err := a.LoadTemplates()
+	if err != nil || !reflect.DeepEqual(rejected, []string{"team"}) {
+		t.Fatalf("LoadTemplates() = %v, %v; want [team] rejected", rejected, err)
+	}
+	if got := severities("team", nil); !reflect.DeepEqual(got, tests[0].want) {
+		t.Errorf("after rejected edit: severities = %v, want %v", got, tests[0].want)
+	}
+	if _, _, err := a.loadTemplate("team.policy"); err == nil {
+		t.Error("policy file was loaded as a template")
+	}
+}
@@ -0,0 +1,240 @@
+package baseline
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go-standards-mcp-server/pkg/models"
+)
+
+// FileName is the default baseline file name, stored next to .go-standards.json
+const FileName = ".go-standards-baseline.json"
+
+// formatVersion is the current baseline file format version
+const formatVersion = 1
+
+// positionPattern matches file positions embedded in linter messages
+var positionPattern = regexp.MustCompile(`\.go:\d+(:\d+)?`)
+
+// Entry represents a group of identical baselined issues
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	File        string `json:"file"`
+	Rule        string `json:"rule"`
+	Source      string `json:"source"`
+	Message     string `json:"message"`
+	Count       int    `json:"count"`
+}
+
+// Baseline is a snapshot of known issues that should not fail future runs
+type Baseline struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// DefaultPath returns the default baseline path for a project directory
+func DefaultPath(projectDir string) string {
+	return filepath.Join(projectDir, FileName)
+}
+
+// New creates a baseline from the given issues
+func New(issues []models.Issue, workDir string) *Baseline {
+	fp := newFingerprinter(workDir)
+	entries := make(map[string]*Entry)
+
+	for _, issue := range issues {
+		sum := fp.fingerprint(issue)
+		if entry, ok := entries[sum]; ok {
+			entry.Count++
+			continue
+		}
+		entries[sum] = &Entry{
+			Fingerprint: sum,
+			File:        normalizePath(workDir, issue.File),
+			Rule:        issue.Rule,
+			Source:      issue.Source,
+			Message:     issue.Message,
+			Count:       1,
+		}
+	}
+
+	b := &Baseline{
+		Version:   formatVersion,
+		CreatedAt: time.Now(),
+		Entries:   make([]Entry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		b.Entries = append(b.Entries, *entry)
+	}
+
+	// Keep the file stable so it diffs cleanly in version control
+	sort.Slice(b.Entries, func(i, j int) bool {
+		if b.Entries[i].File != b.Entries[j].File {
+			return b.Entries[i].File < b.Entries[j].File
+		}
+		return b.Entries[i].Fingerprint < b.Entries[j].Fingerprint
+	})
+
+	return b
+}
+
+// Load loads a baseline from file
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+
+	if b.Version > formatVersion {
+		return nil, fmt.Errorf("unsupported baseline version: %d", b.Version)
+	}
+
+	return &b, nil
+}
+
+// Save saves the baseline to file
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Filter splits issues into new issues and issues already in the baseline.
+// Each baseline entry absorbs at most Count matching issues, so additional
+// occurrences of a known problem are still reported as new.
+func (b *Baseline) Filter(issues []models.Issue, workDir string) ([]models.Issue, []models.Issue) {
+	remaining := make(map[string]int, len(b.Entries))
+	for _, entry := range b.Entries {
+		remaining[entry.Fingerprint] += entry.Count
+	}
+
+	fp := newFingerprinter(workDir)
+	fresh := make([]models.Issue, 0, len(issues))
+	var baselined []models.Issue
+
+	for _, issue := range issues {
+		sum := issue.Fingerprint
+		if sum == "" {
+			sum = fp.fingerprint(issue)
+		}
+		if remaining[sum] > 0 {
+			remaining[sum]--
+			baselined = append(baselined, issue)
+			continue
+		}
+		fresh = append(fresh, issue)
+	}
+
+	return fresh, baselined
+}
+
+// Fingerprint computes a stable fingerprint for the issue. The fingerprint
+// is based on the file, rule and the content of the offending line rather
+// than its number, so it survives unrelated edits that shift lines.
+func Fingerprint(issue models.Issue, workDir string) string {
+	return newFingerprinter(workDir).fingerprint(issue)
+}
+
+// Annotate sets the Fingerprint field on every issue
+func Annotate(issues []models.Issue, workDir string) {
+	fp := newFingerprinter(workDir)
+	for i := range issues {
+		issues[i].Fingerprint = fp.fingerprint(issues[i])
+	}
+}
+
+// fingerprinter computes fingerprints, caching file contents
+type fingerprinter struct {
+	workDir string
+	files   map[string][]string
+}
+
+func newFingerprinter(workDir string) *fingerprinter {
+	return &fingerprinter{
+		workDir: workDir,
+		files:   make(map[string][]string),
+	}
+}
+
+// fingerprint computes the fingerprint of a single issue
+func (f *fingerprinter) fingerprint(issue models.Issue) string {
+	file := normalizePath(f.workDir, issue.File)
+	message := positionPattern.ReplaceAllString(issue.Message, ".go")
+
+	h := sha256.New()
+	for _, part := range []string{file, issue.Source, issue.Rule, message, f.lineText(file, issue.Line)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+}
+
+// lineText returns the whitespace-normalized content of the given line
+func (f *fingerprinter) lineText(file string, line int) string {
+	if file == "" || line <= 0 {
+		return ""
+	}
+
+	lines, ok := f.files[file]
+	if !ok {
+		lines = readLines(filepath.Join(f.workDir, filepath.FromSlash(file)))
+		f.files[file] = lines
+	}
+
+	if line > len(lines) {
+		return ""
+	}
+	return strings.Join(strings.Fields(lines[line-1]), " ")
+}
+
+// readLines reads a file into lines, returning nil if it cannot be read
+func readLines(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+// normalizePath returns a clean, slash-separated path relative to workDir
+func normalizePath(workDir, file string) string {
+	if file == "" {
+		return ""
+	}
+	if filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(workDir, file); err == nil {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
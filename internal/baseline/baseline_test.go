@@ -0,0 +1,62 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-standards-mcp-server/pkg/models"
+)
+
+func TestBaseline_FilterSurvivesLineShift(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+
+	before := "package main\n\nfunc main() {\n\tx := 1\n}\n"
+	if err := os.WriteFile(file, []byte(before), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	issue := models.Issue{File: "main.go", Line: 4, Rule: "ineffassign", Source: "golangci-lint", Message: "ineffectual assignment to x"}
+	b := New([]models.Issue{issue}, dir)
+
+	path := DefaultPath(dir)
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Failed to save baseline: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load baseline: %v", err)
+	}
+
+	// Insert lines above the issue so its line number changes
+	after := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tx := 1\n\tfmt.Println()\n}\n"
+	if err := os.WriteFile(file, []byte(after), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	shifted := issue
+	shifted.Line = 6
+	added := models.Issue{File: "main.go", Line: 7, Rule: "errcheck", Source: "golangci-lint", Message: "error return value not checked"}
+
+	fresh, baselined := loaded.Filter([]models.Issue{shifted, added}, dir)
+	if len(baselined) != 1 || baselined[0].Rule != "ineffassign" {
+		t.Errorf("Expected shifted issue to be baselined, got %+v", baselined)
+	}
+	if len(fresh) != 1 || fresh[0].Rule != "errcheck" {
+		t.Errorf("Expected new issue to be reported, got %+v", fresh)
+	}
+}
+
+func TestBaseline_FilterRespectsCount(t *testing.T) {
+	issue := models.Issue{File: "a.go", Rule: "gocyclo", Source: "golangci-lint", Message: "too complex"}
+	b := New([]models.Issue{issue}, t.TempDir())
+
+	fresh, baselined := b.Filter([]models.Issue{issue, issue}, "")
+	if len(baselined) != 1 {
+		t.Errorf("Expected 1 baselined issue, got %d", len(baselined))
+	}
+	if len(fresh) != 1 {
+		t.Errorf("Expected 1 new issue, got %d", len(fresh))
+	}
+}
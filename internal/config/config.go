@@ -3,20 +3,32 @@ package config
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
+	"go-standards-mcp-server/internal/pathguard"
+	"go-standards-mcp-server/internal/policy"
+	"go-standards-mcp-server/pkg/linters"
+
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Log      LogConfig      `mapstructure:"log"`
-	Analyzer AnalyzerConfig `mapstructure:"analyzer"`
-	Linters  LintersConfig  `mapstructure:"linters"`
-	Storage  StorageConfig  `mapstructure:"storage"`
-	Cache    CacheConfig    `mapstructure:"cache"`
-	Report   ReportConfig   `mapstructure:"report"`
+	Server   ServerConfig     `mapstructure:"server"`
+	Log      LogConfig        `mapstructure:"log"`
+	Analyzer AnalyzerConfig   `mapstructure:"analyzer"`
+	Linters  LintersConfig    `mapstructure:"linters"`
+	Storage  StorageConfig    `mapstructure:"storage"`
+	Cache    CacheConfig      `mapstructure:"cache"`
+	Report   ReportConfig     `mapstructure:"report"`
+	Policy   PolicyConfig     `mapstructure:"policy"`
+	Security pathguard.Config `mapstructure:"security"` // Allowed roots for client-supplied paths
+
+	File string `mapstructure:"-"` // File the config was read from; empty if only defaults and environment were used
 }
 
 // ServerConfig contains server-related configuration
@@ -36,17 +48,60 @@ type LogConfig struct {
 
 // AnalyzerConfig contains analyzer configuration
 type AnalyzerConfig struct {
-	Timeout         time.Duration `mapstructure:"timeout"`
-	ConcurrentLimit int           `mapstructure:"concurrent_limit"`
-	TempDir         string        `mapstructure:"temp_dir"`
+	Timeout         time.Duration     `mapstructure:"timeout"`
+	ConcurrentLimit int               `mapstructure:"concurrent_limit"`
+	TempDir         string            `mapstructure:"temp_dir"`
+	Suppression     SuppressionConfig `mapstructure:"suppression"`
+	Coverage        CoverageConfig    `mapstructure:"coverage"`
+	Sandbox         linters.Sandbox   `mapstructure:"sandbox"` // Restrictions for linter and test runs; always on in http mode
+}
+
+// CoverageConfig controls the optional test coverage stage
+type CoverageConfig struct {
+	Enabled    bool               `mapstructure:"enabled"`    // Run for every project analysis, not only on request
+	Timeout    time.Duration      `mapstructure:"timeout"`    // Timeout for go test
+	Thresholds map[string]float64 `mapstructure:"thresholds"` // Minimum coverage in percent, per standard
+}
+
+// SuppressionConfig controls inline //standards:ignore directives
+type SuppressionConfig struct {
+	Enabled             bool     `mapstructure:"enabled"`
+	RequireReason       bool     `mapstructure:"require_reason"`       // Reject directives without "-- reason"
+	ForbiddenCategories []string `mapstructure:"forbidden_categories"` // Categories that cannot be suppressed
 }
 
 // LintersConfig contains linter configurations
 type LintersConfig struct {
-	GolangciLint GolangciLintConfig `mapstructure:"golangci_lint"`
-	Staticcheck  LinterConfig       `mapstructure:"staticcheck"`
-	Gosec        LinterConfig       `mapstructure:"gosec"`
-	Govet        LinterConfig       `mapstructure:"govet"`
+	GolangciLint GolangciLintConfig        `mapstructure:"golangci_lint"`
+	Staticcheck  LinterConfig              `mapstructure:"staticcheck"`
+	Gosec        LinterConfig              `mapstructure:"gosec"`
+	Govet        LinterConfig              `mapstructure:"govet"`
+	Govulncheck  GovulncheckConfig         `mapstructure:"govulncheck"`
+	Plugins      []linters.PluginSpec      `mapstructure:"plugins"`  // External commands run as additional linters
+	Taxonomy     linters.TaxonomyOverrides `mapstructure:"taxonomy"` // Overrides for issue categories
+}
+
+// IsEnabled reports whether the named linter is enabled. Configured
+// plugins are always enabled.
+func (c LintersConfig) IsEnabled(name string) bool {
+	switch name {
+	case "golangci-lint":
+		return c.GolangciLint.Enabled
+	case "staticcheck":
+		return c.Staticcheck.Enabled
+	case "gosec":
+		return c.Gosec.Enabled
+	case "govet":
+		return c.Govet.Enabled
+	case "govulncheck":
+		return c.Govulncheck.Enabled
+	}
+	for _, spec := range c.Plugins {
+		if spec.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // GolangciLintConfig contains golangci-lint specific configuration
@@ -56,16 +111,31 @@ type GolangciLintConfig struct {
 	ConfigPath string        `mapstructure:"config_path"`
 }
 
+// GovulncheckConfig contains govulncheck specific configuration
+type GovulncheckConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	DBPath  string        `mapstructure:"db_path"` // Local vulnerability database; empty uses vuln.go.dev
+}
+
 // LinterConfig contains generic linter configuration
 type LinterConfig struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+// PolicyConfig contains severity and category overrides applied to every
+// linter's issues after they run
+type PolicyConfig struct {
+	Rules     []policy.Rule            `mapstructure:"rules"`     // Applied for every standard
+	Standards map[string][]policy.Rule `mapstructure:"standards"` // Per-standard rules, evaluated before global rules
+}
+
 // StorageConfig contains storage configuration
 type StorageConfig struct {
-	Type     string         `mapstructure:"type"` // sqlite or postgres
-	SQLite   SQLiteConfig   `mapstructure:"sqlite"`
-	Postgres PostgresConfig `mapstructure:"postgres"`
+	Type             string         `mapstructure:"type"`              // sqlite or postgres
+	StrictValidation bool           `mapstructure:"strict_validation"` // Reject custom configs that fail validation instead of storing them as invalid
+	SQLite           SQLiteConfig   `mapstructure:"sqlite"`
+	Postgres         PostgresConfig `mapstructure:"postgres"`
 }
 
 // SQLiteConfig contains SQLite configuration
@@ -135,7 +205,11 @@ func Load(configPath string) (*Config, error) {
 
 	// Unmarshal config
 	var config Config
-	if err := v.Unmarshal(&config); err != nil {
+	if err := v.Unmarshal(&config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		secondsHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
@@ -144,9 +218,34 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	config.File = v.ConfigFileUsed()
 	return &config, nil
 }
 
+// secondsHook decodes a bare number as a duration in seconds. Timeouts were
+// once configured as plain seconds (timeout: 300), which would otherwise
+// decode as nanoseconds.
+func secondsHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != reflect.TypeOf(time.Duration(0)) {
+		return data, nil
+	}
+	switch v := data.(type) {
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case uint64:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case string:
+		if n, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return time.Duration(n * float64(time.Second)), nil
+		}
+	}
+	return data, nil
+}
+
 // setDefaults sets default configuration values
 func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.mode", "stdio")
@@ -160,15 +259,35 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("analyzer.timeout", "300s")
 	v.SetDefault("analyzer.concurrent_limit", 10)
 	v.SetDefault("analyzer.temp_dir", "./tmp")
+	v.SetDefault("analyzer.suppression.enabled", true)
+	v.SetDefault("analyzer.suppression.require_reason", false)
+	v.SetDefault("analyzer.suppression.forbidden_categories", []string{})
+	v.SetDefault("analyzer.coverage.enabled", false)
+	v.SetDefault("analyzer.coverage.timeout", "10m")
+	v.SetDefault("analyzer.coverage.thresholds", map[string]float64{
+		"strict":   85,
+		"standard": 70,
+		"relaxed":  60,
+	})
+	v.SetDefault("analyzer.sandbox.enabled", false)
+	v.SetDefault("analyzer.sandbox.cpu_time", "5m")
+	v.SetDefault("analyzer.sandbox.memory_mb", 4096)
+	v.SetDefault("analyzer.sandbox.file_size_mb", 1024)
+	v.SetDefault("analyzer.sandbox.open_files", 1024)
+	v.SetDefault("analyzer.sandbox.isolate", false)
 
 	v.SetDefault("linters.golangci_lint.enabled", true)
 	v.SetDefault("linters.golangci_lint.timeout", "5m")
 	v.SetDefault("linters.staticcheck.enabled", true)
 	v.SetDefault("linters.gosec.enabled", true)
 	v.SetDefault("linters.govet.enabled", true)
+	v.SetDefault("linters.govulncheck.enabled", false)
+	v.SetDefault("linters.govulncheck.timeout", "5m")
+	v.SetDefault("linters.govulncheck.db_path", "")
 
 	v.SetDefault("storage.type", "sqlite")
 	v.SetDefault("storage.sqlite.path", "./data/mcp_server.db")
+	v.SetDefault("storage.strict_validation", false)
 
 	v.SetDefault("cache.enabled", false)
 	v.SetDefault("cache.type", "redis")
@@ -196,6 +315,38 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid storage type: %s", c.Storage.Type)
 	}
 
+	// Validate severity policy
+	if _, err := policy.New(c.Policy.Rules); err != nil {
+		return fmt.Errorf("invalid policy: %w", err)
+	}
+	for standard, rules := range c.Policy.Standards {
+		if _, err := policy.New(rules); err != nil {
+			return fmt.Errorf("invalid policy for standard %s: %w", standard, err)
+		}
+	}
+
+	// Validate plugin linters
+	pluginNames := make(map[string]bool)
+	for _, plugin := range c.Linters.Plugins {
+		if err := plugin.Validate(); err != nil {
+			return fmt.Errorf("invalid linter plugin: %w", err)
+		}
+		if pluginNames[plugin.Name] {
+			return fmt.Errorf("duplicate linter plugin: %s", plugin.Name)
+		}
+		pluginNames[plugin.Name] = true
+	}
+
+	// Validate sandbox limits
+	if err := c.Analyzer.Sandbox.Validate(); err != nil {
+		return fmt.Errorf("invalid sandbox: %w", err)
+	}
+
+	// Validate taxonomy overrides
+	if err := c.Linters.Taxonomy.Validate(); err != nil {
+		return fmt.Errorf("invalid linter taxonomy: %w", err)
+	}
+
 	// Create necessary directories
 	dirs := []string{
 		c.Analyzer.TempDir,
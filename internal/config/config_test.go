@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_Durations(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout string
+		want    time.Duration
+	}{
+		{"with unit", "300s", 300 * time.Second},
+		{"minutes", "5m", 5 * time.Minute},
+		{"bare integer is seconds", "300", 300 * time.Second},
+		{"quoted integer is seconds", `"120"`, 120 * time.Second},
+		{"fractional seconds", "1.5", 1500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+			path := filepath.Join(dir, "config.yaml")
+			content := "analyzer:\n  timeout: " + tt.timeout + "\n  sandbox:\n    cpu_time: 60\n"
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Analyzer.Timeout != tt.want {
+				t.Errorf("analyzer.timeout = %v, want %v", cfg.Analyzer.Timeout, tt.want)
+			}
+			if cfg.Analyzer.Sandbox.CPUTime != time.Minute {
+				t.Errorf("analyzer.sandbox.cpu_time = %v, want 1m", cfg.Analyzer.Sandbox.CPUTime)
+			}
+			if cfg.Linters.GolangciLint.Timeout != 5*time.Minute {
+				t.Errorf("linters.golangci_lint.timeout = %v, want default 5m", cfg.Linters.GolangciLint.Timeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// restartOnly lists the settings that only take effect when the server
+// restarts: the transport, the log destination and the services opened at
+// startup
+var restartOnly = []string{"server.", "storage.", "cache.", "report.", "log.output", "log.format"}
+
+// Change is a setting that differs between two configs
+type Change struct {
+	Path string // Setting key, e.g. "analyzer.timeout"
+	From string
+	To   string
+}
+
+// RequiresRestart reports whether the change only takes effect when the
+// server restarts
+func (c Change) RequiresRestart() bool {
+	for _, prefix := range restartOnly {
+		if strings.HasPrefix(c.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns the settings that differ between two configs, by their
+// config file keys. Passwords are not included in the values.
+func Diff(old, new *Config) []Change {
+	var changes []Change
+	diffValues("", reflect.ValueOf(*old), reflect.ValueOf(*new), &changes)
+	return changes
+}
+
+// diffValues compares two values of the same type, descending into structs
+func diffValues(path string, old, new reflect.Value, changes *[]Change) {
+	if old.Kind() == reflect.Struct && old.Type() != reflect.TypeOf(time.Time{}) {
+		for i := 0; i < old.NumField(); i++ {
+			field := old.Type().Field(i)
+			key := field.Tag.Get("mapstructure")
+			if !field.IsExported() || key == "-" {
+				continue
+			}
+			if key == "" {
+				key = strings.ToLower(field.Name)
+			}
+			if path != "" {
+				key = path + "." + key
+			}
+			diffValues(key, old.Field(i), new.Field(i), changes)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(old.Interface(), new.Interface()) {
+		return
+	}
+	change := Change{Path: path, From: fmt.Sprint(old.Interface()), To: fmt.Sprint(new.Interface())}
+	if strings.HasSuffix(path, "password") {
+		change.From, change.To = "***", "***"
+	}
+	*changes = append(*changes, change)
+}
+
+// KeepRestartOnly returns a copy of next whose restart-only settings are
+// those of current, so that a reload applies only what it can
+func KeepRestartOnly(current, next *Config) *Config {
+	merged := *next
+	merged.Server = current.Server
+	merged.Storage = current.Storage
+	merged.Cache = current.Cache
+	merged.Report = current.Report
+	merged.Log.Output = current.Log.Output
+	merged.Log.Format = current.Log.Format
+	return &merged
+}
+
+// Watch calls onChange when files in dir that match change, once they
+// have been quiet for delay so that an editor's save is seen as one
+// change. It watches until stop is closed.
+func Watch(dir string, match func(file string) bool, delay time.Duration, onChange func(), stop <-chan struct{}, logger *zap.Logger) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	// Watch the directory rather than the files to see atomic saves,
+	// which replace a file by renaming another over it
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	go func() {
+		defer watcher.Close()
+		timer := time.NewTimer(delay)
+		timer.Stop()
+
+		for {
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op == fsnotify.Chmod || !match(filepath.Clean(event.Name)) {
+					continue
+				}
+				timer.Reset(delay)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warn("File watcher error", zap.String("dir", dir), zap.Error(err))
+			case <-timer.C:
+				onChange()
+			}
+		}
+	}()
+	return nil
+}
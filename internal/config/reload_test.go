@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Config{}
+	old.Analyzer.Timeout = 5 * time.Minute
+	old.Linters.Gosec.Enabled = true
+	old.Storage.Postgres.Password = "secret"
+	old.File = "a.yaml"
+
+	new := *old
+	new.Analyzer.Timeout = time.Minute
+	new.Linters.Gosec.Enabled = false
+	new.Storage.Postgres.Password = "other"
+	new.File = "b.yaml"
+
+	want := map[string]Change{
+		"analyzer.timeout":          {Path: "analyzer.timeout", From: "5m0s", To: "1m0s"},
+		"linters.gosec.enabled":     {Path: "linters.gosec.enabled", From: "true", To: "false"},
+		"storage.postgres.password": {Path: "storage.postgres.password", From: "***", To: "***"},
+	}
+	restart := map[string]bool{"storage.postgres.password": true}
+
+	changes := Diff(old, &new)
+	if len(changes) != len(want) {
+		t.Fatalf("Diff() = %v, want %d changes", changes, len(want))
+	}
+	for _, c := range changes {
+		if c != want[c.Path] {
+			t.Errorf("change %s = %+v, want %+v", c.Path, c, want[c.Path])
+		}
+		if c.RequiresRestart() != restart[c.Path] {
+			t.Errorf("change %s RequiresRestart() = %v", c.Path, c.RequiresRestart())
+		}
+	}
+}
+
+func TestKeepRestartOnly(t *testing.T) {
+	current := &Config{Server: ServerConfig{Mode: "stdio"}, Log: LogConfig{Level: "info", Output: "stdout"}}
+	next := &Config{Server: ServerConfig{Mode: "http"}, Log: LogConfig{Level: "debug", Output: "server.log"}}
+
+	merged := KeepRestartOnly(current, next)
+	if merged.Server.Mode != "stdio" || merged.Log.Output != "stdout" {
+		t.Errorf("restart-only settings changed: %+v", merged)
+	}
+	if merged.Log.Level != "debug" {
+		t.Errorf("Log.Level = %s, want debug", merged.Log.Level)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+
+	changed := make(chan struct{}, 10)
+	stop := make(chan struct{})
+	defer close(stop)
+	match := func(name string) bool { return name == file }
+	if err := Watch(dir, match, 20*time.Millisecond, func() { changed <- struct{}{} }, stop, zap.NewNop()); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Other files are ignored, and a burst of writes is one change
+	if err := os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := os.WriteFile(file, []byte("log:\n  level: debug\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported")
+	}
+	select {
+	case <-changed:
+		t.Error("burst of writes reported more than once")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
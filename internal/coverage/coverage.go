@@ -0,0 +1,365 @@
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-standards-mcp-server/pkg/linters"
+)
+
+// Block is a single basic block from a coverage profile
+type Block struct {
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	NumStmt   int
+	Count     int
+}
+
+// Profile maps file import paths (e.g. example.com/mod/pkg/file.go) to
+// their coverage blocks
+type Profile struct {
+	Mode  string
+	Files map[string][]Block
+}
+
+// FuncCoverage is the coverage of a single function
+type FuncCoverage struct {
+	File       string // Relative to the module root
+	Line       int
+	Name       string
+	Statements int
+	Covered    int
+}
+
+// Percent returns the function's statement coverage in percent
+func (f FuncCoverage) Percent() float64 {
+	return percent(f.Covered, f.Statements)
+}
+
+// PackageCoverage is the coverage of a single package
+type PackageCoverage struct {
+	Path       string
+	Statements int
+	Covered    int
+}
+
+// Percent returns the package's statement coverage in percent
+func (p PackageCoverage) Percent() float64 {
+	return percent(p.Covered, p.Statements)
+}
+
+// Report is the computed coverage of a module
+type Report struct {
+	Statements int
+	Covered    int
+	Packages   []PackageCoverage
+	Functions  []FuncCoverage
+}
+
+// Percent returns the total statement coverage in percent
+func (r *Report) Percent() float64 {
+	return percent(r.Covered, r.Statements)
+}
+
+// RunTests runs go test with coverage enabled in dir and writes the profile
+// to profilePath. Test failures do not prevent a profile from being written,
+// so the returned output should be inspected by the caller on error. Tests
+// run in the linter sandbox attached to ctx, if any.
+func RunTests(ctx context.Context, dir, profilePath string) ([]byte, error) {
+	cmd, cleanup, err := linters.Command(ctx, dir, "go", "test", "-covermode=set", "-coverprofile="+profilePath, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare go test: %w", err)
+	}
+	defer cleanup()
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err = cmd.Run()
+	if limitErr := linters.LimitExceeded(ctx, cmd.ProcessState, output.Bytes()); limitErr != nil {
+		return output.Bytes(), limitErr
+	}
+	return output.Bytes(), err
+}
+
+// LoadProfile reads a coverage profile from file
+func LoadProfile(path string) (*Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open coverage profile: %w", err)
+	}
+	defer file.Close()
+
+	return ParseProfile(file)
+}
+
+// ParseProfile parses a coverage profile as written by go test -coverprofile
+func ParseProfile(r io.Reader) (*Profile, error) {
+	profile := &Profile{Files: make(map[string][]Block)}
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "mode:") {
+			profile.Mode = strings.TrimSpace(strings.TrimPrefix(line, "mode:"))
+			continue
+		}
+
+		// Format: name.go:line.column,line.column numberOfStatements count
+		file, block, err := parseBlock(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coverage profile line %d: %w", lineNum, err)
+		}
+		profile.Files[file] = append(profile.Files[file], block)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read coverage profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// parseBlock parses a single profile line
+func parseBlock(line string) (string, Block, error) {
+	var b Block
+
+	colon := strings.LastIndex(line, ":")
+	if colon < 0 {
+		return "", b, fmt.Errorf("missing file separator")
+	}
+	file := line[:colon]
+
+	fields := strings.Fields(line[colon+1:])
+	if len(fields) != 3 {
+		return "", b, fmt.Errorf("expected 3 fields, got %d", len(fields))
+	}
+
+	n, err := fmt.Sscanf(fields[0], "%d.%d,%d.%d", &b.StartLine, &b.StartCol, &b.EndLine, &b.EndCol)
+	if err != nil || n != 4 {
+		return "", b, fmt.Errorf("invalid block position %q", fields[0])
+	}
+	if b.NumStmt, err = strconv.Atoi(fields[1]); err != nil {
+		return "", b, fmt.Errorf("invalid statement count %q", fields[1])
+	}
+	if b.Count, err = strconv.Atoi(fields[2]); err != nil {
+		return "", b, fmt.Errorf("invalid hit count %q", fields[2])
+	}
+
+	return file, b, nil
+}
+
+// Split divides a profile covering several modules, such as one written
+// from a workspace, into one profile per module path. Each file goes to the
+// module with the longest matching path, so files of a nested module are
+// not also counted for the module containing it. Files of no listed module
+// are dropped.
+func Split(profile *Profile, modulePaths []string) map[string]*Profile {
+	profiles := make(map[string]*Profile, len(modulePaths))
+	for _, modulePath := range modulePaths {
+		profiles[modulePath] = &Profile{Mode: profile.Mode, Files: make(map[string][]Block)}
+	}
+
+	for file, blocks := range profile.Files {
+		owner := ""
+		for _, modulePath := range modulePaths {
+			if _, ok := moduleRelative(file, modulePath); ok && len(modulePath) > len(owner) {
+				owner = modulePath
+			}
+		}
+		if owner != "" {
+			profiles[owner].Files[file] = blocks
+		}
+	}
+
+	return profiles
+}
+
+// Compute computes package, function and total coverage for the module
+// rooted at moduleDir. Files belonging to other modules are skipped; with an
+// empty modulePath all files are counted but none can be mapped to functions.
+func Compute(profile *Profile, moduleDir, modulePath string) *Report {
+	report := &Report{}
+	packages := make(map[string]*PackageCoverage)
+
+	files := make([]string, 0, len(profile.Files))
+	for file := range profile.Files {
+		if _, ok := moduleRelative(file, modulePath); ok || modulePath == "" {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		blocks := mergeBlocks(profile.Files[file])
+
+		pkgPath := path.Dir(file)
+		pkg, ok := packages[pkgPath]
+		if !ok {
+			pkg = &PackageCoverage{Path: pkgPath}
+			packages[pkgPath] = pkg
+		}
+
+		for _, b := range blocks {
+			pkg.Statements += b.NumStmt
+			if b.Count > 0 {
+				pkg.Covered += b.NumStmt
+			}
+		}
+
+		if rel, ok := moduleRelative(file, modulePath); ok {
+			report.Functions = append(report.Functions, functionCoverage(moduleDir, rel, blocks)...)
+		}
+	}
+
+	for _, pkgPath := range sortedKeys(packages) {
+		pkg := packages[pkgPath]
+		report.Packages = append(report.Packages, *pkg)
+		report.Statements += pkg.Statements
+		report.Covered += pkg.Covered
+	}
+
+	return report
+}
+
+// mergeBlocks combines duplicate blocks, which appear when a package is
+// covered by several test binaries
+func mergeBlocks(blocks []Block) []Block {
+	type key struct{ sl, sc, el, ec int }
+	merged := make(map[key]*Block)
+	var order []key
+
+	for _, b := range blocks {
+		k := key{b.StartLine, b.StartCol, b.EndLine, b.EndCol}
+		if existing, ok := merged[k]; ok {
+			existing.Count += b.Count
+			continue
+		}
+		b := b
+		merged[k] = &b
+		order = append(order, k)
+	}
+
+	result := make([]Block, 0, len(order))
+	for _, k := range order {
+		result = append(result, *merged[k])
+	}
+	return result
+}
+
+// functionCoverage attributes blocks to the functions declared in the file
+func functionCoverage(moduleDir, relFile string, blocks []Block) []FuncCoverage {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(moduleDir, filepath.FromSlash(relFile)), nil, 0)
+	if err != nil {
+		return nil
+	}
+
+	var funcs []FuncCoverage
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+
+		start := fset.Position(fn.Pos())
+		end := fset.Position(fn.End())
+		fc := FuncCoverage{
+			File: relFile,
+			Line: start.Line,
+			Name: funcName(fn),
+		}
+
+		for _, b := range blocks {
+			if !contains(start, end, b) {
+				continue
+			}
+			fc.Statements += b.NumStmt
+			if b.Count > 0 {
+				fc.Covered += b.NumStmt
+			}
+		}
+
+		funcs = append(funcs, fc)
+	}
+
+	return funcs
+}
+
+// contains reports whether the block lies within the function's range
+func contains(start, end token.Position, b Block) bool {
+	if b.StartLine < start.Line || (b.StartLine == start.Line && b.StartCol < start.Column) {
+		return false
+	}
+	if b.EndLine > end.Line || (b.EndLine == end.Line && b.EndCol > end.Column) {
+		return false
+	}
+	return true
+}
+
+// funcName returns the function name, qualified with its receiver type
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if index, ok := typ.(*ast.IndexExpr); ok {
+		typ = index.X
+	}
+	if index, ok := typ.(*ast.IndexListExpr); ok {
+		typ = index.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// moduleRelative strips the module path from a profile file name
+func moduleRelative(file, modulePath string) (string, bool) {
+	if modulePath == "" || !strings.HasPrefix(file, modulePath+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(file, modulePath+"/"), true
+}
+
+// percent returns covered/total in percent, treating empty sets as covered
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) / float64(total) * 100
+}
+
+// sortedKeys returns the keys of the map in sorted order
+func sortedKeys(m map[string]*PackageCoverage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSource = `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func Sub(a, b int) int {
+	return a - b
+}
+`
+
+func TestCompute(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "calc.go"), []byte(testSource), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	profile, err := ParseProfile(strings.NewReader(`mode: set
+example.com/calc/calc.go:3.24,5.2 1 1
+example.com/calc/calc.go:7.24,9.2 1 0
+example.com/calc/calc.go:7.24,9.2 1 0
+example.com/other/x.go:1.1,2.2 5 1
+`))
+	if err != nil {
+		t.Fatalf("ParseProfile() error = %v", err)
+	}
+	if profile.Mode != "set" {
+		t.Errorf("Mode = %s, want set", profile.Mode)
+	}
+
+	report := Compute(profile, dir, "example.com/calc")
+	if report.Statements != 2 || report.Covered != 1 {
+		t.Errorf("Compute() statements = %d, covered = %d, want 2, 1", report.Statements, report.Covered)
+	}
+	if report.Percent() != 50 {
+		t.Errorf("Percent() = %.1f, want 50", report.Percent())
+	}
+
+	want := map[string]int{"Add": 1, "Sub": 0}
+	if len(report.Functions) != len(want) {
+		t.Fatalf("Compute() functions = %+v, want %d", report.Functions, len(want))
+	}
+	for _, fn := range report.Functions {
+		if covered, ok := want[fn.Name]; !ok || fn.Covered != covered {
+			t.Errorf("Function %s covered = %d, want %d", fn.Name, fn.Covered, covered)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	profile, err := ParseProfile(strings.NewReader(`mode: set
+example.com/app/main.go:3.13,5.2 2 1
+example.com/app/tools/gen.go:3.13,5.2 3 0
+example.com/app/toolsx/x.go:3.13,5.2 1 1
+example.com/other/x.go:1.1,2.2 5 1
+`))
+	if err != nil {
+		t.Fatalf("ParseProfile() error = %v", err)
+	}
+
+	profiles := Split(profile, []string{"example.com/app", "example.com/app/tools"})
+	want := map[string]int{"example.com/app": 3, "example.com/app/tools": 3}
+	for modulePath, statements := range want {
+		report := Compute(profiles[modulePath], t.TempDir(), modulePath)
+		if report.Statements != statements {
+			t.Errorf("%s statements = %d, want %d", modulePath, report.Statements, statements)
+		}
+	}
+	if got := len(profiles["example.com/app"].Files); got != 2 {
+		t.Errorf("example.com/app files = %d, want 2 (main.go and toolsx/x.go)", got)
+	}
+}
+
+func TestParseProfileInvalid(t *testing.T) {
+	if _, err := ParseProfile(strings.NewReader("mode: set\nbroken line\n")); err == nil {
+		t.Error("ParseProfile() expected error for invalid line")
+	}
+}
@@ -16,6 +16,8 @@ type IncrementalConfig struct {
 	ConfigFile   string `json:"config_file"`   // Path to golangci-lint config
 	FailOnError  bool   `json:"fail_on_error"` // Fail git operation on error
 	HooksInstalled bool `json:"hooks_installed"` // Whether git hooks are installed
+
+	Standards *Standards `json:"standards,omitempty"` // Project standards picked up by analysis
 }
 
 // DefaultIncrementalConfig returns default configuration
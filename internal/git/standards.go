@@ -0,0 +1,173 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go-standards-mcp-server/internal/policy"
+)
+
+// StandardsFile is the file holding a project's standards and its
+// incremental check settings
+const StandardsFile = ".go-standards.json"
+
+// Standards declares the analysis rules of a project, in the "standards"
+// section of .go-standards.json
+type Standards struct {
+	Standard  string              `json:"standard,omitempty"`  // strict, standard, relaxed, or another template
+	Config    string              `json:"config,omitempty"`    // Lint config: stored config, template, or path relative to the project root
+	Overrides []DirectoryStandard `json:"overrides,omitempty"` // Standards for parts of the project; the longest matching path wins
+	Exclude   []string            `json:"exclude,omitempty"`   // Paths whose issues are dropped, e.g. "vendor/" or "*.pb.go"
+	Generated string              `json:"generated,omitempty"` // exclude (default) or include issues in generated files
+	Policy    []policy.Rule       `json:"policy,omitempty"`    // Severity rules, evaluated before the server's
+}
+
+// DirectoryStandard applies a different standard or lint config to a
+// directory of the project
+type DirectoryStandard struct {
+	Path     string `json:"path"` // Directory relative to the project root, e.g. "pkg/"
+	Standard string `json:"standard,omitempty"`
+	Config   string `json:"config,omitempty"`
+}
+
+// Validate checks that the standards are well-formed
+func (s *Standards) Validate() error {
+	for i, o := range s.Overrides {
+		dir := cleanDir(o.Path)
+		if dir == "" || dir == "." || strings.HasPrefix(dir, "../") || path.IsAbs(dir) {
+			return fmt.Errorf("override %d: path must be a directory inside the project, not %q", i+1, o.Path)
+		}
+		if o.Standard == "" && o.Config == "" {
+			return fmt.Errorf("override %d (%s): standard or config is required", i+1, o.Path)
+		}
+	}
+	for _, pattern := range s.Exclude {
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/"), ""); err != nil {
+			return fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+	switch s.Generated {
+	case "", "exclude", "include":
+	default:
+		return fmt.Errorf("generated must be exclude or include, not %q", s.Generated)
+	}
+	for i, rule := range s.Policy {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("policy rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Excluded reports whether a file, given relative to the project root,
+// matches an exclude pattern. A pattern ending in "/" names a directory,
+// a pattern without "/" matches file names anywhere, and any other pattern
+// matches the path or one of its parent directories.
+func (s *Standards) Excluded(file string) bool {
+	file = filepath.ToSlash(filepath.Clean(file))
+	for _, pattern := range s.Exclude {
+		switch {
+		case strings.HasSuffix(pattern, "/"):
+			dir := cleanDir(pattern)
+			if strings.HasPrefix(file, dir+"/") {
+				return true
+			}
+		case !strings.Contains(pattern, "/"):
+			if ok, _ := path.Match(pattern, path.Base(file)); ok {
+				return true
+			}
+		default:
+			for p := file; p != "." && p != "/"; p = path.Dir(p) {
+				if ok, _ := path.Match(pattern, p); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ExcludeGenerated reports whether issues in generated files are dropped
+func (s *Standards) ExcludeGenerated() bool {
+	return s.Generated != "include"
+}
+
+// cleanDir normalizes a directory path from the standards file
+func cleanDir(dir string) string {
+	return path.Clean(strings.TrimSuffix(filepath.ToSlash(dir), "/"))
+}
+
+// FindStandards looks for .go-standards.json in dir and its parents up to
+// the repository root, and returns the standards declared in the first one
+// found with the path of that file. It returns nil standards if no file is
+// found or the file has no standards section.
+func FindStandards(dir string) (*Standards, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	for {
+		file := filepath.Join(dir, StandardsFile)
+		if _, err := os.Stat(file); err == nil {
+			standards, err := NewConfigManager(dir).LoadStandards()
+			return standards, file, err
+		}
+
+		// Do not look beyond the repository
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return nil, "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadStandards loads and validates the standards section, or returns nil
+// if the file or the section does not exist
+func (cm *ConfigManager) LoadStandards() (*Standards, error) {
+	config, err := cm.Load()
+	if err != nil {
+		return nil, err
+	}
+	if config.Standards == nil {
+		return nil, nil
+	}
+	if err := config.Standards.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid standards in %s: %w", cm.configPath, err)
+	}
+	return config.Standards, nil
+}
+
+// generatedHeader matches the comment that marks generated Go files
+var generatedHeader = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// IsGenerated reports whether a Go file carries the standard generated
+// code comment before its package clause
+func IsGenerated(file string) bool {
+	f, err := os.Open(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if generatedHeader.MatchString(line) {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStandards_Excluded(t *testing.T) {
+	s := &Standards{Exclude: []string{"vendor/", "*.pb.go", "internal/legacy", "gen/*/models.go"}}
+
+	tests := []struct {
+		file string
+		want bool
+	}{
+		{"vendor/github.com/x/y.go", true},
+		{"api/service.pb.go", true},
+		{"internal/legacy/old.go", true},
+		{"internal/legacy", true},
+		{"gen/v1/models.go", true},
+		{"internal/legacyx/new.go", false},
+		{"cmd/vendor.go", false},
+		{"main.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := s.Excluded(tt.file); got != tt.want {
+			t.Errorf("Excluded(%q) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestStandards_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		standards Standards
+		wantErr   bool
+	}{
+		{"valid", Standards{Standard: "strict", Overrides: []DirectoryStandard{{Path: "cmd/", Standard: "relaxed"}}}, false},
+		{"override outside project", Standards{Overrides: []DirectoryStandard{{Path: "../other", Standard: "strict"}}}, true},
+		{"override without standard", Standards{Overrides: []DirectoryStandard{{Path: "pkg"}}}, true},
+		{"bad exclude pattern", Standards{Exclude: []string{"[a-"}}, true},
+		{"bad generated mode", Standards{Generated: "sometimes"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.standards.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindStandards(t *testing.T) {
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "services", "api")
+	for _, dir := range []string{filepath.Join(repo, ".git"), sub} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+
+	// Nothing found up to the repository root
+	standards, _, err := FindStandards(sub)
+	if err != nil || standards != nil {
+		t.Fatalf("FindStandards() = %v, %v; want nil, nil", standards, err)
+	}
+
+	cm := NewConfigManager(repo)
+	if err := cm.Save(&IncrementalConfig{Standards: &Standards{Standard: "strict"}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	standards, file, err := FindStandards(sub)
+	if err != nil {
+		t.Fatalf("FindStandards() error = %v", err)
+	}
+	if standards == nil || standards.Standard != "strict" || file != filepath.Join(repo, StandardsFile) {
+		t.Errorf("FindStandards() = %+v, %s", standards, file)
+	}
+
+	// The incremental settings are kept when they are saved
+	if err := cm.Enable(); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if standards, err := cm.LoadStandards(); err != nil || standards == nil {
+		t.Errorf("LoadStandards() after Enable = %v, %v", standards, err)
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"gen.go":  "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n",
+		"hand.go": "// Package api is written by hand.\npackage api\n\n// Code generated by x. DO NOT EDIT.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	if !IsGenerated(filepath.Join(dir, "gen.go")) {
+		t.Error("IsGenerated(gen.go) = false")
+	}
+	if IsGenerated(filepath.Join(dir, "hand.go")) {
+		t.Error("IsGenerated(hand.go) = true")
+	}
+}
@@ -0,0 +1,101 @@
+package lintconfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// sectionOrder is the order golangci-lint documents its top-level sections
+// in. Configs converted from TOML, which has no key order, use it.
+var sectionOrder = []string{"version", "extends", "run", "linters", "formatters", "issues", "severity", "output"}
+
+// FormatOf returns the format of a golangci-lint config file from its
+// extension: yaml, json, or toml
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	default:
+		return "yaml"
+	}
+}
+
+// ToYAML converts a config in the given format to YAML. JSON keeps its key
+// order; TOML keys are sorted, with the top-level sections in the order
+// golangci-lint documents them.
+func ToYAML(data []byte, format string) ([]byte, error) {
+	switch format {
+	case "yaml", "yml", "":
+		return data, nil
+	case "json":
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON config: %w", err)
+		}
+		if doc.Kind == 0 {
+			return nil, fmt.Errorf("JSON config is empty")
+		}
+		blockStyle(&doc)
+		return encode(&doc)
+	case "toml":
+		var values map[string]interface{}
+		if err := toml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse TOML config: %w", err)
+		}
+		var doc yaml.Node
+		if err := doc.Encode(values); err != nil {
+			return nil, fmt.Errorf("failed to convert TOML config: %w", err)
+		}
+		orderSections(&doc)
+		return encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&doc}})
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+}
+
+// blockStyle clears the flow and quoting styles JSON is parsed with, so
+// the tree is written as ordinary block YAML
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+}
+
+// orderSections puts the known top-level sections of a mapping first, in
+// sectionOrder
+func orderSections(m *yaml.Node) {
+	if m.Kind != yaml.MappingNode {
+		return
+	}
+	rank := func(key string) int {
+		for i, name := range sectionOrder {
+			if name == key {
+				return i
+			}
+		}
+		return len(sectionOrder)
+	}
+
+	pairs := make([][2]*yaml.Node, 0, len(m.Content)/2)
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{m.Content[i], m.Content[i+1]})
+	}
+	// Keys are already sorted, so a stable insertion by rank keeps unknown
+	// sections in alphabetical order after the known ones
+	for i := 1; i < len(pairs); i++ {
+		for j := i; j > 0 && rank(pairs[j][0].Value) < rank(pairs[j-1][0].Value); j-- {
+			pairs[j], pairs[j-1] = pairs[j-1], pairs[j]
+		}
+	}
+	m.Content = m.Content[:0]
+	for _, p := range pairs {
+		m.Content = append(m.Content, p[0], p[1])
+	}
+}
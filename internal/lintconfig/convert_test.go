@@ -0,0 +1,81 @@
+package lintconfig
+
+import (
+	"testing"
+)
+
+func TestToYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "yaml is unchanged",
+			data:   "version: \"2\"\n",
+			format: "yaml",
+			want:   "version: \"2\"\n",
+		},
+		{
+			name:   "json keeps key order",
+			data:   `{"version": "2", "run": {"timeout": "5m"}, "linters": {"enable": ["errcheck", "govet"]}}`,
+			format: "json",
+			want:   "version: \"2\"\nrun:\n  timeout: 5m\nlinters:\n  enable:\n    - errcheck\n    - govet\n",
+		},
+		{
+			name:   "toml sections in documented order",
+			data:   "version = \"2\"\n\n[issues]\nmax-same-issues = 3\n\n[linters]\nenable = [\"errcheck\"]\n\n[linters.settings.lll]\nline-length = 120\n",
+			format: "toml",
+			want:   "version: \"2\"\nlinters:\n  enable:\n    - errcheck\n  settings:\n    lll:\n      line-length: 120\nissues:\n  max-same-issues: 3\n",
+		},
+		{
+			name:    "invalid toml",
+			data:    "[linters\n",
+			format:  "toml",
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			data:    "x",
+			format:  "ini",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToYAML([]byte(tt.data), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToYAML() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProvenance(t *testing.T) {
+	body := []byte("version: \"2\"\nlinters:\n  default: standard\n")
+	exported := AddProvenance(body, "config:team@3")
+
+	provenance, got := ParseProvenance(exported)
+	if provenance == nil {
+		t.Fatal("ParseProvenance() found no header in an exported config")
+	}
+	if provenance.Source != "config:team@3" || provenance.Ref() != "config:team" {
+		t.Errorf("provenance = %+v, want source config:team@3", provenance)
+	}
+	if string(got) != string(body) || provenance.Edited(got) {
+		t.Errorf("body = %q, want the exported config unedited", got)
+	}
+	if !provenance.Edited(append(got, "# tweak\n"...)) {
+		t.Error("Edited() = false for a changed body")
+	}
+
+	if provenance, got := ParseProvenance(body); provenance != nil || string(got) != string(body) {
+		t.Errorf("ParseProvenance() of a plain config = %+v, %q", provenance, got)
+	}
+}
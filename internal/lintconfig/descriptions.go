@@ -0,0 +1,118 @@
+package lintconfig
+
+// linterDescriptions says what each linter and formatter checks
+var linterDescriptions = map[string]string{
+	"asasalint":                 "Checks for []any passed as a single value to a variadic func(...any)",
+	"asciicheck":                "Checks that identifiers contain only ASCII characters",
+	"bidichk":                   "Checks for dangerous Unicode bidirectional control characters",
+	"bodyclose":                 "Checks that HTTP response bodies are closed",
+	"canonicalheader":           "Checks that HTTP header names are written in canonical form",
+	"containedctx":              "Detects structs that contain a context.Context field",
+	"contextcheck":              "Checks that functions pass on the context they received instead of creating a new one",
+	"copyloopvar":               "Detects needless copies of loop variables, which Go 1.22 made unnecessary",
+	"cyclop":                    "Checks the cyclomatic complexity of functions and packages",
+	"decorder":                  "Checks the order and grouping of type, const, var and func declarations",
+	"depguard":                  "Checks that imported packages are on the allowed list",
+	"dogsled":                   "Checks for assignments with too many blank identifiers, like x, _, _, _ := f()",
+	"dupl":                      "Detects duplicated blocks of code",
+	"dupword":                   "Checks for duplicated words in comments and strings, like \"the the\"",
+	"durationcheck":             "Checks for two durations multiplied together",
+	"embeddedstructfieldcheck":  "Checks that embedded fields come first in a struct, separated by an empty line",
+	"err113":                    "Checks that errors are wrapped or compared with errors.Is rather than created dynamically and compared with ==",
+	"errcheck":                  "Checks that returned errors are not ignored",
+	"errchkjson":                "Checks that errors from encoding/json are checked, and reports calls that cannot fail",
+	"errname":                   "Checks that error types end in Error and sentinel errors start with Err",
+	"errorlint":                 "Checks that wrapped errors are compared with errors.Is/As and wrapped with %w",
+	"exhaustive":                "Checks that switch statements on enums cover every value",
+	"exhaustruct":               "Checks that struct literals set every field",
+	"exptostd":                  "Detects functions from golang.org/x/exp that have standard library replacements",
+	"fatcontext":                "Detects nested contexts in loops and function literals",
+	"forbidigo":                 "Forbids configured identifiers, such as fmt.Println",
+	"forcetypeassert":           "Checks for type assertions without the ok check",
+	"funcorder":                 "Checks that constructors follow their type and exported methods come before unexported ones",
+	"funlen":                    "Checks the length of functions in lines and statements",
+	"ginkgolinter":              "Enforces standards for tests written with Ginkgo and Gomega",
+	"gocheckcompilerdirectives": "Checks that //go: compiler directives are valid",
+	"gochecknoglobals":          "Checks that there are no global variables",
+	"gochecknoinits":            "Checks that there are no init functions",
+	"gochecksumtype":            "Checks that type switches on sum types are exhaustive",
+	"gocognit":                  "Checks the cognitive complexity of functions: how hard they are to follow",
+	"goconst":                   "Finds repeated strings that could be constants",
+	"gocritic":                  "Runs a large set of checks for bugs, performance problems and style",
+	"gocyclo":                   "Checks the cyclomatic complexity of functions: the number of independent paths through them",
+	"godot":                     "Checks that comments end in a period",
+	"godox":                     "Detects TODO, FIXME and BUG comments",
+	"goheader":                  "Checks that files start with the configured header",
+	"gomoddirectives":           "Checks replace, retract and exclude directives in go.mod",
+	"gomodguard":                "Checks modules against allowed and blocked lists",
+	"goprintffuncname":          "Checks that printf-like functions end in f",
+	"gosec":                     "Inspects code for security problems, such as SQL injection, weak crypto and unsafe file permissions",
+	"gosmopolitan":              "Detects hard-coded strings and time zones that get in the way of internationalization",
+	"govet":                     "Runs go vet: suspicious constructs such as wrong printf arguments, unreachable code and copied locks",
+	"grouper":                   "Checks how declarations are grouped",
+	"iface":                     "Detects incorrect use of interfaces, such as identical or unused ones",
+	"importas":                  "Enforces consistent aliases for imported packages",
+	"inamedparam":               "Checks that interface method parameters are named",
+	"ineffassign":               "Detects assignments whose value is never used",
+	"interfacebloat":            "Checks the number of methods in an interface",
+	"intrange":                  "Finds loops that could use an integer range (Go 1.22)",
+	"ireturn":                   "Checks that functions accept interfaces and return concrete types",
+	"lll":                       "Checks the length of lines",
+	"loggercheck":               "Checks key-value pairs passed to common logging libraries",
+	"maintidx":                  "Checks the maintainability index of functions",
+	"makezero":                  "Finds slices made with a non-zero length and then appended to",
+	"mirror":                    "Suggests the string or []byte variant of a function to avoid conversions",
+	"misspell":                  "Finds commonly misspelled English words",
+	"mnd":                       "Detects magic numbers that should be named constants",
+	"musttag":                   "Checks that structs passed to encoders have field tags",
+	"nakedret":                  "Checks for naked returns in long functions",
+	"nestif":                    "Checks for deeply nested if statements",
+	"nilerr":                    "Finds code that returns nil even though it checks that an error is not nil",
+	"nilnesserr":                "Finds code that returns an error known to be nil instead of the one just checked",
+	"nilnil":                    "Checks that functions do not return a nil error together with an invalid value",
+	"nlreturn":                  "Checks for a blank line before return and branch statements",
+	"noctx":                     "Finds HTTP requests and other calls made without a context.Context",
+	"nolintlint":                "Checks that //nolint directives are well-formed and used",
+	"nonamedreturns":            "Checks that functions do not use named results",
+	"nosprintfhostport":         "Checks for URLs built with Sprintf from a host and port",
+	"paralleltest":              "Checks that tests call t.Parallel",
+	"perfsprint":                "Finds fmt.Sprintf calls that have faster alternatives",
+	"prealloc":                  "Finds slices that could be preallocated",
+	"predeclared":               "Finds declarations that shadow predeclared identifiers, such as len or new",
+	"promlinter":                "Checks Prometheus metric names",
+	"protogetter":               "Checks that protobuf fields are read through getters",
+	"reassign":                  "Checks that package variables of other packages are not reassigned",
+	"recvcheck":                 "Checks that a type's methods consistently use value or pointer receivers",
+	"revive":                    "Runs configurable style rules, a faster and stricter replacement for golint",
+	"rowserrcheck":              "Checks that the Err of sql.Rows is checked",
+	"sloglint":                  "Enforces a consistent style for log/slog calls",
+	"spancheck":                 "Checks that OpenTelemetry and OpenCensus spans are ended and errors recorded",
+	"sqlclosecheck":             "Checks that sql.Rows and sql.Stmt are closed",
+	"staticcheck":               "Runs staticcheck: bugs, simplifications and style (including the former gosimple and stylecheck)",
+	"tagalign":                  "Checks that struct tags are aligned and sorted",
+	"tagliatelle":               "Checks the naming style of struct tags",
+	"testableexamples":          "Checks that examples have an output comment so they are tested",
+	"testifylint":               "Checks for mistakes in the use of testify",
+	"testpackage":               "Checks that tests are in a separate _test package",
+	"thelper":                   "Checks that test helpers call t.Helper and take t as their first argument",
+	"tparallel":                 "Checks that t.Parallel is used consistently in tests and subtests",
+	"unconvert":                 "Finds unnecessary type conversions",
+	"unparam":                   "Finds function parameters that always receive the same value or are unused",
+	"unused":                    "Finds unused constants, variables, functions and types",
+	"usestdlibvars":             "Suggests constants from the standard library, such as http.MethodGet",
+	"usetesting":                "Suggests testing helpers such as t.TempDir and t.Context over their standard library versions",
+	"varnamelen":                "Checks that variable names are not too short for their scope",
+	"wastedassign":              "Finds assignments that are overwritten before they are used",
+	"whitespace":                "Checks for leading and trailing blank lines in functions and blocks",
+	"wrapcheck":                 "Checks that errors from external packages are wrapped",
+	"wsl":                       "Enforces blank lines around blocks and statements",
+	"zerologlint":               "Checks that zerolog events are sent",
+
+	// Formatters
+	"gci":       "Formats imports into configured sections",
+	"gofmt":     "Checks that code is formatted with gofmt",
+	"gofumpt":   "Checks that code is formatted with gofumpt, a stricter gofmt",
+	"goimports": "Checks that imports are grouped and sorted like goimports does",
+	"golines":   "Shortens long lines",
+	"swaggo":    "Formats swaggo comments",
+}
@@ -0,0 +1,657 @@
+package lintconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Effects of a config change on how much golangci-lint reports
+const (
+	EffectStricter = "stricter"
+	EffectLooser   = "looser"
+	EffectMixed    = "mixed"   // Tightens some checks and loosens others
+	EffectNeutral  = "neutral" // No known effect on strictness
+)
+
+// Diff is the semantic difference between two configs. Both configs are
+// compared in the v2 layout, so a v1 config and its migration are equal.
+type Diff struct {
+	From              string            `json:"from"`
+	To                string            `json:"to"`
+	Verdict           string            `json:"verdict"` // stricter, looser, mixed, neutral, or identical
+	LintersAdded      []string          `json:"linters_added,omitempty"`
+	LintersRemoved    []string          `json:"linters_removed,omitempty"`
+	FormattersAdded   []string          `json:"formatters_added,omitempty"`
+	FormattersRemoved []string          `json:"formatters_removed,omitempty"`
+	Settings          []SettingChange   `json:"settings,omitempty"`
+	Exclusions        []ExclusionChange `json:"exclusions,omitempty"`
+	Notes             []string          `json:"notes,omitempty"` // Limits of the comparison
+}
+
+// SettingChange is a changed linter setting or other option
+type SettingChange struct {
+	Path   string `json:"path"`
+	From   string `json:"from,omitempty"` // Empty if the setting was added
+	To     string `json:"to,omitempty"`   // Empty if the setting was removed
+	Effect string `json:"effect"`
+}
+
+// ExclusionChange is an added or removed exclusion
+type ExclusionChange struct {
+	Section string `json:"section"` // linters or formatters
+	Kind    string `json:"kind"`    // rule, path, path-except, preset, or generated
+	Change  string `json:"change"`  // added, removed, or changed
+	Value   string `json:"value"`
+	Effect  string `json:"effect"`
+}
+
+// standardLinters are the linters v2 enables with default: standard
+var standardLinters = []string{"errcheck", "govet", "ineffassign", "staticcheck", "unused"}
+
+// thresholds maps numeric settings to whether a higher value is stricter.
+// Keys below linters.settings omit that prefix.
+var thresholds = map[string]bool{
+	"cyclop.max-complexity":         false,
+	"cyclop.package-average":        false,
+	"dogsled.max-blank-identifiers": false,
+	"dupl.threshold":                false,
+	"funlen.lines":                  false,
+	"funlen.statements":             false,
+	"gocognit.min-complexity":       false,
+	"goconst.min-len":               false,
+	"goconst.min-occurrences":       false,
+	"gocyclo.min-complexity":        false,
+	"interfacebloat.max":            false,
+	"lll.line-length":               false,
+	"maintidx.under":                true,
+	"nakedret.max-func-lines":       false,
+	"nestif.min-complexity":         false,
+
+	// 0 means unlimited for these
+	"issues.max-issues-per-linter": true,
+	"issues.max-same-issues":       true,
+}
+
+// toggles maps boolean settings to whether true is stricter
+var toggles = map[string]bool{
+	"errcheck.check-blank":           true,
+	"errcheck.check-type-assertions": true,
+	"godot.capital":                  true,
+	"govet.disable-all":              false,
+	"govet.enable-all":               true,
+	"nolintlint.allow-unused":        false,
+	"nolintlint.require-explanation": true,
+	"nolintlint.require-specific":    true,
+	"revive.enable-all-rules":        true,
+	"issues.new":                     false,
+	"issues.whole-files":             false,
+	"run.tests":                      true,
+}
+
+// checkLists maps lists of checks to whether adding an entry is stricter
+var checkLists = map[string]bool{
+	"gocritic.enabled-checks":    true,
+	"gocritic.disabled-checks":   false,
+	"gocritic.enabled-tags":      true,
+	"gocritic.disabled-tags":     false,
+	"gosec.includes":             true,
+	"gosec.excludes":             false,
+	"govet.enable":               true,
+	"govet.disable":              false,
+	"errcheck.exclude-functions": false,
+}
+
+// diffSkipped are paths compared separately or without effect on findings
+var diffSkipped = map[string]bool{
+	"version":               true,
+	"linters.default":       true,
+	"linters.enable":        true,
+	"linters.disable":       true,
+	"linters.exclusions":    true,
+	"formatters.enable":     true,
+	"formatters.exclusions": true,
+	"output":                true,
+}
+
+// Compare reports the semantic difference between two configs labelled
+// from and to. Configs that extend others must be resolved first.
+func Compare(fromData, toData []byte, from, to string) (*Diff, error) {
+	fromRoot, err := normalize(fromData, from)
+	if err != nil {
+		return nil, err
+	}
+	toRoot, err := normalize(toData, to)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Diff{From: from, To: to}
+	active := d.compareLinters(fromRoot, toRoot)
+
+	oldSettings, newSettings := make(map[string]string), make(map[string]string)
+	flatten(fromRoot, "", oldSettings)
+	flatten(toRoot, "", newSettings)
+	d.compareSettings(oldSettings, newSettings, active)
+
+	for _, section := range []string{"linters", "formatters"} {
+		d.compareExclusions(section, getPath(fromRoot, section+".exclusions"), getPath(toRoot, section+".exclusions"))
+	}
+
+	d.Verdict = d.verdict()
+	return d, nil
+}
+
+// normalize parses a config and migrates it to v2
+func normalize(data []byte, source string) (*yaml.Node, error) {
+	version, err := Version(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if version == 1 {
+		migration, err := Migrate(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		data = migration.Content
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse config: %w", source, err)
+	}
+	if len(doc.Content) == 0 {
+		return newMap(), nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: config must be a YAML mapping", source)
+	}
+	return doc.Content[0], nil
+}
+
+// compareLinters compares the sets of linters and formatters the configs
+// run, and returns those both run, or nil if that is unknown
+func (d *Diff) compareLinters(fromRoot, toRoot *yaml.Node) map[string]bool {
+	oldDefault := linterDefault(fromRoot)
+	newDefault := linterDefault(toRoot)
+	if oldDefault == "fast" || newDefault == "fast" {
+		d.Notes = append(d.Notes, "default: fast depends on the golangci-lint version; only explicitly enabled and disabled linters are compared")
+		oldDefault, newDefault = "none", "none"
+	}
+
+	oldLinters := enabledLinters(fromRoot, oldDefault)
+	newLinters := enabledLinters(toRoot, newDefault)
+	d.LintersAdded = setDifference(newLinters, oldLinters)
+	d.LintersRemoved = setDifference(oldLinters, newLinters)
+
+	oldFormatters := toSet(seqValues(getPath(fromRoot, "formatters.enable"))...)
+	newFormatters := toSet(seqValues(getPath(toRoot, "formatters.enable"))...)
+	d.FormattersAdded = setDifference(newFormatters, oldFormatters)
+	d.FormattersRemoved = setDifference(oldFormatters, newFormatters)
+
+	if len(d.Notes) > 0 {
+		return nil
+	}
+	active := make(map[string]bool)
+	for name := range oldLinters {
+		active[name] = newLinters[name]
+	}
+	for name := range oldFormatters {
+		active[name] = newFormatters[name]
+	}
+	return active
+}
+
+// linterDefault returns the v2 default linter set of a config
+func linterDefault(root *yaml.Node) string {
+	if def := getPath(root, "linters.default"); def != nil && def.Value != "" {
+		return def.Value
+	}
+	return "standard"
+}
+
+// enabledLinters returns the linters a v2 config runs
+func enabledLinters(root *yaml.Node, def string) map[string]bool {
+	enabled := make(map[string]bool)
+	switch def {
+	case "standard":
+		for _, name := range standardLinters {
+			enabled[name] = true
+		}
+	case "all":
+		for name := range knownLinters {
+			if _, removed := replacedLinters[name]; !removed && !formatters[name] && !v1OnlyLinters[name] && name != "swaggo" {
+				enabled[name] = true
+			}
+		}
+	}
+	for _, name := range seqValues(getPath(root, "linters.enable")) {
+		enabled[name] = true
+	}
+	for _, name := range seqValues(getPath(root, "linters.disable")) {
+		delete(enabled, name)
+	}
+	return enabled
+}
+
+// v1OnlyLinters are known linters that v2 no longer has
+var v1OnlyLinters = toSet("gomnd", "goerr113", "execinquery", "exportloopref", "tenv")
+
+// flatten records every setting below n as path → value. Lists of scalars
+// are joined into one value; list items with a name are keyed by it.
+func flatten(n *yaml.Node, path string, out map[string]string) {
+	if diffSkipped[path] {
+		return
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			flatten(n.Content[i+1], joinPath(path, n.Content[i].Value), out)
+		}
+	case yaml.SequenceNode:
+		if values := seqValues(n); len(values) == len(n.Content) {
+			out[path] = strings.Join(values, ", ")
+			return
+		}
+		for i, item := range n.Content {
+			name := get(item, "name")
+			if name == nil {
+				flatten(item, fmt.Sprintf("%s[%d]", path, i), out)
+				continue
+			}
+			// Named items such as revive rules are recorded as present, with
+			// their other keys below them
+			itemPath := fmt.Sprintf("%s[%s]", path, name.Value)
+			out[itemPath] = "enabled"
+			if isTrue(get(item, "disabled")) {
+				out[itemPath] = "disabled"
+			}
+			for j := 0; j+1 < len(item.Content); j += 2 {
+				if key := item.Content[j].Value; key != "name" && key != "disabled" {
+					flatten(item.Content[j+1], itemPath+"."+key, out)
+				}
+			}
+		}
+	case yaml.AliasNode:
+		flatten(n.Alias, path, out)
+	default:
+		out[path] = n.Value
+	}
+}
+
+// compareSettings compares flattened settings. Settings of a linter that
+// only one config runs have no effect of their own, since adding or
+// removing the linter already counts.
+func (d *Diff) compareSettings(oldSettings, newSettings map[string]string, active map[string]bool) {
+	paths := make(map[string]bool)
+	for path := range oldSettings {
+		paths[path] = true
+	}
+	for path := range newSettings {
+		paths[path] = true
+	}
+
+	for _, path := range sortedKeys(paths) {
+		oldValue, hadOld := oldSettings[path]
+		newValue, hasNew := newSettings[path]
+		if hadOld && hasNew && oldValue == newValue {
+			continue
+		}
+		effect := EffectNeutral
+		if name := settingsLinter(path); name == "" || active == nil || active[name] {
+			effect = settingEffect(path, oldValue, newValue, hadOld, hasNew)
+		}
+		d.Settings = append(d.Settings, SettingChange{
+			Path:   path,
+			From:   oldValue,
+			To:     newValue,
+			Effect: effect,
+		})
+	}
+}
+
+// settingsLinter returns the linter or formatter a settings path belongs
+// to, or "" for other options
+func settingsLinter(path string) string {
+	for _, prefix := range []string{"linters.settings.", "formatters.settings."} {
+		if rest, ok := strings.CutPrefix(path, prefix); ok {
+			name, _, _ := strings.Cut(rest, ".")
+			return name
+		}
+	}
+	return ""
+}
+
+// settingEffect judges whether a setting change is stricter or looser. An
+// added or removed threshold is compared against nothing, so its effect is
+// unknown.
+func settingEffect(path, oldValue, newValue string, hadOld, hasNew bool) string {
+	key := strings.TrimPrefix(strings.TrimPrefix(path, "linters.settings."), "formatters.settings.")
+
+	if higherStricter, ok := thresholds[key]; ok && hadOld && hasNew {
+		return thresholdEffect(key, oldValue, newValue, higherStricter)
+	}
+	if trueStricter, ok := toggles[key]; ok {
+		oldOn := strings.EqualFold(oldValue, "true")
+		newOn := strings.EqualFold(newValue, "true")
+		if key == "run.tests" {
+			// Tests are linted unless turned off
+			oldOn, newOn = oldValue != "false", newValue != "false"
+		}
+		switch {
+		case oldOn == newOn:
+			return EffectNeutral
+		case newOn == trueStricter:
+			return EffectStricter
+		default:
+			return EffectLooser
+		}
+	}
+	if addStricter, ok := checkLists[key]; ok {
+		added, removed := listDifference(oldValue, newValue)
+		return listEffect(len(added) > 0, len(removed) > 0, addStricter)
+	}
+	if key == "staticcheck.checks" {
+		return staticcheckEffect(oldValue, newValue)
+	}
+	if strings.HasPrefix(key, "revive.rules[") && !strings.Contains(key, "].") {
+		// A rule turned on or off; changes to its arguments are neutral
+		return combine(!hadOld || oldValue == "disabled", !hasNew || newValue == "disabled")
+	}
+	return EffectNeutral
+}
+
+// thresholdEffect compares two values of a numeric threshold
+func thresholdEffect(key, oldValue, newValue string, higherStricter bool) string {
+	oldN, err1 := strconv.ParseFloat(oldValue, 64)
+	newN, err2 := strconv.ParseFloat(newValue, 64)
+	if err1 != nil || err2 != nil || oldN == newN {
+		return EffectNeutral
+	}
+
+	// Zero turns the issue limits off, and a negative value disables
+	// size checks such as funlen
+	unlimited := func(n float64) bool {
+		if strings.HasPrefix(key, "issues.") {
+			return n == 0
+		}
+		return n < 0
+	}
+	switch {
+	case unlimited(oldN) && unlimited(newN):
+		return EffectNeutral
+	case unlimited(newN):
+		if higherStricter {
+			return EffectStricter
+		}
+		return EffectLooser
+	case unlimited(oldN):
+		if higherStricter {
+			return EffectLooser
+		}
+		return EffectStricter
+	case (newN > oldN) == higherStricter:
+		return EffectStricter
+	default:
+		return EffectLooser
+	}
+}
+
+// staticcheckEffect compares staticcheck check lists, where "-SA1000"
+// turns a check off
+func staticcheckEffect(oldValue, newValue string) string {
+	added, removed := listDifference(oldValue, newValue)
+	var stricter, looser bool
+	for _, c := range added {
+		if strings.HasPrefix(c, "-") {
+			looser = true
+		} else {
+			stricter = true
+		}
+	}
+	for _, c := range removed {
+		if strings.HasPrefix(c, "-") {
+			stricter = true
+		} else {
+			looser = true
+		}
+	}
+	return combine(stricter, looser)
+}
+
+// listEffect judges additions to and removals from a list of checks
+func listEffect(added, removed, addStricter bool) string {
+	if addStricter {
+		return combine(added, removed)
+	}
+	return combine(removed, added)
+}
+
+// combine turns the directions of a change into an effect
+func combine(stricter, looser bool) string {
+	switch {
+	case stricter && looser:
+		return EffectMixed
+	case stricter:
+		return EffectStricter
+	case looser:
+		return EffectLooser
+	}
+	return EffectNeutral
+}
+
+// listDifference splits two joined lists into added and removed items
+func listDifference(oldValue, newValue string) (added, removed []string) {
+	oldItems := toSet(splitList(oldValue)...)
+	newItems := toSet(splitList(newValue)...)
+	return setDifference(newItems, oldItems), setDifference(oldItems, newItems)
+}
+
+// splitList splits a list flattened by flatten
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ", ")
+}
+
+// compareExclusions compares an exclusions section. More exclusions hide
+// more findings, so additions are looser.
+func (d *Diff) compareExclusions(section string, oldEx, newEx *yaml.Node) {
+	for _, list := range []struct {
+		key  string
+		kind string
+	}{{"rules", "rule"}, {"paths", "path"}, {"paths-except", "path-except"}, {"presets", "preset"}} {
+		oldItems := exclusionItems(get(oldEx, list.key))
+		newItems := exclusionItems(get(newEx, list.key))
+
+		// paths-except narrows the excluded paths, so it works the other way
+		addEffect, removeEffect := EffectLooser, EffectStricter
+		if list.kind == "path-except" {
+			addEffect, removeEffect = EffectStricter, EffectLooser
+		}
+		for _, item := range setDifference(newItems, oldItems) {
+			d.Exclusions = append(d.Exclusions, ExclusionChange{Section: section, Kind: list.kind, Change: "added", Value: item, Effect: addEffect})
+		}
+		for _, item := range setDifference(oldItems, newItems) {
+			d.Exclusions = append(d.Exclusions, ExclusionChange{Section: section, Kind: list.kind, Change: "removed", Value: item, Effect: removeEffect})
+		}
+	}
+
+	oldMode, newMode := generatedMode(oldEx), generatedMode(newEx)
+	if oldMode != newMode {
+		// lax excludes the most generated files, disable none
+		rank := map[string]int{"lax": 0, "strict": 1, "disable": 2}
+		effect := EffectLooser
+		if rank[newMode] > rank[oldMode] {
+			effect = EffectStricter
+		}
+		d.Exclusions = append(d.Exclusions, ExclusionChange{
+			Section: section,
+			Kind:    "generated",
+			Change:  "changed",
+			Value:   oldMode + " → " + newMode,
+			Effect:  effect,
+		})
+	}
+}
+
+// generatedMode returns how an exclusions section treats generated files
+func generatedMode(exclusions *yaml.Node) string {
+	if mode := get(exclusions, "generated"); mode != nil && mode.Value != "" {
+		return mode.Value
+	}
+	return "lax"
+}
+
+// exclusionItems returns the items of an exclusions list as strings. Rules
+// are written as their sorted key=value pairs.
+func exclusionItems(list *yaml.Node) map[string]bool {
+	items := make(map[string]bool)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return items
+	}
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			items[item.Value] = true
+			continue
+		}
+		items[ruleKey(item)] = true
+	}
+	return items
+}
+
+// ruleKey writes an exclusion rule as its sorted key=value pairs
+func ruleKey(item *yaml.Node) string {
+	var parts []string
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		value := item.Content[i+1].Value
+		if item.Content[i+1].Kind == yaml.SequenceNode {
+			values := seqValues(item.Content[i+1])
+			sort.Strings(values)
+			value = "[" + strings.Join(values, ", ") + "]"
+		}
+		parts = append(parts, item.Content[i].Value+"="+value)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
+
+// verdict sums up the direction of all changes
+func (d *Diff) verdict() string {
+	var stricter, looser, changed bool
+	note := func(effect string) {
+		changed = true
+		switch effect {
+		case EffectStricter:
+			stricter = true
+		case EffectLooser:
+			looser = true
+		case EffectMixed:
+			stricter, looser = true, true
+		}
+	}
+
+	if len(d.LintersAdded) > 0 || len(d.FormattersAdded) > 0 {
+		note(EffectStricter)
+	}
+	if len(d.LintersRemoved) > 0 || len(d.FormattersRemoved) > 0 {
+		note(EffectLooser)
+	}
+	for _, s := range d.Settings {
+		note(s.Effect)
+	}
+	for _, e := range d.Exclusions {
+		note(e.Effect)
+	}
+
+	if !changed {
+		return "identical"
+	}
+	return combine(stricter, looser)
+}
+
+// Text formats the diff for reading in a terminal or a PR review
+func (d *Diff) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s → %s: %s\n", d.From, d.To, d.Verdict)
+
+	if len(d.LintersAdded)+len(d.LintersRemoved)+len(d.FormattersAdded)+len(d.FormattersRemoved) > 0 {
+		sb.WriteString("\nLinters:\n")
+		for _, name := range d.LintersAdded {
+			fmt.Fprintf(&sb, "  + %s\n", name)
+		}
+		for _, name := range d.LintersRemoved {
+			fmt.Fprintf(&sb, "  - %s\n", name)
+		}
+		for _, name := range d.FormattersAdded {
+			fmt.Fprintf(&sb, "  + %s (formatter)\n", name)
+		}
+		for _, name := range d.FormattersRemoved {
+			fmt.Fprintf(&sb, "  - %s (formatter)\n", name)
+		}
+	}
+
+	if len(d.Settings) > 0 {
+		sb.WriteString("\nSettings:\n")
+		for _, s := range d.Settings {
+			switch {
+			case s.From == "" && s.To != "":
+				fmt.Fprintf(&sb, "  + %s: %s", s.Path, s.To)
+			case s.To == "" && s.From != "":
+				fmt.Fprintf(&sb, "  - %s: %s", s.Path, s.From)
+			default:
+				fmt.Fprintf(&sb, "  ~ %s: %s → %s", s.Path, s.From, s.To)
+			}
+			if s.Effect != EffectNeutral {
+				fmt.Fprintf(&sb, " (%s)", s.Effect)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(d.Exclusions) > 0 {
+		sb.WriteString("\nExclusions:\n")
+		for _, e := range d.Exclusions {
+			sign := map[string]string{"added": "+", "removed": "-"}[e.Change]
+			if sign == "" {
+				sign = "~"
+			}
+			kind := e.Kind
+			if e.Section != "linters" {
+				kind = e.Section + " " + kind
+			}
+			fmt.Fprintf(&sb, "  %s %s %s (%s)\n", sign, kind, e.Value, e.Effect)
+		}
+	}
+
+	for _, note := range d.Notes {
+		fmt.Fprintf(&sb, "\nNote: %s\n", note)
+	}
+	return sb.String()
+}
+
+// setDifference returns the sorted members of a that are not in b
+func setDifference(a, b map[string]bool) []string {
+	var result []string
+	for v := range a {
+		if !b[v] {
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// sortedKeys returns the keys of a set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
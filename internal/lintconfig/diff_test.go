@@ -0,0 +1,184 @@
+package lintconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	base := `version: "2"
+linters:
+  default: none
+  enable: [errcheck, gocyclo, lll]
+  settings:
+    gocyclo:
+      min-complexity: 10
+    lll:
+      line-length: 120
+  exclusions:
+    paths: [vendor]
+`
+
+	tests := []struct {
+		name       string
+		from       string
+		to         string
+		verdict    string
+		added      []string
+		removed    []string
+		settings   map[string]string // Path → effect
+		exclusions map[string]string // Kind + value → effect
+	}{
+		{
+			name:    "identical",
+			from:    base,
+			to:      base,
+			verdict: "identical",
+		},
+		{
+			name:    "v1 config equals its migration",
+			from:    "linters:\n  disable-all: true\n  enable: [errcheck]\nissues:\n  exclude-use-default: false\n",
+			to:      "version: \"2\"\nlinters:\n  default: none\n  enable: [errcheck]\n",
+			verdict: "identical",
+		},
+		{
+			name: "threshold raised",
+			from: base,
+			to: `version: "2"
+linters:
+  default: none
+  enable: [errcheck, gocyclo, lll]
+  settings:
+    gocyclo:
+      min-complexity: 15
+    lll:
+      line-length: 120
+  exclusions:
+    paths: [vendor]
+`,
+			verdict:  EffectLooser,
+			settings: map[string]string{"linters.settings.gocyclo.min-complexity": EffectLooser},
+		},
+		{
+			name: "linter added and exclusion removed",
+			from: base,
+			to: `version: "2"
+linters:
+  default: none
+  enable: [errcheck, gocyclo, lll, gosec]
+  settings:
+    gocyclo:
+      min-complexity: 10
+    lll:
+      line-length: 120
+`,
+			verdict:    EffectStricter,
+			added:      []string{"gosec"},
+			exclusions: map[string]string{"path vendor": EffectStricter},
+		},
+		{
+			name: "linter removed, its settings have no effect of their own",
+			from: base,
+			to: `version: "2"
+linters:
+  default: none
+  enable: [errcheck, gocyclo]
+  settings:
+    gocyclo:
+      min-complexity: 10
+  exclusions:
+    paths: [vendor]
+`,
+			verdict:  EffectLooser,
+			removed:  []string{"lll"},
+			settings: map[string]string{"linters.settings.lll.line-length": EffectNeutral},
+		},
+		{
+			name: "mixed",
+			from: base,
+			to: `version: "2"
+linters:
+  default: none
+  enable: [errcheck, gocyclo, lll]
+  settings:
+    gocyclo:
+      min-complexity: 5
+    lll:
+      line-length: 120
+  exclusions:
+    paths: [vendor]
+    rules:
+      - path: _test\.go
+        linters: [errcheck]
+`,
+			verdict:    EffectMixed,
+			settings:   map[string]string{"linters.settings.gocyclo.min-complexity": EffectStricter},
+			exclusions: map[string]string{`rule linters=[errcheck] path=_test\.go`: EffectLooser},
+		},
+		{
+			name:    "default standard",
+			from:    "version: \"2\"\n",
+			to:      "version: \"2\"\nlinters:\n  disable: [errcheck]\n",
+			verdict: EffectLooser,
+			removed: []string{"errcheck"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := Compare([]byte(tt.from), []byte(tt.to), "from", "to")
+			if err != nil {
+				t.Fatalf("Compare() error = %v", err)
+			}
+			if diff.Verdict != tt.verdict {
+				t.Errorf("Verdict = %s, want %s\n%s", diff.Verdict, tt.verdict, diff.Text())
+			}
+			if !reflect.DeepEqual(diff.LintersAdded, tt.added) {
+				t.Errorf("LintersAdded = %v, want %v", diff.LintersAdded, tt.added)
+			}
+			if !reflect.DeepEqual(diff.LintersRemoved, tt.removed) {
+				t.Errorf("LintersRemoved = %v, want %v", diff.LintersRemoved, tt.removed)
+			}
+
+			settings := make(map[string]string)
+			for _, s := range diff.Settings {
+				settings[s.Path] = s.Effect
+			}
+			for path, effect := range tt.settings {
+				if settings[path] != effect {
+					t.Errorf("setting %s effect = %q, want %q", path, settings[path], effect)
+				}
+			}
+
+			exclusions := make(map[string]string)
+			for _, e := range diff.Exclusions {
+				exclusions[e.Kind+" "+e.Value] = e.Effect
+			}
+			for key, effect := range tt.exclusions {
+				if exclusions[key] != effect {
+					t.Errorf("exclusion %s effect = %q, want %q", key, exclusions[key], effect)
+				}
+			}
+		})
+	}
+}
+
+func TestThresholdEffect(t *testing.T) {
+	tests := []struct {
+		key      string
+		from, to string
+		want     string
+	}{
+		{"funlen.lines", "60", "80", EffectLooser},
+		{"funlen.lines", "60", "-1", EffectLooser},
+		{"maintidx.under", "20", "30", EffectStricter},
+		{"issues.max-same-issues", "3", "0", EffectStricter},
+		{"issues.max-same-issues", "0", "50", EffectLooser},
+	}
+
+	for _, tt := range tests {
+		if got := thresholdEffect(tt.key, tt.from, tt.to, thresholds[tt.key]); got != tt.want {
+			t.Errorf("thresholdEffect(%s, %s, %s) = %s, want %s", tt.key, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
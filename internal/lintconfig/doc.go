@@ -0,0 +1,2 @@
+// Package lintconfig reads and transforms golangci-lint configuration files.
+package lintconfig
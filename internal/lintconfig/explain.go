@@ -0,0 +1,475 @@
+package lintconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Explanation describes in plain language what a config enforces. The
+// config is read in the v2 layout, so a v1 config is explained as its
+// migration.
+type Explanation struct {
+	Source     string          `json:"source"`
+	Default    string          `json:"default"` // Linter set the config starts from: standard, all, none, or fast
+	Linters    []LinterRule    `json:"linters"`
+	Formatters []LinterRule    `json:"formatters,omitempty"`
+	Settings   []SettingRule   `json:"settings,omitempty"`
+	Exclusions []ExclusionRule `json:"exclusions,omitempty"`
+	Notes      []string        `json:"notes,omitempty"`
+}
+
+// LinterRule is an enabled linter or formatter
+type LinterRule struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SettingRule is a threshold or option of an enabled linter, or a general
+// option of the run
+type SettingRule struct {
+	Linter  string `json:"linter,omitempty"` // Empty for run and issues options
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Default bool   `json:"default,omitempty"` // Not set in the config; golangci-lint's default applies
+	Text    string `json:"text,omitempty"`    // Plain-language meaning; empty if unknown
+}
+
+// ExclusionRule is an exclusion and the issues it hides
+type ExclusionRule struct {
+	Section string `json:"section"` // linters or formatters
+	Kind    string `json:"kind"`    // rule, path, path-except, preset, or generated
+	Value   string `json:"value"`
+	Scope   string `json:"scope"`
+}
+
+// settingTexts describes numeric and text settings; %s is the value. Keys
+// below linters.settings omit that prefix.
+var settingTexts = map[string]string{
+	"cyclop.max-complexity":         "functions with a cyclomatic complexity over %s are flagged",
+	"cyclop.package-average":        "packages whose average function complexity is over %s are flagged",
+	"dogsled.max-blank-identifiers": "assignments with more than %s blank identifiers are flagged",
+	"dupl.threshold":                "duplicated blocks of %s tokens or more are flagged",
+	"funlen.lines":                  "functions over %s lines are flagged",
+	"funlen.statements":             "functions over %s statements are flagged",
+	"gocognit.min-complexity":       "functions with a cognitive complexity over %s are flagged",
+	"goconst.min-len":               "only strings of %s characters or more are considered",
+	"goconst.min-occurrences":       "strings repeated %s times or more are flagged",
+	"gocyclo.min-complexity":        "functions with a cyclomatic complexity over %s are flagged",
+	"gosec.confidence":              "issues of %s confidence or higher are reported",
+	"gosec.severity":                "issues of %s severity or higher are reported",
+	"interfacebloat.max":            "interfaces with more than %s methods are flagged",
+	"lll.line-length":               "lines longer than %s characters are flagged",
+	"maintidx.under":                "functions with a maintainability index under %s are flagged",
+	"misspell.locale":               "spelling follows %s English",
+	"nakedret.max-func-lines":       "naked returns are flagged in functions longer than %s lines",
+	"nestif.min-complexity":         "if statements with a nesting complexity of %s or more are flagged",
+	"revive.confidence":             "issues with a confidence of %s or higher are reported",
+	"issues.max-issues-per-linter":  "at most %s issues are reported per linter",
+	"issues.max-same-issues":        "at most %s identical issues are reported",
+	"run.timeout":                   "the analysis is stopped after %s",
+}
+
+// unlimitedTexts describe the values that turn a limit off
+var unlimitedTexts = map[string]map[string]string{
+	"funlen.lines":                 {"-1": "the number of lines in a function is not limited"},
+	"funlen.statements":            {"-1": "the number of statements in a function is not limited"},
+	"issues.max-issues-per-linter": {"0": "all issues of each linter are reported"},
+	"issues.max-same-issues":       {"0": "all identical issues are reported"},
+}
+
+// settingDefaults are golangci-lint's defaults for described settings,
+// shown for enabled linters that do not set them
+var settingDefaults = map[string]string{
+	"cyclop.max-complexity":         "10",
+	"dogsled.max-blank-identifiers": "2",
+	"dupl.threshold":                "150",
+	"funlen.lines":                  "60",
+	"funlen.statements":             "40",
+	"gocognit.min-complexity":       "30",
+	"goconst.min-len":               "3",
+	"goconst.min-occurrences":       "3",
+	"gocyclo.min-complexity":        "30",
+	"interfacebloat.max":            "10",
+	"lll.line-length":               "120",
+	"maintidx.under":                "20",
+	"nakedret.max-func-lines":       "30",
+	"nestif.min-complexity":         "5",
+	"issues.max-issues-per-linter":  "50",
+	"issues.max-same-issues":        "3",
+}
+
+// toggleTexts describe boolean settings when true and when false. An empty
+// text means the value is not worth mentioning.
+var toggleTexts = map[string][2]string{
+	"errcheck.check-blank":           {"errors assigned to _ are flagged", "errors assigned to _ are allowed"},
+	"errcheck.check-type-assertions": {"type assertions without the ok check are flagged", "type assertions without the ok check are allowed"},
+	"godot.capital":                  {"comments must start with a capital letter", ""},
+	"govet.disable-all":              {"only the analyzers listed in enable run", ""},
+	"govet.enable-all":               {"all vet analyzers run", ""},
+	"nolintlint.allow-unused":        {"unused //nolint directives are allowed", "unused //nolint directives are flagged"},
+	"nolintlint.require-explanation": {"//nolint directives must say why", "//nolint directives need no explanation"},
+	"nolintlint.require-specific":    {"//nolint directives must name the linters they silence", "//nolint directives may silence every linter"},
+	"revive.enable-all-rules":        {"all revive rules run", ""},
+	"issues.new":                     {"only issues in new code are reported", ""},
+	"issues.whole-files":             {"issues anywhere in a changed file count as new", ""},
+	"run.tests":                      {"test files are analyzed", "test files are not analyzed"},
+}
+
+// listTexts describe lists of checks; %s is the list
+var listTexts = map[string]string{
+	"gocritic.enabled-checks":    "these checks also run: %s",
+	"gocritic.disabled-checks":   "these checks do not run: %s",
+	"gocritic.enabled-tags":      "checks tagged %s run",
+	"gocritic.disabled-tags":     "checks tagged %s do not run",
+	"gosec.includes":             "only these rules run: %s",
+	"gosec.excludes":             "these rules are not reported: %s",
+	"govet.enable":               "these analyzers also run: %s",
+	"govet.disable":              "these analyzers do not run: %s",
+	"errcheck.exclude-functions": "errors from these functions may be ignored: %s",
+}
+
+// presetScopes describe the exclusion presets
+var presetScopes = map[string]string{
+	"comments":               "Issues about missing or badly formatted comments, such as on exported identifiers, are not reported",
+	"common-false-positives": "Common false positives, such as gosec's warnings about file paths from variables, are not reported",
+	"legacy":                 "Issues that golangci-lint v1 excluded by default are not reported",
+	"std-error-handling":     "Unchecked errors from calls that rarely fail, such as Close, Flush and printing to stdout, are not reported",
+}
+
+// generatedScopes describe how generated files are treated
+var generatedScopes = map[string]string{
+	"lax":     "Files that look generated, such as those with a \"generated\" or \"DO NOT EDIT\" comment, are not checked",
+	"strict":  "Files with the standard \"// Code generated ... DO NOT EDIT.\" comment are not checked",
+	"disable": "Generated files are checked like any other file",
+}
+
+// Explain describes what a config labelled source enforces. Configs that
+// extend others must be resolved first.
+func Explain(data []byte, source string) (*Explanation, error) {
+	root, err := normalize(data, source)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Explanation{Source: source, Default: linterDefault(root)}
+	def := e.Default
+	if def == "fast" {
+		e.Notes = append(e.Notes, "default: fast depends on the golangci-lint version; only the explicitly enabled linters are listed")
+		def = "none"
+	}
+
+	enabled := enabledLinters(root, def)
+	for _, name := range sortedKeys(enabled) {
+		e.Linters = append(e.Linters, LinterRule{Name: name, Description: describeLinter(name)})
+	}
+	formatters := toSet(seqValues(getPath(root, "formatters.enable"))...)
+	for _, name := range sortedKeys(formatters) {
+		e.Formatters = append(e.Formatters, LinterRule{Name: name, Description: describeLinter(name)})
+		enabled[name] = true
+	}
+
+	settings := make(map[string]string)
+	flatten(root, "", settings)
+	e.explainSettings(settings, enabled)
+
+	for _, section := range []string{"linters", "formatters"} {
+		if section == "formatters" && len(formatters) == 0 {
+			continue
+		}
+		e.explainExclusions(section, getPath(root, section+".exclusions"))
+	}
+	return e, nil
+}
+
+// describeLinter returns what a linter checks
+func describeLinter(name string) string {
+	if description, ok := linterDescriptions[name]; ok {
+		return description
+	}
+	return "No description available; see the golangci-lint documentation"
+}
+
+// explainSettings describes the settings of enabled linters and the
+// general options, adding golangci-lint's defaults for described
+// thresholds that are not set
+func (e *Explanation) explainSettings(settings map[string]string, enabled map[string]bool) {
+	ignored := make(map[string]bool)
+	var reviveOn, reviveOff []string
+
+	for _, path := range sortedKeys(keySet(settings)) {
+		value := settings[path]
+		linter := settingsLinter(path)
+		key := path
+		if linter != "" {
+			if !enabled[linter] {
+				ignored[linter] = true
+				continue
+			}
+			key = strings.SplitN(path, ".", 3)[2]
+		}
+
+		// Revive rules are listed together
+		if rule, ok := strings.CutPrefix(key, "revive.rules["); ok && strings.HasSuffix(rule, "]") {
+			rule = strings.TrimSuffix(rule, "]")
+			if value == "disabled" {
+				reviveOff = append(reviveOff, rule)
+			} else {
+				reviveOn = append(reviveOn, rule)
+			}
+			continue
+		}
+
+		e.Settings = append(e.Settings, SettingRule{
+			Linter: linter,
+			Key:    strings.TrimPrefix(key, linter+"."),
+			Value:  value,
+			Text:   settingText(key, value),
+		})
+	}
+
+	if len(reviveOn) > 0 {
+		e.Settings = append(e.Settings, SettingRule{Linter: "revive", Key: "rules", Value: strings.Join(reviveOn, ", "),
+			Text: "these rules run: " + strings.Join(reviveOn, ", ")})
+	}
+	if len(reviveOff) > 0 {
+		e.Settings = append(e.Settings, SettingRule{Linter: "revive", Key: "rules", Value: strings.Join(reviveOff, ", "),
+			Text: "these rules are turned off: " + strings.Join(reviveOff, ", ")})
+	}
+
+	for _, key := range sortedKeys(keySet(settingDefaults)) {
+		linter, _, _ := strings.Cut(key, ".")
+		path := key
+		if linter != "issues" {
+			if !enabled[linter] {
+				continue
+			}
+			path = "linters.settings." + key
+		} else {
+			linter = ""
+		}
+		if _, set := settings[path]; set {
+			continue
+		}
+		value := settingDefaults[key]
+		e.Settings = append(e.Settings, SettingRule{
+			Linter:  linter,
+			Key:     strings.TrimPrefix(key, linter+"."),
+			Value:   value,
+			Default: true,
+			Text:    settingText(key, value),
+		})
+	}
+
+	sort.SliceStable(e.Settings, func(i, j int) bool {
+		return e.Settings[i].Linter < e.Settings[j].Linter
+	})
+
+	if len(ignored) > 0 {
+		e.Notes = append(e.Notes, fmt.Sprintf("Settings for linters that are not enabled have no effect: %s", strings.Join(sortedKeys(ignored), ", ")))
+	}
+}
+
+// settingText describes a setting in plain language, or returns "" if its
+// meaning is not known
+func settingText(key, value string) string {
+	if text, ok := unlimitedTexts[key][value]; ok {
+		return text
+	}
+	if format, ok := settingTexts[key]; ok {
+		return fmt.Sprintf(format, value)
+	}
+	if texts, ok := toggleTexts[key]; ok {
+		if value == "true" {
+			return texts[0]
+		}
+		return texts[1]
+	}
+	if format, ok := listTexts[key]; ok {
+		return fmt.Sprintf(format, value)
+	}
+	if key == "staticcheck.checks" {
+		return staticcheckText(value)
+	}
+	return ""
+}
+
+// staticcheckText describes a staticcheck checks list such as
+// "all, -ST1000, -ST1003"
+func staticcheckText(value string) string {
+	var include, exclude []string
+	for _, check := range splitList(value) {
+		if name, ok := strings.CutPrefix(check, "-"); ok {
+			exclude = append(exclude, name)
+		} else {
+			include = append(include, check)
+		}
+	}
+	text := "checks " + strings.Join(include, ", ") + " run"
+	if len(include) == 1 && include[0] == "all" {
+		text = "all checks run"
+	}
+	if len(exclude) > 0 {
+		text += ", except " + strings.Join(exclude, ", ")
+	}
+	return text
+}
+
+// explainExclusions describes an exclusions section
+func (e *Explanation) explainExclusions(section string, exclusions *yaml.Node) {
+	subject := "Issues"
+	if section == "formatters" {
+		subject = "Formatting issues"
+	}
+
+	if rules := get(exclusions, "rules"); rules != nil && rules.Kind == yaml.SequenceNode {
+		for _, item := range rules.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			e.Exclusions = append(e.Exclusions, ExclusionRule{
+				Section: section,
+				Kind:    "rule",
+				Value:   ruleKey(item),
+				Scope:   ruleScope(item),
+			})
+		}
+	}
+	for _, path := range seqValues(get(exclusions, "paths")) {
+		e.Exclusions = append(e.Exclusions, ExclusionRule{Section: section, Kind: "path", Value: path,
+			Scope: fmt.Sprintf("%s in files matching `%s` are not reported", subject, path)})
+	}
+	for _, path := range seqValues(get(exclusions, "paths-except")) {
+		e.Exclusions = append(e.Exclusions, ExclusionRule{Section: section, Kind: "path-except", Value: path,
+			Scope: fmt.Sprintf("Files matching `%s` are checked even if an excluded path matches them", path)})
+	}
+	for _, preset := range seqValues(get(exclusions, "presets")) {
+		scope, ok := presetScopes[preset]
+		if !ok {
+			scope = "Issues the " + preset + " preset matches are not reported"
+		}
+		e.Exclusions = append(e.Exclusions, ExclusionRule{Section: section, Kind: "preset", Value: preset, Scope: scope})
+	}
+
+	mode := generatedMode(exclusions)
+	scope, ok := generatedScopes[mode]
+	if !ok {
+		scope = "Generated files are handled in " + mode + " mode"
+	}
+	e.Exclusions = append(e.Exclusions, ExclusionRule{Section: section, Kind: "generated", Value: mode, Scope: scope})
+}
+
+// ruleScope describes the issues an exclusion rule hides
+func ruleScope(item *yaml.Node) string {
+	subject := "All issues"
+	if linters := seqValues(get(item, "linters")); len(linters) > 0 {
+		subject = "Issues from " + strings.Join(linters, ", ")
+	}
+
+	var where []string
+	if path := get(item, "path"); path != nil {
+		where = append(where, fmt.Sprintf("in files matching `%s`", path.Value))
+	}
+	if except := get(item, "path-except"); except != nil {
+		where = append(where, fmt.Sprintf("in files not matching `%s`", except.Value))
+	}
+	if text := get(item, "text"); text != nil {
+		where = append(where, fmt.Sprintf("with a message matching `%s`", text.Value))
+	}
+	if source := get(item, "source"); source != nil {
+		where = append(where, fmt.Sprintf("on lines matching `%s`", source.Value))
+	}
+	if len(where) == 0 {
+		return subject + " are not reported"
+	}
+	return subject + " " + strings.Join(where, " ") + " are not reported"
+}
+
+// keySet returns the keys of a map as a set
+func keySet[V any](m map[string]V) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for k := range m {
+		set[k] = true
+	}
+	return set
+}
+
+// Summary describes the config in one line: the number of linters and its
+// main size and complexity limits
+func (e *Explanation) Summary() string {
+	parts := []string{plural(len(e.Linters), "linter")}
+	if len(e.Formatters) > 0 {
+		parts[0] += " and " + plural(len(e.Formatters), "formatter")
+	}
+	for _, key := range []string{"gocyclo.min-complexity", "cyclop.max-complexity", "gocognit.min-complexity", "funlen.lines", "lll.line-length"} {
+		linter, setting, _ := strings.Cut(key, ".")
+		for _, s := range e.Settings {
+			if s.Linter == linter && s.Key == setting && !s.Default && s.Text != "" {
+				parts = append(parts, s.Text)
+			}
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// plural writes a count with a noun, like "1 linter" or "3 linters"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// Markdown formats the explanation for reading
+func (e *Explanation) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# What %s enforces\n\n", e.Source)
+	fmt.Fprintf(&sb, "Starts from the `%s` linter set.\n", e.Default)
+
+	fmt.Fprintf(&sb, "\n## Linters (%d)\n\n", len(e.Linters))
+	for _, l := range e.Linters {
+		fmt.Fprintf(&sb, "- **%s**: %s\n", l.Name, l.Description)
+	}
+	if len(e.Formatters) > 0 {
+		fmt.Fprintf(&sb, "\n## Formatters (%d)\n\n", len(e.Formatters))
+		for _, f := range e.Formatters {
+			fmt.Fprintf(&sb, "- **%s**: %s\n", f.Name, f.Description)
+		}
+	}
+
+	if len(e.Settings) > 0 {
+		sb.WriteString("\n## Thresholds and options\n\n")
+		for _, s := range e.Settings {
+			subject := s.Linter
+			if subject == "" {
+				subject = "general"
+			}
+			if s.Text != "" {
+				fmt.Fprintf(&sb, "- **%s**: %s", subject, s.Text)
+			} else {
+				fmt.Fprintf(&sb, "- **%s**: `%s` is %s", subject, s.Key, s.Value)
+			}
+			if s.Default {
+				sb.WriteString(" (golangci-lint default)")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(e.Exclusions) > 0 {
+		sb.WriteString("\n## Exclusions\n\n")
+		for _, x := range e.Exclusions {
+			fmt.Fprintf(&sb, "- %s\n", x.Scope)
+		}
+	}
+
+	if len(e.Notes) > 0 {
+		sb.WriteString("\n## Notes\n\n")
+		for _, note := range e.Notes {
+			fmt.Fprintf(&sb, "- %s\n", note)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package lintconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	config := `version: "2"
+linters:
+  default: none
+  enable: [errcheck, funlen, gocyclo]
+  settings:
+    gocyclo:
+      min-complexity: 10
+    funlen:
+      lines: -1
+    lll:
+      line-length: 100
+  exclusions:
+    generated: strict
+    paths: [vendor]
+    rules:
+      - path: _test\.go
+        linters: [errcheck]
+`
+	e, err := Explain([]byte(config), "template:team")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+
+	var linters []string
+	for _, l := range e.Linters {
+		linters = append(linters, l.Name)
+		if l.Description == "" {
+			t.Errorf("linter %s has no description", l.Name)
+		}
+	}
+	if got := strings.Join(linters, ","); got != "errcheck,funlen,gocyclo" {
+		t.Errorf("Linters = %s, want errcheck,funlen,gocyclo", got)
+	}
+
+	settings := make(map[string]SettingRule)
+	for _, s := range e.Settings {
+		settings[s.Linter+"."+s.Key] = s
+	}
+	tests := []struct {
+		key      string
+		text     string
+		defaults bool
+	}{
+		{"gocyclo.min-complexity", "functions with a cyclomatic complexity over 10 are flagged", false},
+		{"funlen.lines", "the number of lines in a function is not limited", false},
+		{"funlen.statements", "functions over 40 statements are flagged", true},
+		{".issues.max-same-issues", "at most 3 identical issues are reported", true},
+	}
+	for _, tt := range tests {
+		s, ok := settings[tt.key]
+		if !ok {
+			t.Errorf("setting %s missing", tt.key)
+			continue
+		}
+		if s.Text != tt.text || s.Default != tt.defaults {
+			t.Errorf("setting %s = %q (default %v), want %q (default %v)", tt.key, s.Text, s.Default, tt.text, tt.defaults)
+		}
+	}
+	if _, ok := settings["lll.line-length"]; ok {
+		t.Error("setting of a disabled linter is listed")
+	}
+
+	scopes := make(map[string]string)
+	for _, x := range e.Exclusions {
+		scopes[x.Kind] = x.Scope
+	}
+	if want := "Issues from errcheck in files matching `_test\\.go` are not reported"; scopes["rule"] != want {
+		t.Errorf("rule scope = %q, want %q", scopes["rule"], want)
+	}
+	if want := generatedScopes["strict"]; scopes["generated"] != want {
+		t.Errorf("generated scope = %q, want %q", scopes["generated"], want)
+	}
+
+	if want := "3 linters; functions with a cyclomatic complexity over 10 are flagged; the number of lines in a function is not limited"; e.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", e.Summary(), want)
+	}
+	if md := e.Markdown(); !strings.Contains(md, "## Linters (3)") || !strings.Contains(md, "(golangci-lint default)") {
+		t.Errorf("Markdown() is missing sections:\n%s", md)
+	}
+}
@@ -0,0 +1,304 @@
+package lintconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A config may start with "extends: <name>" to inherit from a template or
+// another stored config. The config is then deep-merged over its resolved
+// base:
+//
+//   - mappings are merged key by key, so settings can be patched
+//   - scalars replace the base value
+//   - sequences are appended to, skipping values already present, so
+//     linters and exclusion rules are added
+//   - a linter listed in enable is removed from the base's disable list and
+//     vice versa, so a config can turn off a linter its base enables
+
+// maxExtendsDepth limits the length of an extends chain
+const maxExtendsDepth = 10
+
+// Loader returns the content of the config an extends value refers to, and
+// a label for it such as "template:standard"
+type Loader func(name string) (content []byte, source string, err error)
+
+// Resolution is a config with its extends chain merged into one document
+type Resolution struct {
+	Content []byte   `json:"-"`
+	Chain   []string `json:"chain"`   // Sources from the config itself to the root of the chain
+	Origins []Origin `json:"origins"` // Where each setting of the resolved config came from
+}
+
+// Origin records the source of one setting in a resolved config
+type Origin struct {
+	Path   string `json:"path"`            // Dot-separated key path, with [i] for sequence items
+	Value  string `json:"value,omitempty"` // Scalar value, if the setting is a scalar
+	Source string `json:"source"`
+}
+
+// Extends returns the name a config extends, or "" if it has no base
+func Extends(data []byte) (string, error) {
+	var doc struct {
+		Extends string `yaml:"extends"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("failed to parse config: %w", err)
+	}
+	return doc.Extends, nil
+}
+
+// layer is one config in an extends chain
+type layer struct {
+	source  string
+	data    []byte
+	version int
+}
+
+// Resolve merges a config labelled source with the chain of configs it
+// extends. A v1 base under a v2 config is migrated before merging; a v1
+// config cannot extend a v2 base.
+func Resolve(data []byte, source string, load Loader) (*Resolution, error) {
+	layers, err := loadChain(data, source, load)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &resolver{origins: make(map[*yaml.Node]string)}
+	var merged *yaml.Node
+	for i := len(layers) - 1; i >= 0; i-- {
+		l := layers[i]
+		if merged != nil && l.version == 1 && isV2(merged) {
+			return nil, fmt.Errorf("%s is a v1 config but extends the v2 config %s; migrate it first", l.source, layers[i+1].source)
+		}
+		if merged != nil && l.version == 2 && !isV2(merged) {
+			if merged, err = r.migrate(merged, layers[i+1].source); err != nil {
+				return nil, err
+			}
+		}
+
+		root, err := r.parse(l.data, l.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", l.source, err)
+		}
+		if merged == nil {
+			merged = root
+			continue
+		}
+		r.merge(merged, root)
+	}
+
+	content, err := encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{merged}})
+	if err != nil {
+		return nil, err
+	}
+
+	resolution := &Resolution{Content: content}
+	for _, l := range layers {
+		resolution.Chain = append(resolution.Chain, l.source)
+	}
+	r.collect(merged, "", &resolution.Origins)
+	return resolution, nil
+}
+
+// loadChain follows extends from the config to the root of its chain
+func loadChain(data []byte, source string, load Loader) ([]layer, error) {
+	var layers []layer
+	seen := make(map[string]bool)
+	for {
+		if seen[source] {
+			return nil, fmt.Errorf("extends cycle: %s", chainString(layers, source))
+		}
+		seen[source] = true
+
+		version, err := Version(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", source, err)
+		}
+		layers = append(layers, layer{source: source, data: data, version: version})
+
+		base, err := Extends(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", source, err)
+		}
+		if base == "" {
+			return layers, nil
+		}
+		if len(layers) > maxExtendsDepth {
+			return nil, fmt.Errorf("extends chain is longer than %d configs: %s", maxExtendsDepth, chainString(layers, base))
+		}
+		if load == nil {
+			return nil, fmt.Errorf("%s extends %s, but no base configs are available", source, base)
+		}
+
+		data, source, err = load(base)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s extended by %s: %w", base, layers[len(layers)-1].source, err)
+		}
+	}
+}
+
+// isV2 reports whether a config root declares version "2"
+func isV2(root *yaml.Node) bool {
+	v := get(root, "version")
+	return v != nil && v.Value == "2"
+}
+
+// chainString formats an extends chain for error messages
+func chainString(layers []layer, next string) string {
+	names := make([]string, 0, len(layers)+1)
+	for _, l := range layers {
+		names = append(names, l.source)
+	}
+	return strings.Join(append(names, next), " -> ")
+}
+
+// resolver merges configs while tracking which source every node came from
+type resolver struct {
+	origins map[*yaml.Node]string
+}
+
+// parse decodes a config, drops its extends key, and marks its nodes with
+// source
+func (r *resolver) parse(data []byte, source string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	root := newMap()
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config must be a YAML mapping")
+	}
+	remove(root, "extends")
+	r.mark(root, source)
+	return root, nil
+}
+
+// migrate converts a resolved v1 base to v2 so a v2 config can extend it.
+// The migration rebuilds the tree, so its settings are attributed to the
+// direct base.
+func (r *resolver) migrate(merged *yaml.Node, source string) (*yaml.Node, error) {
+	data, err := encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{merged}})
+	if err != nil {
+		return nil, err
+	}
+	migration, err := Migrate(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate %s to v2: %w", source, err)
+	}
+	return r.parse(migration.Content, source+" (migrated to v2)")
+}
+
+// mark records source as the origin of n and all nodes below it
+func (r *resolver) mark(n *yaml.Node, source string) {
+	r.origins[n] = source
+	for _, c := range n.Content {
+		r.mark(c, source)
+	}
+}
+
+// merge deep-merges the mapping src into dst
+func (r *resolver) merge(dst, src *yaml.Node) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i].Value, src.Content[i+1]
+		existing := get(dst, key)
+		switch {
+		case existing == nil:
+			set(dst, key, value)
+		case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			r.merge(existing, value)
+		case existing.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+			r.appendItems(existing, value)
+		default:
+			set(dst, key, value)
+		}
+	}
+
+	// Enabling a linter overrides the base disabling it, and vice versa
+	for _, pair := range [][2]string{{"enable", "disable"}, {"disable", "enable"}} {
+		if values := seqValues(get(src, pair[0])); len(values) > 0 {
+			removeItems(get(dst, pair[1]), values)
+		}
+	}
+
+	// golangci-lint rejects disable together with disable-all, and with
+	// nothing enabled by default a linter is off once removed from enable
+	if get(src, "disable") != nil && (isTrue(get(dst, "disable-all")) || isScalar(get(dst, "default"), "none")) {
+		remove(dst, "disable")
+	}
+}
+
+// isScalar reports whether n is a scalar holding value
+func isScalar(n *yaml.Node, value string) bool {
+	return n != nil && n.Kind == yaml.ScalarNode && n.Value == value
+}
+
+// appendItems appends the items of src to dst, skipping scalars dst
+// already contains
+func (r *resolver) appendItems(dst, src *yaml.Node) {
+	existing := make(map[string]bool)
+	for _, v := range seqValues(dst) {
+		existing[v] = true
+	}
+	for _, item := range src.Content {
+		if item.Kind == yaml.ScalarNode {
+			if existing[item.Value] {
+				continue
+			}
+			existing[item.Value] = true
+		}
+		dst.Content = append(dst.Content, item)
+	}
+}
+
+// removeItems removes scalar values from a sequence node
+func removeItems(seq *yaml.Node, values []string) {
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return
+	}
+	drop := make(map[string]bool, len(values))
+	for _, v := range values {
+		drop[v] = true
+	}
+	kept := seq.Content[:0]
+	for _, item := range seq.Content {
+		if item.Kind != yaml.ScalarNode || !drop[item.Value] {
+			kept = append(kept, item)
+		}
+	}
+	seq.Content = kept
+}
+
+// collect lists the origin of every scalar and sequence item below n
+func (r *resolver) collect(n *yaml.Node, path string, origins *[]Origin) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			r.collect(n.Content[i+1], joinPath(path, n.Content[i].Value), origins)
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if item.Kind == yaml.ScalarNode {
+				*origins = append(*origins, Origin{Path: itemPath, Value: item.Value, Source: r.origins[item]})
+				continue
+			}
+			r.collect(item, itemPath, origins)
+		}
+	default:
+		*origins = append(*origins, Origin{Path: path, Value: n.Value, Source: r.origins[n]})
+	}
+}
+
+// joinPath appends key to a dot-separated path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
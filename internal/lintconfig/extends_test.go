@@ -0,0 +1,169 @@
+package lintconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const baseConfig = `linters:
+  disable-all: true
+  enable:
+    - errcheck
+    - govet
+    - lll
+
+linters-settings:
+  gocyclo:
+    min-complexity: 10
+  lll:
+    line-length: 120
+
+issues:
+  exclude-rules:
+    - path: _test\.go
+      linters: [errcheck]
+`
+
+const teamConfig = `extends: standard
+linters:
+  enable:
+    - gocyclo
+    - govet
+  disable:
+    - lll
+linters-settings:
+  gocyclo:
+    min-complexity: 15
+issues:
+  exclude-rules:
+    - path: mocks/
+      linters: [gocyclo]
+`
+
+// testLoader serves configs from a map, labelled as templates
+func testLoader(configs map[string]string) Loader {
+	return func(name string) ([]byte, string, error) {
+		content, ok := configs[name]
+		if !ok {
+			return nil, "", fmt.Errorf("not found: %s", name)
+		}
+		return []byte(content), "template:" + name, nil
+	}
+}
+
+func TestResolve(t *testing.T) {
+	resolution, err := Resolve([]byte(teamConfig), "config:team", testLoader(map[string]string{"standard": baseConfig}))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	var got struct {
+		Extends string `yaml:"extends"`
+		Linters struct {
+			DisableAll bool     `yaml:"disable-all"`
+			Enable     []string `yaml:"enable"`
+			Disable    []string `yaml:"disable"`
+		} `yaml:"linters"`
+		Settings map[string]map[string]int `yaml:"linters-settings"`
+		Issues   struct {
+			ExcludeRules []map[string]interface{} `yaml:"exclude-rules"`
+		} `yaml:"issues"`
+	}
+	if err := yaml.Unmarshal(resolution.Content, &got); err != nil {
+		t.Fatalf("Resolved config is not valid YAML: %v", err)
+	}
+
+	if got.Extends != "" {
+		t.Errorf("extends = %q, want it removed", got.Extends)
+	}
+	if want := []string{"errcheck", "govet", "gocyclo"}; !reflect.DeepEqual(got.Linters.Enable, want) {
+		t.Errorf("linters.enable = %v, want %v", got.Linters.Enable, want)
+	}
+	if !got.Linters.DisableAll || len(got.Linters.Disable) != 0 {
+		t.Errorf("linters = %+v, want disable-all kept and no disable list", got.Linters)
+	}
+	if got.Settings["gocyclo"]["min-complexity"] != 15 || got.Settings["lll"]["line-length"] != 120 {
+		t.Errorf("linters-settings = %v, want gocyclo patched and lll kept", got.Settings)
+	}
+	if len(got.Issues.ExcludeRules) != 2 {
+		t.Errorf("exclude-rules = %v, want the base rule and the appended rule", got.Issues.ExcludeRules)
+	}
+
+	if want := []string{"config:team", "template:standard"}; !reflect.DeepEqual(resolution.Chain, want) {
+		t.Errorf("Chain = %v, want %v", resolution.Chain, want)
+	}
+
+	sources := make(map[string]string)
+	for _, origin := range resolution.Origins {
+		sources[origin.Path+"="+origin.Value] = origin.Source
+	}
+	for setting, want := range map[string]string{
+		"linters-settings.gocyclo.min-complexity=15": "config:team",
+		"linters-settings.lll.line-length=120":       "template:standard",
+		"linters.enable[2]=gocyclo":                  "config:team",
+		"issues.exclude-rules[1].path=mocks/":        "config:team",
+	} {
+		if sources[setting] != want {
+			t.Errorf("origin of %s = %q, want %q", setting, sources[setting], want)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		configs map[string]string
+		want    string
+	}{
+		{
+			name:    "cycle",
+			config:  "extends: a\n",
+			configs: map[string]string{"a": "extends: b\n", "b": "extends: a\n"},
+			want:    "extends cycle",
+		},
+		{
+			name:   "missing base",
+			config: "extends: nope\n",
+			want:   "not found",
+		},
+		{
+			name:    "v1 config extending v2",
+			config:  "extends: v2\nlinters:\n  enable: [gocyclo]\n",
+			configs: map[string]string{"v2": "version: \"2\"\n"},
+			want:    "migrate it first",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Resolve([]byte(tt.config), "content", testLoader(tt.configs))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Resolve() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveMigratesV1Base(t *testing.T) {
+	config := "version: \"2\"\nextends: standard\nlinters:\n  enable: [gocritic]\n"
+	resolution, err := Resolve([]byte(config), "content", testLoader(map[string]string{"standard": baseConfig}))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	var got v2Layout
+	if err := yaml.Unmarshal(resolution.Content, &got); err != nil {
+		t.Fatalf("Resolved config is not valid YAML: %v", err)
+	}
+	if got.Version != "2" || got.Linters.Default != "none" {
+		t.Errorf("resolved config = %+v, want a v2 config with default: none", got)
+	}
+	if want := []string{"errcheck", "govet", "lll", "gocritic"}; !reflect.DeepEqual(got.Linters.Enable, want) {
+		t.Errorf("linters.enable = %v, want %v", got.Linters.Enable, want)
+	}
+}
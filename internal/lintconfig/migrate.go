@@ -0,0 +1,573 @@
+package lintconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration describes the result of converting a golangci-lint config
+type Migration struct {
+	Content  []byte   `json:"-"`
+	Changes  []string `json:"changes"`            // Edits made to the config
+	Warnings []string `json:"warnings,omitempty"` // Settings dropped because v2 has no equivalent
+}
+
+// formatters lists linters that moved to the formatters section in v2
+var formatters = map[string]bool{
+	"gofmt":     true,
+	"gofumpt":   true,
+	"goimports": true,
+	"gci":       true,
+	"golines":   true,
+}
+
+// replacedLinters maps linters removed in v2 to their replacement
+var replacedLinters = map[string]string{
+	"gosimple":         "staticcheck",
+	"stylecheck":       "staticcheck",
+	"deadcode":         "unused",
+	"varcheck":         "unused",
+	"structcheck":      "unused",
+	"exportloopref":    "copyloopvar",
+	"golint":           "revive",
+	"exhaustivestruct": "exhaustruct",
+	"ifshort":          "",
+	"interfacer":       "",
+	"maligned":         "",
+	"nosnakecase":      "",
+	"scopelint":        "",
+	"typecheck":        "", // Always runs in v2
+}
+
+// defaultExcludeDirs are the directories v1 skipped by default
+var defaultExcludeDirs = []string{"third_party$", "builtin$", "examples$"}
+
+// defaultPresets are the exclusion presets matching v1's default excludes
+var defaultPresets = []string{"comments", "common-false-positives", "legacy", "std-error-handling"}
+
+// outputFormats maps v1 output format names to v2 format keys
+var outputFormats = map[string]string{
+	"colored-line-number": "text",
+	"line-number":         "text",
+	"json":                "json",
+	"tab":                 "tab",
+	"colored-tab":         "tab",
+	"checkstyle":          "checkstyle",
+	"code-climate":        "code-climate",
+	"html":                "html",
+	"junit-xml":           "junit-xml",
+	"junit-xml-extended":  "junit-xml",
+	"teamcity":            "teamcity",
+	"sarif":               "sarif",
+}
+
+// Version returns the config format version: 2 if the config declares
+// version "2", otherwise 1
+func Version(data []byte) (int, error) {
+	var doc struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return 0, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc.Version == "2" {
+		return 2, nil
+	}
+	return 1, nil
+}
+
+// Migrate converts a golangci-lint v1 config to the v2 layout. Configs
+// that are already v2 are returned unchanged.
+func Migrate(data []byte) (*Migration, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{newMap()}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config must be a YAML mapping")
+	}
+
+	if v := get(root, "version"); v != nil && v.Value == "2" {
+		return &Migration{
+			Content: data,
+			Changes: []string{"config is already in the v2 format"},
+		}, nil
+	}
+
+	m := &migrator{root: root}
+	m.migrateLinters()
+	m.migrateSettings()
+	m.migrateIssues()
+	m.migrateRun()
+	m.migrateOutput()
+	m.migrateSeverity()
+	m.placeFormatters()
+
+	setFirst(root, "version", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "2", Style: yaml.DoubleQuotedStyle})
+	m.change("set version: \"2\"")
+
+	content, err := encode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migration{
+		Content:  content,
+		Changes:  m.changes,
+		Warnings: m.warnings,
+	}, nil
+}
+
+// migrator holds the state of a single migration
+type migrator struct {
+	root       *yaml.Node
+	formatters *yaml.Node // Created on demand
+	changes    []string
+	warnings   []string
+}
+
+func (m *migrator) change(format string, args ...interface{}) {
+	m.changes = append(m.changes, fmt.Sprintf(format, args...))
+}
+
+func (m *migrator) warn(format string, args ...interface{}) {
+	m.warnings = append(m.warnings, fmt.Sprintf(format, args...))
+}
+
+// formattersSection returns the formatters mapping, creating it if needed
+func (m *migrator) formattersSection() *yaml.Node {
+	if m.formatters == nil {
+		m.formatters = child(m.root, "formatters")
+	}
+	return m.formatters
+}
+
+// exclusions returns linters.exclusions, creating it if needed
+func (m *migrator) exclusions() *yaml.Node {
+	return child(child(m.root, "linters"), "exclusions")
+}
+
+// migrateLinters converts enable-all/disable-all to default and maps
+// removed linters to their replacements
+func (m *migrator) migrateLinters() {
+	linters := child(m.root, "linters")
+
+	def := "standard"
+	if isTrue(remove(linters, "enable-all")) {
+		def = "all"
+	}
+	if isTrue(remove(linters, "disable-all")) {
+		def = "none"
+	}
+	setFirst(linters, "default", newScalar(def))
+	m.change("linters: set default: %s", def)
+
+	if remove(linters, "fast") != nil {
+		m.warn("linters.fast was removed; use --fast-only on the command line")
+	}
+	if presets := remove(linters, "presets"); presets != nil {
+		m.warn("linters.presets was removed; enable the linters explicitly (presets: %s)", strings.Join(seqValues(presets), ", "))
+	}
+
+	if enable := get(linters, "enable"); enable != nil {
+		var kept, moved []string
+		for _, name := range seqValues(enable) {
+			switch {
+			case formatters[name]:
+				moved = append(moved, name)
+			default:
+				if mapped, ok := m.replaceLinter(name); ok {
+					kept = append(kept, mapped)
+				}
+			}
+		}
+		set(linters, "enable", newSeq(unique(kept)...))
+		if len(moved) > 0 {
+			appendUnique(seqChild(m.formattersSection(), "enable"), moved...)
+			m.change("moved formatters to formatters.enable: %s", strings.Join(moved, ", "))
+		}
+	}
+
+	if disable := get(linters, "disable"); disable != nil {
+		var kept []string
+		for _, name := range seqValues(disable) {
+			if formatters[name] {
+				continue // Formatters are not enabled by default in v2
+			}
+			if _, removed := replacedLinters[name]; removed {
+				continue
+			}
+			kept = append(kept, name)
+		}
+		if len(kept) == 0 {
+			remove(linters, "disable")
+		} else {
+			set(linters, "disable", newSeq(kept...))
+		}
+	}
+}
+
+// replaceLinter maps a v1 linter name to its v2 name. It returns false if
+// the linter was removed without replacement.
+func (m *migrator) replaceLinter(name string) (string, bool) {
+	replacement, removed := replacedLinters[name]
+	if !removed {
+		return name, true
+	}
+	if replacement == "" {
+		m.change("removed linter %s (no longer available in v2)", name)
+		return "", false
+	}
+	m.change("replaced linter %s with %s", name, replacement)
+	return replacement, true
+}
+
+// migrateSettings moves linters-settings under linters.settings, and
+// formatter settings under formatters.settings
+func (m *migrator) migrateSettings() {
+	settings := remove(m.root, "linters-settings")
+	if settings == nil || settings.Kind != yaml.MappingNode {
+		return
+	}
+	m.change("moved linters-settings to linters.settings")
+
+	linterSettings := newMap()
+	for i := 0; i+1 < len(settings.Content); i += 2 {
+		name, value := settings.Content[i].Value, settings.Content[i+1]
+
+		switch {
+		case formatters[name]:
+			set(child(m.formattersSection(), "settings"), name, value)
+			m.change("moved %s settings to formatters.settings", name)
+		case name == "gosimple" || name == "stylecheck":
+			m.mergeStaticcheckSettings(linterSettings, name, value)
+		case name == "govet":
+			m.migrateGovet(value)
+			set(linterSettings, name, value)
+		case name == "staticcheck":
+			if remove(value, "go") != nil {
+				m.warn("staticcheck.go was removed; the Go version comes from run.go")
+			}
+			set(linterSettings, name, mergeMaps(get(linterSettings, name), value))
+		default:
+			if replacement, removed := replacedLinters[name]; removed {
+				m.warn("dropped settings for removed linter %s", name)
+				if replacement != "" && get(settings, replacement) == nil {
+					m.warn("review settings for %s, which replaces %s", replacement, name)
+				}
+				continue
+			}
+			set(linterSettings, name, value)
+		}
+	}
+
+	if len(linterSettings.Content) > 0 {
+		set(child(m.root, "linters"), "settings", linterSettings)
+	}
+}
+
+// mergeStaticcheckSettings folds gosimple and stylecheck settings into
+// staticcheck, which absorbed both linters in v2
+func (m *migrator) mergeStaticcheckSettings(settings *yaml.Node, name string, value *yaml.Node) {
+	staticcheck := get(settings, "staticcheck")
+	if staticcheck == nil {
+		staticcheck = newMap()
+		set(settings, "staticcheck", staticcheck)
+	}
+
+	if checks := remove(value, "checks"); checks != nil {
+		appendUnique(seqChild(staticcheck, "checks"), seqValues(checks)...)
+	}
+	remove(value, "go")
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		set(staticcheck, value.Content[i].Value, value.Content[i+1])
+	}
+	m.change("merged %s settings into staticcheck", name)
+}
+
+// migrateGovet replaces govet's check-shadowing with the shadow analyzer
+func (m *migrator) migrateGovet(govet *yaml.Node) {
+	if shadow := remove(govet, "check-shadowing"); shadow != nil {
+		if isTrue(shadow) {
+			appendUnique(seqChild(govet, "enable"), "shadow")
+		}
+		m.change("replaced govet.check-shadowing with govet.enable: [shadow]")
+	}
+}
+
+// migrateIssues moves exclusion settings to linters.exclusions
+func (m *migrator) migrateIssues() {
+	issues := get(m.root, "issues")
+
+	// v1 applied the default exclusions unless explicitly disabled
+	useDefault := remove(issues, "exclude-use-default")
+	if useDefault == nil || isTrue(useDefault) {
+		appendUnique(seqChild(m.exclusions(), "presets"), defaultPresets...)
+		m.change("replaced issues.exclude-use-default with linters.exclusions.presets")
+	}
+
+	if issues == nil {
+		return
+	}
+
+	if rules := remove(issues, "exclude-rules"); rules != nil {
+		target := seqChild(m.exclusions(), "rules")
+		for _, rule := range rules.Content {
+			if m.remapRuleLinters(rule) {
+				target.Content = append(target.Content, rule)
+			}
+		}
+		m.change("moved issues.exclude-rules to linters.exclusions.rules")
+	}
+
+	if exclude := remove(issues, "exclude"); exclude != nil {
+		target := seqChild(m.exclusions(), "rules")
+		for _, text := range seqValues(exclude) {
+			rule := newMap()
+			set(rule, "text", newScalar(text))
+			target.Content = append(target.Content, rule)
+		}
+		m.change("moved issues.exclude to linters.exclusions.rules")
+	}
+
+	if generated := remove(issues, "exclude-generated"); generated != nil {
+		set(m.exclusions(), "generated", generated)
+		m.change("moved issues.exclude-generated to linters.exclusions.generated")
+	}
+
+	for _, key := range []string{"exclude-dirs", "exclude-files"} {
+		if paths := remove(issues, key); paths != nil {
+			m.addExcludedPaths(seqValues(paths)...)
+			m.change("moved issues.%s to linters.exclusions.paths", key)
+		}
+	}
+
+	if isTrue(remove(issues, "exclude-dirs-use-default")) {
+		m.addExcludedPaths(defaultExcludeDirs...)
+		m.change("replaced issues.exclude-dirs-use-default with explicit exclusion paths")
+	}
+
+	for _, key := range []string{"exclude-case-sensitive", "include"} {
+		if remove(issues, key) != nil {
+			m.warn("issues.%s was removed in v2", key)
+		}
+	}
+
+	if len(issues.Content) == 0 {
+		remove(m.root, "issues")
+	}
+}
+
+// remapRuleLinters renames removed linters in an exclusion rule. It
+// returns false if the rule only applied to linters that no longer exist.
+func (m *migrator) remapRuleLinters(rule *yaml.Node) bool {
+	linters := get(rule, "linters")
+	if linters == nil {
+		return true
+	}
+
+	var names []string
+	for _, name := range seqValues(linters) {
+		replacement, removed := replacedLinters[name]
+		switch {
+		case !removed:
+			names = append(names, name)
+		case replacement != "":
+			names = append(names, replacement)
+		}
+	}
+
+	if len(names) == 0 {
+		m.warn("dropped exclusion rule for removed linters: %s", strings.Join(seqValues(linters), ", "))
+		return false
+	}
+	set(rule, "linters", newSeq(unique(names)...))
+	return true
+}
+
+// migrateRun moves skipped paths to linters.exclusions and drops removed keys
+func (m *migrator) migrateRun() {
+	run := get(m.root, "run")
+	if run == nil {
+		return
+	}
+
+	for _, key := range []string{"skip-dirs", "skip-files"} {
+		if paths := remove(run, key); paths != nil {
+			m.addExcludedPaths(seqValues(paths)...)
+			m.change("moved run.%s to linters.exclusions.paths", key)
+		}
+	}
+	if isTrue(remove(run, "skip-dirs-use-default")) {
+		m.addExcludedPaths(defaultExcludeDirs...)
+		m.change("replaced run.skip-dirs-use-default with explicit exclusion paths")
+	}
+	if remove(run, "deadline") != nil {
+		m.warn("run.deadline was removed; use run.timeout")
+	}
+	if stats := remove(run, "show-stats"); stats != nil {
+		set(child(m.root, "output"), "show-stats", stats)
+		m.change("moved run.show-stats to output.show-stats")
+	}
+
+	if len(run.Content) == 0 {
+		remove(m.root, "run")
+	}
+}
+
+// addExcludedPaths adds path patterns to both linter and formatter exclusions
+func (m *migrator) addExcludedPaths(paths ...string) {
+	appendUnique(seqChild(m.exclusions(), "paths"), paths...)
+	if m.formatters != nil || get(m.root, "formatters") != nil {
+		appendUnique(seqChild(child(m.formattersSection(), "exclusions"), "paths"), paths...)
+	}
+}
+
+// migrateOutput converts output.format and output.formats to the v2
+// formats mapping
+func (m *migrator) migrateOutput() {
+	output := get(m.root, "output")
+	if output == nil {
+		return
+	}
+
+	formats := newMap()
+
+	addFormat := func(name, path string) {
+		key, ok := outputFormats[name]
+		if !ok {
+			m.warn("output format %s is not available in v2", name)
+			return
+		}
+		format := newMap()
+		if path == "" {
+			path = "stdout"
+		}
+		set(format, "path", newScalar(path))
+		if key == "text" || key == "tab" {
+			set(format, "colors", newBool(strings.HasPrefix(name, "colored-")))
+		}
+		set(formats, key, format)
+	}
+
+	if format := remove(output, "format"); format != nil {
+		for _, spec := range strings.Split(format.Value, ",") {
+			name, path, _ := strings.Cut(strings.TrimSpace(spec), ":")
+			addFormat(name, path)
+		}
+		m.change("converted output.format to output.formats")
+	}
+
+	if list := get(output, "formats"); list != nil && list.Kind == yaml.SequenceNode {
+		remove(output, "formats")
+		for _, item := range list.Content {
+			name := get(item, "format")
+			path := get(item, "path")
+			if name == nil {
+				continue
+			}
+			p := ""
+			if path != nil {
+				p = path.Value
+			}
+			addFormat(name.Value, p)
+		}
+		m.change("converted the output.formats list to a mapping")
+	}
+
+	for _, key := range []string{"print-issued-lines", "print-linter-name"} {
+		value := remove(output, key)
+		if value == nil {
+			continue
+		}
+		if text := get(formats, "text"); text != nil {
+			set(text, key, value)
+			m.change("moved output.%s to output.formats.text", key)
+		} else {
+			m.warn("output.%s only applies to the text format and was dropped", key)
+		}
+	}
+
+	if uniq := remove(output, "uniq-by-line"); uniq != nil {
+		set(child(m.root, "issues"), "uniq-by-line", uniq)
+		m.change("moved output.uniq-by-line to issues.uniq-by-line")
+	}
+	if remove(output, "sort-results") != nil {
+		m.change("removed output.sort-results (results are always sorted in v2)")
+	}
+
+	if len(formats.Content) > 0 {
+		setFirst(output, "formats", formats)
+	}
+	if len(output.Content) == 0 {
+		remove(m.root, "output")
+	}
+}
+
+// migrateSeverity renames severity.default-severity
+func (m *migrator) migrateSeverity() {
+	severity := get(m.root, "severity")
+	if severity == nil {
+		return
+	}
+	if def := remove(severity, "default-severity"); def != nil {
+		setFirst(severity, "default", def)
+		m.change("renamed severity.default-severity to severity.default")
+	}
+	if remove(severity, "case-sensitive") != nil {
+		m.warn("severity.case-sensitive was removed in v2")
+	}
+}
+
+// placeFormatters moves the formatters section directly after linters
+func (m *migrator) placeFormatters() {
+	section := remove(m.root, "formatters")
+	if section == nil {
+		return
+	}
+
+	for i := 0; i+1 < len(m.root.Content); i += 2 {
+		if m.root.Content[i].Value == "linters" {
+			rest := append([]*yaml.Node{newScalar("formatters"), section}, m.root.Content[i+2:]...)
+			m.root.Content = append(m.root.Content[:i+2], rest...)
+			return
+		}
+	}
+	set(m.root, "formatters", section)
+}
+
+// mergeMaps copies the keys of src into dst, creating dst if nil
+func mergeMaps(dst, src *yaml.Node) *yaml.Node {
+	if dst == nil {
+		return src
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key := src.Content[i].Value
+		if key == "checks" {
+			appendUnique(seqChild(dst, "checks"), seqValues(src.Content[i+1])...)
+			continue
+		}
+		set(dst, key, src.Content[i+1])
+	}
+	return dst
+}
+
+// unique returns values without duplicates, keeping the first occurrence
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package lintconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const v1Config = `linters:
+  disable-all: true
+  enable:
+    - gofmt
+    - goimports
+    - gosimple
+    - staticcheck
+    - deadcode
+    - typecheck
+    - exportloopref
+
+linters-settings:
+  govet:
+    check-shadowing: true
+  gofmt:
+    simplify: true
+  stylecheck:
+    checks: ["ST1001"]
+
+issues:
+  exclude-use-default: false
+  exclude-rules:
+    - path: _test\.go
+      linters:
+        - gosimple
+        - deadcode
+    - linters:
+        - maligned
+      text: struct of size
+
+run:
+  timeout: 5m
+  skip-dirs:
+    - vendor
+
+output:
+  format: colored-line-number,json:report.json
+  print-issued-lines: false
+  uniq-by-line: true
+`
+
+// v2Layout is the subset of the v2 layout checked by the tests
+type v2Layout struct {
+	Version string `yaml:"version"`
+	Linters struct {
+		Default  string                 `yaml:"default"`
+		Enable   []string               `yaml:"enable"`
+		Settings map[string]interface{} `yaml:"settings"`
+		Exclude  struct {
+			Presets []string `yaml:"presets"`
+			Paths   []string `yaml:"paths"`
+			Rules   []struct {
+				Path    string   `yaml:"path"`
+				Linters []string `yaml:"linters"`
+			} `yaml:"rules"`
+		} `yaml:"exclusions"`
+	} `yaml:"linters"`
+	Formatters struct {
+		Enable   []string               `yaml:"enable"`
+		Settings map[string]interface{} `yaml:"settings"`
+	} `yaml:"formatters"`
+	Issues map[string]interface{} `yaml:"issues"`
+	Run    map[string]interface{} `yaml:"run"`
+	Output struct {
+		Formats map[string]map[string]interface{} `yaml:"formats"`
+	} `yaml:"output"`
+	LegacySettings interface{} `yaml:"linters-settings"`
+}
+
+func TestMigrate(t *testing.T) {
+	migration, err := Migrate([]byte(v1Config))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	var got v2Layout
+	if err := yaml.Unmarshal(migration.Content, &got); err != nil {
+		t.Fatalf("Migrated config is not valid YAML: %v\n%s", err, migration.Content)
+	}
+
+	if got.Version != "2" {
+		t.Errorf("version = %q, want 2", got.Version)
+	}
+	if got.Linters.Default != "none" {
+		t.Errorf("linters.default = %q, want none", got.Linters.Default)
+	}
+	if want := []string{"staticcheck", "unused", "copyloopvar"}; !reflect.DeepEqual(got.Linters.Enable, want) {
+		t.Errorf("linters.enable = %v, want %v", got.Linters.Enable, want)
+	}
+	if want := []string{"gofmt", "goimports"}; !reflect.DeepEqual(got.Formatters.Enable, want) {
+		t.Errorf("formatters.enable = %v, want %v", got.Formatters.Enable, want)
+	}
+	if got.LegacySettings != nil {
+		t.Error("linters-settings should be removed")
+	}
+	if _, ok := got.Formatters.Settings["gofmt"]; !ok {
+		t.Error("gofmt settings should move to formatters.settings")
+	}
+	if _, ok := got.Linters.Settings["stylecheck"]; ok {
+		t.Error("stylecheck settings should merge into staticcheck")
+	}
+	if govet, _ := got.Linters.Settings["govet"].(map[string]interface{}); govet["check-shadowing"] != nil || govet["enable"] == nil {
+		t.Errorf("govet settings = %v, want enable: [shadow]", govet)
+	}
+
+	if len(got.Linters.Exclude.Presets) != 0 {
+		t.Errorf("presets = %v, want none when exclude-use-default is false", got.Linters.Exclude.Presets)
+	}
+	if want := []string{"vendor"}; !reflect.DeepEqual(got.Linters.Exclude.Paths, want) {
+		t.Errorf("exclusions.paths = %v, want %v", got.Linters.Exclude.Paths, want)
+	}
+	if len(got.Linters.Exclude.Rules) != 1 || !reflect.DeepEqual(got.Linters.Exclude.Rules[0].Linters, []string{"staticcheck", "unused"}) {
+		t.Errorf("exclusions.rules = %+v, want one rule for staticcheck and unused", got.Linters.Exclude.Rules)
+	}
+
+	if _, ok := got.Run["skip-dirs"]; ok {
+		t.Error("run.skip-dirs should be removed")
+	}
+	if got.Issues["uniq-by-line"] != true {
+		t.Errorf("issues = %v, want uniq-by-line moved from output", got.Issues)
+	}
+	if text := got.Output.Formats["text"]; text["colors"] != true || text["print-issued-lines"] != false {
+		t.Errorf("output.formats.text = %v", text)
+	}
+	if json := got.Output.Formats["json"]; json["path"] != "report.json" {
+		t.Errorf("output.formats.json = %v, want path report.json", json)
+	}
+
+	if len(migration.Warnings) == 0 {
+		t.Error("expected a warning for the dropped maligned rule")
+	}
+}
+
+func TestMigrateIdempotent(t *testing.T) {
+	first, err := Migrate([]byte(v1Config))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	second, err := Migrate(first.Content)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if string(second.Content) != string(first.Content) {
+		t.Error("migrating a v2 config should leave it unchanged")
+	}
+}
+
+func TestMigrateTemplates(t *testing.T) {
+	templates, err := filepath.Glob(filepath.Join("..", "..", "configs", "templates", "*.yaml"))
+	if err != nil || len(templates) == 0 {
+		t.Skip("templates not found")
+	}
+
+	for _, path := range templates {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read template: %v", err)
+			}
+			migration, err := Migrate(data)
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if version, _ := Version(migration.Content); version != 2 {
+				t.Errorf("Version() = %d, want 2", version)
+			}
+
+			var got v2Layout
+			if err := yaml.Unmarshal(migration.Content, &got); err != nil {
+				t.Fatalf("Migrated template is not valid YAML: %v", err)
+			}
+			for _, name := range got.Linters.Enable {
+				if _, removed := replacedLinters[name]; removed || formatters[name] {
+					t.Errorf("linters.enable still contains %s", name)
+				}
+			}
+		})
+	}
+}
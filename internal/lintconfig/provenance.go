@@ -0,0 +1,68 @@
+package lintconfig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// Header lines that mark a config file written from a registered config
+const (
+	provenancePrefix = "# Generated by go-standards-mcp-server from "
+	hashPrefix       = "# sha256: "
+	provenanceNote   = "# Do not edit: change the registered config and export it again."
+)
+
+// Provenance records which registered config a committed config file was
+// exported from
+type Provenance struct {
+	Source string `json:"source"` // e.g. "config:team@3" or "template:strict"
+	Hash   string `json:"hash"`   // SHA-256 of the config below the header
+}
+
+// Edited reports whether body is no longer the config that was exported
+func (p *Provenance) Edited(body []byte) bool {
+	return p.Hash != ContentHash(body)
+}
+
+// Ref returns the reference of the exported config without its revision
+func (p *Provenance) Ref() string {
+	ref, _, _ := strings.Cut(p.Source, "@")
+	return ref
+}
+
+// ContentHash returns the SHA-256 of config content in hex
+func ContentHash(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// AddProvenance prefixes a config with a header naming the config it was
+// exported from and the hash of its content, so that drift can be told
+// apart from later changes to the registered config
+func AddProvenance(data []byte, source string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(provenancePrefix + source + "\n")
+	buf.WriteString(hashPrefix + ContentHash(data) + "\n")
+	buf.WriteString(provenanceNote + "\n")
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// ParseProvenance splits a config file into its provenance header, nil if
+// it has none, and the config below it
+func ParseProvenance(data []byte) (*Provenance, []byte) {
+	lines := bytes.SplitAfterN(data, []byte("\n"), 4)
+	if len(lines) < 4 {
+		return nil, data
+	}
+	source, ok := strings.CutPrefix(strings.TrimSpace(string(lines[0])), provenancePrefix)
+	if !ok {
+		return nil, data
+	}
+	hash, ok := strings.CutPrefix(strings.TrimSpace(string(lines[1])), hashPrefix)
+	if !ok || strings.TrimSpace(string(lines[2])) != provenanceNote {
+		return nil, data
+	}
+	return &Provenance{Source: source, Hash: hash}, lines[3]
+}
@@ -0,0 +1,33 @@
+package lintconfig
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// issueCaps are the issues settings that limit how many issues
+// golangci-lint reports. Unset, they default to 50 and 3.
+var issueCaps = []string{"max-issues-per-linter", "max-same-issues"}
+
+// Uncap sets the issue caps of a config to 0, so golangci-lint reports
+// every issue it finds
+func Uncap(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{newMap()}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config must be a YAML mapping")
+	}
+
+	issues := child(root, "issues")
+	for _, key := range issueCaps {
+		set(issues, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "0"})
+	}
+	return encode(&doc)
+}
@@ -0,0 +1,38 @@
+package lintconfig
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUncap(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"capped", "version: \"2\"\nissues:\n  max-issues-per-linter: 50\n  max-same-issues: 3\n"},
+		{"default caps", "version: \"2\"\nlinters:\n  enable: [errcheck]\n"},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Uncap([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Uncap() error = %v", err)
+			}
+			var doc struct {
+				Issues map[string]interface{} `yaml:"issues"`
+			}
+			if err := yaml.Unmarshal(got, &doc); err != nil {
+				t.Fatalf("Uncap() returned invalid YAML: %v", err)
+			}
+			for _, key := range issueCaps {
+				if doc.Issues[key] != 0 {
+					t.Errorf("issues.%s = %v, want 0", key, doc.Issues[key])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,461 @@
+package lintconfig
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validation is the result of checking a golangci-lint config
+type Validation struct {
+	Valid    bool     `json:"valid"`
+	Version  int      `json:"version,omitempty"` // Detected config version, 1 or 2
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"` // Problems golangci-lint tolerates, such as deprecated linters
+}
+
+// Failed returns a validation that failed with a single error
+func Failed(err error) *Validation {
+	return &Validation{Errors: []string{err.Error()}}
+}
+
+// kind is the expected type of a config value
+type kind int
+
+const (
+	kindAny kind = iota
+	kindBool
+	kindInt
+	kindString
+	kindDuration
+	kindList
+	kindMap
+)
+
+// String returns the kind's name for error messages
+func (k kind) String() string {
+	switch k {
+	case kindBool:
+		return "a boolean"
+	case kindInt:
+		return "an integer"
+	case kindString:
+		return "a string"
+	case kindDuration:
+		return "a duration"
+	case kindList:
+		return "a list"
+	case kindMap:
+		return "a mapping"
+	}
+	return "any value"
+}
+
+// commonSchema lists the keys shared by both config versions, by
+// dot-separated path. Sections not listed here are not checked.
+var commonSchema = map[string]kind{
+	"run":                          kindMap,
+	"run.timeout":                  kindDuration,
+	"run.concurrency":              kindInt,
+	"run.tests":                    kindBool,
+	"run.build-tags":               kindList,
+	"run.modules-download-mode":    kindString,
+	"run.go":                       kindString,
+	"run.issues-exit-code":         kindInt,
+	"run.allow-parallel-runners":   kindBool,
+	"run.allow-serial-runners":     kindBool,
+	"run.relative-path-mode":       kindString,
+	"output":                       kindMap,
+	"output.sort-results":          kindBool,
+	"linters":                      kindMap,
+	"linters.enable":               kindList,
+	"linters.disable":              kindList,
+	"issues":                       kindMap,
+	"issues.max-issues-per-linter": kindInt,
+	"issues.max-same-issues":       kindInt,
+	"issues.new":                   kindBool,
+	"issues.new-from-rev":          kindString,
+	"issues.fix":                   kindBool,
+	"issues.new-from-patch":        kindString,
+	"issues.new-from-merge-base":   kindString,
+	"issues.whole-files":           kindBool,
+	"issues.uniq-by-line":          kindBool,
+	"severity":                     kindMap,
+	"extends":                      kindString,
+}
+
+// v1Schema lists the keys only valid in v1 configs
+var v1Schema = map[string]kind{
+	"run.skip-dirs":                   kindList,
+	"run.skip-files":                  kindList,
+	"run.skip-dirs-use-default":       kindBool,
+	"output.format":                   kindString,
+	"output.formats":                  kindAny,
+	"output.print-issued-lines":       kindBool,
+	"output.print-linter-name":        kindBool,
+	"output.uniq-by-line":             kindBool,
+	"linters.enable-all":              kindBool,
+	"linters.disable-all":             kindBool,
+	"linters.fast":                    kindBool,
+	"linters.presets":                 kindList,
+	"linters-settings":                kindMap,
+	"issues.exclude":                  kindList,
+	"issues.exclude-rules":            kindList,
+	"issues.exclude-use-default":      kindBool,
+	"issues.exclude-case-sensitive":   kindBool,
+	"issues.exclude-dirs":             kindList,
+	"issues.exclude-files":            kindList,
+	"issues.exclude-dirs-use-default": kindBool,
+	"issues.exclude-generated":        kindString,
+	"issues.include":                  kindList,
+	"severity.default-severity":       kindString,
+	"severity.rules":                  kindList,
+}
+
+// v2Schema lists the keys only valid in v2 configs
+var v2Schema = map[string]kind{
+	"version":                         kindString,
+	"output.formats":                  kindMap,
+	"output.path-prefix":              kindString,
+	"output.path-mode":                kindString,
+	"output.show-stats":               kindBool,
+	"linters.default":                 kindString,
+	"linters.fast-only":               kindBool,
+	"linters.settings":                kindMap,
+	"linters.exclusions":              kindMap,
+	"linters.exclusions.generated":    kindString,
+	"linters.exclusions.presets":      kindList,
+	"linters.exclusions.rules":        kindList,
+	"linters.exclusions.paths":        kindList,
+	"linters.exclusions.paths-except": kindList,
+	"linters.exclusions.warn-unused":  kindBool,
+	"formatters":                      kindMap,
+	"formatters.enable":               kindList,
+	"formatters.settings":             kindMap,
+	"formatters.exclusions":           kindMap,
+	"severity.default":                kindString,
+	"severity.rules":                  kindList,
+}
+
+// settingTypes lists the types of common linter settings, keyed by
+// "linter.setting"
+var settingTypes = map[string]kind{
+	"gocyclo.min-complexity":         kindInt,
+	"gocognit.min-complexity":        kindInt,
+	"cyclop.max-complexity":          kindInt,
+	"nestif.min-complexity":          kindInt,
+	"funlen.lines":                   kindInt,
+	"funlen.statements":              kindInt,
+	"lll.line-length":                kindInt,
+	"lll.tab-width":                  kindInt,
+	"dupl.threshold":                 kindInt,
+	"goconst.min-len":                kindInt,
+	"goconst.min-occurrences":        kindInt,
+	"nakedret.max-func-lines":        kindInt,
+	"maintidx.under":                 kindInt,
+	"misspell.locale":                kindString,
+	"misspell.ignore-words":          kindList,
+	"errcheck.check-type-assertions": kindBool,
+	"errcheck.check-blank":           kindBool,
+	"errcheck.exclude-functions":     kindList,
+	"govet.enable-all":               kindBool,
+	"govet.disable-all":              kindBool,
+	"govet.enable":                   kindList,
+	"govet.disable":                  kindList,
+	"govet.settings":                 kindMap,
+	"staticcheck.checks":             kindList,
+	"gosimple.checks":                kindList,
+	"stylecheck.checks":              kindList,
+	"gosec.includes":                 kindList,
+	"gosec.excludes":                 kindList,
+	"gosec.severity":                 kindString,
+	"gosec.confidence":               kindString,
+	"revive.rules":                   kindList,
+	"revive.confidence":              kindAny,
+	"gocritic.enabled-checks":        kindList,
+	"gocritic.disabled-checks":       kindList,
+	"gocritic.enabled-tags":          kindList,
+	"gocritic.disabled-tags":         kindList,
+	"depguard.rules":                 kindMap,
+	"gofmt.simplify":                 kindBool,
+	"goimports.local-prefixes":       kindAny,
+	"godot.scope":                    kindString,
+	"unparam.check-exported":         kindBool,
+}
+
+// knownLinters lists the linters golangci-lint accepts, including the
+// formatters and the linters removed in v2
+var knownLinters = toSet(
+	"asasalint", "asciicheck", "bidichk", "bodyclose", "canonicalheader",
+	"containedctx", "contextcheck", "copyloopvar", "cyclop", "decorder",
+	"depguard", "dogsled", "dupl", "dupword", "durationcheck",
+	"embeddedstructfieldcheck", "err113", "errcheck", "errchkjson", "errname",
+	"errorlint", "exhaustive", "exhaustruct", "exptostd", "fatcontext",
+	"forbidigo", "forcetypeassert", "funcorder", "funlen", "ginkgolinter",
+	"gocheckcompilerdirectives", "gochecknoglobals", "gochecknoinits",
+	"gochecksumtype", "gocognit", "goconst", "gocritic", "gocyclo", "godot",
+	"godox", "goheader", "gomoddirectives", "gomodguard", "goprintffuncname",
+	"gosec", "gosmopolitan", "govet", "grouper", "iface", "importas",
+	"inamedparam", "ineffassign", "interfacebloat", "intrange", "ireturn",
+	"lll", "loggercheck", "maintidx", "makezero", "mirror", "misspell", "mnd",
+	"musttag", "nakedret", "nestif", "nilerr", "nilnesserr", "nilnil",
+	"nlreturn", "noctx", "nolintlint", "nonamedreturns", "nosprintfhostport",
+	"paralleltest", "perfsprint", "prealloc", "predeclared", "promlinter",
+	"protogetter", "reassign", "recvcheck", "revive", "rowserrcheck",
+	"sloglint", "spancheck", "sqlclosecheck", "staticcheck", "tagalign",
+	"tagliatelle", "testableexamples", "testifylint", "testpackage", "thelper",
+	"tparallel", "unconvert", "unparam", "unused", "usestdlibvars",
+	"usetesting", "varnamelen", "wastedassign", "whitespace", "wrapcheck",
+	"wsl", "zerologlint",
+	// Formatters
+	"gci", "gofmt", "gofumpt", "goimports", "golines", "swaggo",
+	// v1 only
+	"gomnd", "goerr113", "execinquery", "exportloopref", "tenv",
+)
+
+// toSet builds a set from values
+func toSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// Validate checks a config for YAML syntax, the golangci-lint schema of its
+// version, unknown or deprecated linters, wrongly typed settings, and
+// contradictions such as a linter that is both enabled and disabled
+func Validate(data []byte) *Validation {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return Failed(fmt.Errorf("invalid YAML: %w", err))
+	}
+	if len(doc.Content) == 0 {
+		return Failed(fmt.Errorf("config is empty"))
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return Failed(fmt.Errorf("config must be a YAML mapping"))
+	}
+
+	v := &validator{result: &Validation{Version: 1}}
+	if version := get(root, "version"); version != nil {
+		switch version.Value {
+		case "2":
+			v.result.Version = 2
+		default:
+			v.errorf("version: unsupported config version %q (must be \"2\" or omitted for v1)", version.Value)
+		}
+	}
+
+	v.schema = make(map[string]kind, len(commonSchema)+len(v2Schema))
+	for path, k := range commonSchema {
+		v.schema[path] = k
+	}
+	extra := v1Schema
+	if v.result.Version == 2 {
+		extra = v2Schema
+	}
+	for path, k := range extra {
+		v.schema[path] = k
+	}
+
+	v.checkKeys(root, "")
+	v.checkLinters(root)
+	v.checkSettings(root)
+
+	v.result.Valid = len(v.result.Errors) == 0
+	return v.result
+}
+
+// validator collects the problems found in one config
+type validator struct {
+	schema map[string]kind
+	result *Validation
+}
+
+func (v *validator) errorf(format string, args ...interface{}) {
+	v.result.Errors = append(v.result.Errors, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) warnf(format string, args ...interface{}) {
+	v.result.Warnings = append(v.result.Warnings, fmt.Sprintf(format, args...))
+}
+
+// checkKeys reports unknown keys and wrongly typed values in the sections
+// covered by the schema
+func (v *validator) checkKeys(m *yaml.Node, prefix string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key, value := m.Content[i].Value, m.Content[i+1]
+		path := joinPath(prefix, key)
+
+		k, known := v.schema[path]
+		if !known {
+			if v.checked(prefix) {
+				v.errorf("%s: unknown setting for config version %d", path, v.result.Version)
+			}
+			continue
+		}
+		if err := checkKind(value, k); err != nil {
+			v.errorf("%s: %v", path, err)
+			continue
+		}
+		if k == kindMap {
+			v.checkKeys(value, path)
+		}
+	}
+}
+
+// checked reports whether the keys of a section are all listed in the
+// schema. Settings sections hold per-linter maps and are checked separately.
+func (v *validator) checked(section string) bool {
+	switch section {
+	case "", "run", "linters", "issues", "formatters", "linters.exclusions":
+		return true
+	}
+	return false
+}
+
+// checkLinters reports unknown, deprecated and contradictory linters
+func (v *validator) checkLinters(root *yaml.Node) {
+	linters := get(root, "linters")
+	enable := seqValues(get(linters, "enable"))
+	disable := seqValues(get(linters, "disable"))
+
+	for _, list := range []struct {
+		path  string
+		names []string
+	}{{"linters.enable", enable}, {"linters.disable", disable}} {
+		for _, name := range list.names {
+			v.checkLinterName(list.path, name)
+		}
+	}
+	for _, name := range seqValues(getPath(root, "formatters.enable")) {
+		if !formatters[name] && name != "swaggo" {
+			v.errorf("formatters.enable: %s is not a formatter", name)
+		}
+	}
+
+	disabled := toSet(disable...)
+	seen := make(map[string]bool)
+	for _, name := range enable {
+		if disabled[name] {
+			v.errorf("linters: %s is both enabled and disabled", name)
+		}
+		if seen[name] {
+			v.warnf("linters.enable: %s is listed more than once", name)
+		}
+		seen[name] = true
+	}
+
+	if isTrue(get(linters, "enable-all")) && isTrue(get(linters, "disable-all")) {
+		v.errorf("linters: enable-all and disable-all cannot both be set")
+	}
+	if isTrue(get(linters, "disable-all")) && len(disable) > 0 {
+		v.errorf("linters: disable cannot be combined with disable-all")
+	}
+	if isTrue(get(linters, "enable-all")) && len(enable) > 0 {
+		v.errorf("linters: enable cannot be combined with enable-all")
+	}
+	if def := get(linters, "default"); def != nil {
+		switch def.Value {
+		case "standard", "all", "none", "fast":
+		default:
+			v.errorf("linters.default: must be standard, all, none, or fast, not %q", def.Value)
+		}
+		if def.Value == "none" && len(disable) > 0 {
+			v.warnf("linters.disable has no effect with default: none")
+		}
+	}
+	if (isTrue(get(linters, "disable-all")) || isScalar(get(linters, "default"), "none")) && len(enable) == 0 && len(seqValues(getPath(root, "formatters.enable"))) == 0 {
+		v.errorf("linters: every linter is disabled, so golangci-lint would report nothing")
+	}
+}
+
+// checkLinterName reports an unknown or deprecated linter
+func (v *validator) checkLinterName(path, name string) {
+	replacement, removed := replacedLinters[name]
+	switch {
+	case removed && v.result.Version == 2:
+		if replacement != "" {
+			v.errorf("%s: %s was removed in v2; use %s", path, name, replacement)
+		} else {
+			v.errorf("%s: %s was removed in v2", path, name)
+		}
+	case removed:
+		if replacement != "" {
+			v.warnf("%s: %s is deprecated; use %s", path, name, replacement)
+		} else {
+			v.warnf("%s: %s is deprecated", path, name)
+		}
+	case v.result.Version == 2 && (formatters[name] || name == "swaggo"):
+		v.errorf("%s: %s is a formatter in v2; list it in formatters.enable", path, name)
+	case !knownLinters[name]:
+		v.errorf("%s: unknown linter %s", path, name)
+	}
+}
+
+// checkSettings checks per-linter settings for unknown linters and
+// wrongly typed values
+func (v *validator) checkSettings(root *yaml.Node) {
+	sections := []string{"linters-settings"}
+	if v.result.Version == 2 {
+		sections = []string{"linters.settings", "formatters.settings"}
+	}
+
+	for _, section := range sections {
+		settings := getPath(root, section)
+		if settings == nil || settings.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(settings.Content); i += 2 {
+			name, value := settings.Content[i].Value, settings.Content[i+1]
+			path := section + "." + name
+			if !knownLinters[name] && name != "custom" {
+				v.warnf("%s: settings for unknown linter %s", path, name)
+			}
+			if value.Kind != yaml.MappingNode {
+				v.errorf("%s: must be a mapping", path)
+				continue
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				key := value.Content[j].Value
+				if k, ok := settingTypes[name+"."+key]; ok {
+					if err := checkKind(value.Content[j+1], k); err != nil {
+						v.errorf("%s.%s: %v", path, key, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkKind checks that a node holds a value of kind k
+func checkKind(n *yaml.Node, k kind) error {
+	ok := true
+	switch k {
+	case kindBool:
+		ok = n.Kind == yaml.ScalarNode && n.Tag == "!!bool"
+	case kindInt:
+		ok = n.Kind == yaml.ScalarNode && n.Tag == "!!int"
+	case kindString:
+		ok = n.Kind == yaml.ScalarNode && n.Tag != "!!map" && n.Tag != "!!seq"
+	case kindDuration:
+		if n.Kind == yaml.ScalarNode {
+			if _, err := time.ParseDuration(n.Value); err != nil {
+				return fmt.Errorf("must be a duration such as 5m, not %q", n.Value)
+			}
+		} else {
+			ok = false
+		}
+	case kindList:
+		ok = n.Kind == yaml.SequenceNode
+	case kindMap:
+		ok = n.Kind == yaml.MappingNode
+	}
+	if !ok {
+		return fmt.Errorf("must be %s", k)
+	}
+	return nil
+}
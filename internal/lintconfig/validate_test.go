@@ -0,0 +1,97 @@
+package lintconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		valid    bool
+		problems []string // Substrings expected in the errors and warnings
+	}{
+		{
+			name:   "valid v1 config",
+			config: "linters:\n  disable-all: true\n  enable: [errcheck, gocyclo]\nlinters-settings:\n  gocyclo:\n    min-complexity: 10\n",
+			valid:  true,
+		},
+		{
+			name:   "valid v2 config",
+			config: "version: \"2\"\nlinters:\n  default: none\n  enable: [errcheck]\nformatters:\n  enable: [gofmt]\n",
+			valid:  true,
+		},
+		{
+			name:     "YAML syntax error",
+			config:   "linters:\n  enable: [errcheck\n",
+			problems: []string{"invalid YAML"},
+		},
+		{
+			name:     "unknown linter",
+			config:   "linters:\n  enable: [errchek]\n",
+			problems: []string{"unknown linter errchek"},
+		},
+		{
+			name:     "deprecated linter in v1",
+			config:   "linters:\n  enable: [deadcode]\n",
+			valid:    true,
+			problems: []string{"deadcode is deprecated; use unused"},
+		},
+		{
+			name:     "removed linter in v2",
+			config:   "version: \"2\"\nlinters:\n  enable: [gosimple]\n",
+			problems: []string{"gosimple was removed in v2; use staticcheck"},
+		},
+		{
+			name:     "formatter listed as linter in v2",
+			config:   "version: \"2\"\nlinters:\n  enable: [gofmt]\n",
+			problems: []string{"gofmt is a formatter"},
+		},
+		{
+			name:     "wrongly typed setting",
+			config:   "linters-settings:\n  gocyclo:\n    min-complexity: high\n",
+			problems: []string{"min-complexity: must be an integer"},
+		},
+		{
+			name:     "invalid duration",
+			config:   "run:\n  timeout: forever\n",
+			problems: []string{"run.timeout: must be a duration"},
+		},
+		{
+			name:     "v2 key in v1 config",
+			config:   "linters:\n  default: none\n  enable: [errcheck]\n",
+			problems: []string{"linters.default: unknown setting for config version 1"},
+		},
+		{
+			name:     "enabled and disabled",
+			config:   "linters:\n  enable: [errcheck]\n  disable: [errcheck]\n",
+			problems: []string{"errcheck is both enabled and disabled"},
+		},
+		{
+			name:     "disable with disable-all",
+			config:   "linters:\n  disable-all: true\n  enable: [govet]\n  disable: [errcheck]\n",
+			problems: []string{"disable cannot be combined with disable-all"},
+		},
+		{
+			name:     "nothing enabled",
+			config:   "linters:\n  disable-all: true\n",
+			problems: []string{"every linter is disabled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Validate([]byte(tt.config))
+			if got.Valid != tt.valid {
+				t.Errorf("Validate().Valid = %v, want %v (errors: %v)", got.Valid, tt.valid, got.Errors)
+			}
+			all := strings.Join(append(append([]string{}, got.Errors...), got.Warnings...), "\n")
+			for _, want := range tt.problems {
+				if !strings.Contains(all, want) {
+					t.Errorf("Validate() problems = %q, want one containing %q", all, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package lintconfig
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Helpers for editing yaml.v3 node trees in place, which keeps key order
+// and comments intact
+
+// encode writes a node tree as YAML with two-space indentation
+func encode(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// newMap creates an empty mapping node
+func newMap() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// newSeq creates a sequence node of strings
+func newSeq(values ...string) *yaml.Node {
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, v := range values {
+		seq.Content = append(seq.Content, newScalar(v))
+	}
+	return seq
+}
+
+// newScalar creates a string scalar node
+func newScalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// newBool creates a boolean scalar node
+func newBool(value bool) *yaml.Node {
+	v := "false"
+	if value {
+		v = "true"
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: v}
+}
+
+// get returns the value for key in a mapping node, or nil
+func get(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// getPath returns the value at a dot-separated path, or nil
+func getPath(m *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		m = get(m, key)
+		if m == nil {
+			return nil
+		}
+	}
+	return m
+}
+
+// set sets key to value in a mapping node, appending it if absent
+func set(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, newScalar(key), value)
+}
+
+// setFirst sets key to value in a mapping node, moving it to the front
+func setFirst(m *yaml.Node, key string, value *yaml.Node) {
+	remove(m, key)
+	m.Content = append([]*yaml.Node{newScalar(key), value}, m.Content...)
+}
+
+// remove deletes key from a mapping node and returns its value, or nil
+func remove(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			value := m.Content[i+1]
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return value
+		}
+	}
+	return nil
+}
+
+// child returns the mapping at key, creating it if absent
+func child(m *yaml.Node, key string) *yaml.Node {
+	if c := get(m, key); c != nil && c.Kind == yaml.MappingNode {
+		return c
+	}
+	c := newMap()
+	set(m, key, c)
+	return c
+}
+
+// isTrue reports whether a scalar node holds a true boolean
+func isTrue(n *yaml.Node) bool {
+	return n != nil && n.Kind == yaml.ScalarNode && strings.EqualFold(n.Value, "true")
+}
+
+// seqValues returns the values of a sequence of scalars
+func seqValues(n *yaml.Node) []string {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	values := make([]string, 0, len(n.Content))
+	for _, item := range n.Content {
+		if item.Kind == yaml.ScalarNode {
+			values = append(values, item.Value)
+		}
+	}
+	return values
+}
+
+// appendUnique appends values to a sequence node, skipping duplicates
+func appendUnique(seq *yaml.Node, values ...string) {
+	existing := make(map[string]bool)
+	for _, v := range seqValues(seq) {
+		existing[v] = true
+	}
+	for _, v := range values {
+		if !existing[v] {
+			existing[v] = true
+			seq.Content = append(seq.Content, newScalar(v))
+		}
+	}
+}
+
+// seqChild returns the sequence at key, creating it if absent
+func seqChild(m *yaml.Node, key string) *yaml.Node {
+	if c := get(m, key); c != nil && c.Kind == yaml.SequenceNode {
+		return c
+	}
+	c := newSeq()
+	set(m, key, c)
+	return c
+}
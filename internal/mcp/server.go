@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"go-standards-mcp-server/internal/analyzer"
-	"go-standards-mcp-server/internal/config"
 	"go-standards-mcp-server/internal/git"
+	"go-standards-mcp-server/internal/lintconfig"
+	"go-standards-mcp-server/internal/recommend"
 	"go-standards-mcp-server/internal/storage"
+	"go-standards-mcp-server/internal/textdiff"
 	"go-standards-mcp-server/internal/usercontext"
 	"go-standards-mcp-server/pkg/models"
 
@@ -24,7 +28,6 @@ const (
 
 // Server represents the MCP server
 type Server struct {
-	config         *config.Config
 	logger         *zap.Logger
 	analyzer       *analyzer.Analyzer
 	srv            *server.MCPServer
@@ -34,15 +37,17 @@ type Server struct {
 }
 
 // NewServer creates a new MCP server instance
-func NewServer(cfg *config.Config, logger *zap.Logger, analyzer *analyzer.Analyzer, sessionManager *usercontext.SessionManager) (*Server, error) {
+func NewServer(logger *zap.Logger, analyzer *analyzer.Analyzer, sessionManager *usercontext.SessionManager) (*Server, error) {
 	// Initialize config storage
-	configStorage, err := storage.NewConfigStorage("./configs/custom")
+	configStorage, err := storage.NewConfigStorage(storage.DefaultConfigDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize config storage: %w", err)
 	}
 
+	// Custom configs may extend stored configs
+	analyzer.SetConfigStorage(configStorage)
+
 	s := &Server{
-		config:         cfg,
 		logger:         logger,
 		analyzer:       analyzer,
 		configStorage:  configStorage,
@@ -81,10 +86,28 @@ func (s *Server) registerTools(mcpServer *server.MCPServer) error {
 		},
 		{
 			name:        "manage_config",
-			description: "Manage custom configuration files - upload, update, delete, or list configurations",
+			description: "Manage custom configuration files - upload, update, delete, or list configurations, import them from a repository, export them into one, or check repositories for drift",
 			schema:      s.getManageConfigSchema(),
 			handler:     s.handleManageConfig,
 		},
+		{
+			name:        "diff_configs",
+			description: "Compare two lint configs (templates, stored configs, inline YAML or a repository's .golangci.yml) by linters, thresholds and exclusions, and say whether the change is stricter or looser",
+			schema:      s.getDiffConfigsSchema(),
+			handler:     s.handleDiffConfigs,
+		},
+		{
+			name:        "explain_config",
+			description: "Explain in plain language what a lint config enforces: each enabled linter and what it checks, each threshold, and each exclusion and its scope",
+			schema:      s.getExplainConfigSchema(),
+			handler:     s.handleExplainConfig,
+		},
+		{
+			name:        "recommend_config",
+			description: "Recommend a lint config tuned to a project's current state: analyze it with a target template, count the issues of each linter and threshold value, and plan milestones that tighten the config from near the current state to the target",
+			schema:      s.getRecommendConfigSchema(),
+			handler:     s.handleRecommendConfig,
+		},
 		{
 			name:        "manage_templates",
 			description: "Manage predefined configuration templates - list available templates and their details",
@@ -99,7 +122,7 @@ func (s *Server) registerTools(mcpServer *server.MCPServer) error {
 		},
 		{
 			name:        "batch_analyze",
-			description: "Batch analyze multiple Go projects in parallel",
+			description: "Batch analyze multiple Go projects with the same standard or lint config",
 			schema:      s.getBatchAnalyzeSchema(),
 			handler:     s.handleBatchAnalyze,
 		},
@@ -109,6 +132,12 @@ func (s *Server) registerTools(mcpServer *server.MCPServer) error {
 			schema:      s.getHealthCheckSchema(),
 			handler:     s.handleHealthCheck,
 		},
+		{
+			name:        "list_linters",
+			description: "List registered linters with their availability, versions and capabilities",
+			schema:      s.getListLintersSchema(),
+			handler:     s.handleListLinters,
+		},
 		{
 			name:        "upload_document",
 			description: "Upload team code standard document (PDF, TXT, Markdown) and auto-convert to golangci-lint config",
@@ -178,13 +207,34 @@ func (s *Server) getAnalyzeCodeSchema() mcp.ToolInputSchema {
 			},
 			"standard": map[string]interface{}{
 				"type":        "string",
-				"description": "Configuration standard to use: strict, standard, relaxed, or custom",
+				"description": "Configuration standard to use: strict, standard, relaxed, or custom. Defaults to the standards declared in the project's .go-standards.json, or standard",
 				"enum":        []string{"strict", "standard", "relaxed", "custom"},
-				"default":     "standard",
 			},
 			"config": map[string]interface{}{
 				"type":        "string",
-				"description": "Custom configuration content (YAML format, required if standard is 'custom')",
+				"description": "Custom configuration content (YAML format, required if standard is 'custom' and config_ref is not set)",
+			},
+			"config_ref": map[string]interface{}{
+				"type":        "string",
+				"description": "Lint config to use: a stored config name, a template name, or a path to a golangci-lint config, tried in that order. Prefix with config: or template: to select one kind",
+			},
+			"baseline": map[string]interface{}{
+				"type":        "string",
+				"description": "Path to a baseline file; issues recorded there are reported separately and do not affect the score (default: <project_dir>/.go-standards-baseline.json if present)",
+			},
+			"update_baseline": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Snapshot the current issues into the baseline file",
+				"default":     false,
+			},
+			"coverage": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Run the project's tests and enforce the standard's coverage threshold (requires project_dir)",
+				"default":     false,
+			},
+			"cover_profile": map[string]interface{}{
+				"type":        "string",
+				"description": "Path to an existing coverage profile (go test -coverprofile) to use instead of running tests",
 			},
 			"format": map[string]interface{}{
 				"type":        "string",
@@ -218,15 +268,63 @@ func (s *Server) getManageConfigSchema() mcp.ToolInputSchema {
 			"action": map[string]interface{}{
 				"type":        "string",
 				"description": "Action to perform",
-				"enum":        []string{"upload", "update", "delete", "list", "get"},
+				"enum":        []string{"upload", "update", "delete", "list", "get", "migrate", "resolve", "validate", "revisions", "get_revision", "diff", "rollback", "import", "export", "drift"},
 			},
 			"name": map[string]interface{}{
 				"type":        "string",
-				"description": "Configuration name (required for upload, update, delete, get)",
+				"description": "Configuration name (required for upload, update, delete, get, revisions, get_revision, diff, rollback, import; for migrate, resolve, validate, export and drift, the stored config to use)",
 			},
 			"content": map[string]interface{}{
 				"type":        "string",
-				"description": "Configuration content in YAML format (required for upload, update)",
+				"description": "Configuration content in YAML format (required for upload, update; for migrate, resolve and validate, a config to use). A config may start with 'extends: <template or stored config>' and list only its overrides",
+			},
+			"author": map[string]interface{}{
+				"type":        "string",
+				"description": "Who made the change, recorded in the new revision (upload, update, migrate with save, rollback)",
+			},
+			"note": map[string]interface{}{
+				"type":        "string",
+				"description": "Change note recorded in the new revision",
+			},
+			"revision": map[string]interface{}{
+				"type":        "integer",
+				"description": "Revision number for get_revision and rollback",
+			},
+			"from": map[string]interface{}{
+				"type":        "integer",
+				"description": "For diff, the older revision (default: the one before to)",
+			},
+			"to": map[string]interface{}{
+				"type":        "integer",
+				"description": "For diff, the newer revision (default: the current revision)",
+			},
+			"strict": map[string]interface{}{
+				"type":        "boolean",
+				"description": "For upload and update, reject the config if it fails validation instead of storing it as invalid",
+				"default":     false,
+			},
+			"template": map[string]interface{}{
+				"type":        "string",
+				"description": "Template to convert with the migrate action, write with export, or compare with drift: strict, standard, or relaxed",
+			},
+			"path": map[string]interface{}{
+				"type":        "string",
+				"description": "For import, a repository directory or a .golangci.yml, .toml or .json file to store; for export, the repository directory to write .golangci.yml to",
+			},
+			"paths": map[string]interface{}{
+				"type":        "array",
+				"description": "For drift, the repository directories to check. Each is compared with name or template, or else with the config its .golangci.yml was exported from",
+				"items":       map[string]interface{}{"type": "string"},
+			},
+			"force": map[string]interface{}{
+				"type":        "boolean",
+				"description": "For export, replace a .golangci.yml that was not exported from a registered config or was edited since",
+				"default":     false,
+			},
+			"save": map[string]interface{}{
+				"type":        "boolean",
+				"description": "For migrate with name, store the converted config in place of the original",
+				"default":     false,
 			},
 			"description": map[string]interface{}{
 				"type":        "string",
@@ -236,6 +334,84 @@ func (s *Server) getManageConfigSchema() mcp.ToolInputSchema {
 	}
 }
 
+// getDiffConfigsSchema returns the JSON schema for diff_configs tool
+func (s *Server) getDiffConfigsSchema() mcp.ToolInputSchema {
+	return mcp.ToolInputSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"from": map[string]interface{}{
+				"type":        "string",
+				"description": "Base config: a stored config name, a template name, or a path to a config file or a directory containing .golangci.yml. Prefix with config: or template: to pick one kind",
+			},
+			"to": map[string]interface{}{
+				"type":        "string",
+				"description": "Changed config, referenced like from",
+			},
+			"from_content": map[string]interface{}{
+				"type":        "string",
+				"description": "Inline YAML to use instead of from",
+			},
+			"to_content": map[string]interface{}{
+				"type":        "string",
+				"description": "Inline YAML to use instead of to",
+			},
+			"format": map[string]interface{}{
+				"type":        "string",
+				"description": "Output format (default: text)",
+				"enum":        []string{"text", "json"},
+			},
+		},
+	}
+}
+
+// getExplainConfigSchema returns the JSON schema for explain_config tool
+func (s *Server) getExplainConfigSchema() mcp.ToolInputSchema {
+	return mcp.ToolInputSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Config to explain: a template name, a stored config name, or a path to a config file or a directory containing .golangci.yml. Prefix with config: or template: to pick one kind",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"description": "Inline YAML to explain instead of name",
+			},
+			"format": map[string]interface{}{
+				"type":        "string",
+				"description": "Output format (default: markdown)",
+				"enum":        []string{"markdown", "json"},
+			},
+		},
+	}
+}
+
+// getRecommendConfigSchema returns the JSON schema for recommend_config tool
+func (s *Server) getRecommendConfigSchema() mcp.ToolInputSchema {
+	return mcp.ToolInputSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"project_dir": map[string]interface{}{
+				"type":        "string",
+				"description": "Path to the project directory",
+			},
+			"target": map[string]interface{}{
+				"type":        "string",
+				"description": "Template the plan ends at (default: strict)",
+			},
+			"milestones": map[string]interface{}{
+				"type":        "integer",
+				"description": "Number of milestones after the starting config (default: 3, at most 10)",
+			},
+			"format": map[string]interface{}{
+				"type":        "string",
+				"description": "Output format (default: markdown)",
+				"enum":        []string{"markdown", "json"},
+			},
+		},
+	}
+}
+
 // getManageTemplatesSchema returns the JSON schema for manage_templates tool
 func (s *Server) getManageTemplatesSchema() mcp.ToolInputSchema {
 	return mcp.ToolInputSchema{
@@ -302,17 +478,21 @@ func (s *Server) getBatchAnalyzeSchema() mcp.ToolInputSchema {
 				},
 			},
 			"standard": map[string]interface{}{
-				"type":    "string",
-				"enum":    []string{"strict", "standard", "relaxed", "custom"},
-				"default": "standard",
+				"type":        "string",
+				"description": "Configuration standard to use. Defaults to the standards declared in each project's .go-standards.json, or standard",
+				"enum":        []string{"strict", "standard", "relaxed", "custom"},
 			},
 			"config": map[string]interface{}{
 				"type":        "string",
 				"description": "Custom configuration content",
 			},
+			"config_ref": map[string]interface{}{
+				"type":        "string",
+				"description": "Lint config to use for every project: a stored config name, a template name, or a path to a golangci-lint config",
+			},
 			"format": map[string]interface{}{
 				"type":    "string",
-				"enum":    []string{"json", "markdown", "html"},
+				"enum":    []string{"json", "markdown"},
 				"default": "json",
 			},
 		},
@@ -327,6 +507,14 @@ func (s *Server) getHealthCheckSchema() mcp.ToolInputSchema {
 	}
 }
 
+// getListLintersSchema returns the JSON schema for list_linters tool
+func (s *Server) getListLintersSchema() mcp.ToolInputSchema {
+	return mcp.ToolInputSchema{
+		Type:       "object",
+		Properties: map[string]interface{}{},
+	}
+}
+
 // handleAnalyzeCode handles the analyze_code tool invocation
 func (s *Server) handleAnalyzeCode(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	s.logger.Info("Handling analyze_code request")
@@ -337,10 +525,7 @@ func (s *Server) handleAnalyzeCode(arguments map[string]interface{}) (*mcp.CallT
 		return nil, fmt.Errorf("invalid arguments: %w", err)
 	}
 
-	// Set defaults
-	if req.Standard == "" {
-		req.Standard = "standard"
-	}
+	// Set defaults; an empty standard lets the project's standards file decide
 	if req.Format == "" {
 		req.Format = "json"
 	}
@@ -372,15 +557,27 @@ func (s *Server) handleManageConfig(arguments map[string]interface{}) (*mcp.Call
 	s.logger.Info("Handling manage_config request")
 
 	var args struct {
-		Action      string `json:"action"`
-		Name        string `json:"name"`
-		Content     string `json:"content"`
-		Description string `json:"description"`
+		Action      string   `json:"action"`
+		Name        string   `json:"name"`
+		Content     string   `json:"content"`
+		Description string   `json:"description"`
+		Template    string   `json:"template"`
+		Save        bool     `json:"save"`
+		Strict      bool     `json:"strict"`
+		Author      string   `json:"author"`
+		Note        string   `json:"note"`
+		Revision    int      `json:"revision"`
+		From        int      `json:"from"`
+		To          int      `json:"to"`
+		Path        string   `json:"path"`
+		Paths       []string `json:"paths"`
+		Force       bool     `json:"force"`
 	}
 
 	if err := parseArguments(arguments, &args); err != nil {
 		return nil, fmt.Errorf("invalid arguments: %w", err)
 	}
+	change := storage.Change{Author: args.Author, Note: args.Note}
 
 	var response string
 	var err error
@@ -401,10 +598,78 @@ func (s *Server) handleManageConfig(arguments map[string]interface{}) (*mcp.Call
 		if args.Name == "" || args.Content == "" {
 			return nil, fmt.Errorf("name and content are required")
 		}
-		if err := s.configStorage.Save(args.Name, args.Content, args.Description); err != nil {
+		validation := s.analyzer.ValidateConfig([]byte(args.Content), "config:"+args.Name)
+		if !validation.Valid && (args.Strict || s.analyzer.Config().Storage.StrictValidation) {
+			return nil, fmt.Errorf("config %s is invalid: %s", args.Name, strings.Join(validation.Errors, "; "))
+		}
+		if err := s.configStorage.Save(args.Name, args.Content, args.Description, validation, change); err != nil {
 			return nil, fmt.Errorf("failed to save config: %w", err)
 		}
-		response = fmt.Sprintf(`{"message": "Config '%s' saved successfully"}`, args.Name)
+		message := fmt.Sprintf("Config '%s' saved successfully", args.Name)
+		if !validation.Valid {
+			message = fmt.Sprintf("Config '%s' saved, but it is invalid", args.Name)
+		}
+		data, err := json.MarshalIndent(configSaveResult{Message: message, Validation: validation}, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		response = string(data)
+
+	case "revisions":
+		if args.Name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		revisions, err := s.configStorage.Revisions(args.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list revisions: %w", err)
+		}
+		data, err := json.MarshalIndent(revisions, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal revisions: %w", err)
+		}
+		response = string(data)
+
+	case "get_revision":
+		if args.Name == "" || args.Revision == 0 {
+			return nil, fmt.Errorf("name and revision are required")
+		}
+		revision, err := s.configStorage.GetRevision(args.Name, args.Revision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get revision: %w", err)
+		}
+		data, err := json.MarshalIndent(revision, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal revision: %w", err)
+		}
+		response = string(data)
+
+	case "diff":
+		response, err = s.diffRevisions(args.Name, args.From, args.To)
+		if err != nil {
+			return nil, err
+		}
+
+	case "rollback":
+		result, err := s.rollbackConfig(args.Name, args.Revision, change)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		response = string(data)
+
+	case "validate":
+		validation, err := s.validateConfig(args.Name, args.Content)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(validation, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal validation: %w", err)
+		}
+		response = string(data)
 
 	case "get":
 		if args.Name == "" {
@@ -429,8 +694,82 @@ func (s *Server) handleManageConfig(arguments map[string]interface{}) (*mcp.Call
 		}
 		response = fmt.Sprintf(`{"message": "Config '%s' deleted successfully"}`, args.Name)
 
+	case "migrate":
+		if change.Note == "" {
+			change.Note = "migrated to golangci-lint v2"
+		}
+		result, err := s.migrateConfig(args.Name, args.Template, args.Content, args.Save, change)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal migration: %w", err)
+		}
+		response = string(data)
+
+	case "resolve":
+		result, err := s.resolveConfig(args.Name, args.Content)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal resolved config: %w", err)
+		}
+		response = string(data)
+
+	case "import":
+		result, err := s.importConfig(args.Path, args.Name, args.Description, args.Strict, change)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		response = string(data)
+
+	case "export":
+		if args.Path == "" {
+			return nil, fmt.Errorf("path is required")
+		}
+		ref, err := configOrTemplate(args.Name, args.Template)
+		if err != nil {
+			return nil, err
+		}
+		result, err := s.analyzer.ExportConfig(ref, args.Path, args.Force)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		response = string(data)
+
+	case "drift":
+		if len(args.Paths) == 0 {
+			return nil, fmt.Errorf("paths is required")
+		}
+		var ref string
+		if args.Name != "" || args.Template != "" {
+			if ref, err = configOrTemplate(args.Name, args.Template); err != nil {
+				return nil, err
+			}
+		}
+		reports := make([]*analyzer.DriftReport, 0, len(args.Paths))
+		for _, repo := range args.Paths {
+			reports = append(reports, s.analyzer.CheckDrift(repo, ref))
+		}
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal drift reports: %w", err)
+		}
+		response = string(data)
+
 	default:
-		return nil, fmt.Errorf("unknown action: %s (valid actions: list, upload, update, get, delete)", args.Action)
+		return nil, fmt.Errorf("unknown action: %s (valid actions: list, upload, update, get, delete, migrate, resolve, validate, revisions, get_revision, diff, rollback, import, export, drift)", args.Action)
 	}
 
 	_ = err // avoid unused variable warning
@@ -445,6 +784,443 @@ func (s *Server) handleManageConfig(arguments map[string]interface{}) (*mcp.Call
 	}, nil
 }
 
+// migrationResult is the response of the manage_config migrate action
+type migrationResult struct {
+	Source   string   `json:"source"`
+	Content  string   `json:"content"`
+	Changes  []string `json:"changes"`
+	Warnings []string `json:"warnings,omitempty"`
+	Saved    bool     `json:"saved"`
+}
+
+// migrateConfig converts a stored config, template, or inline config to the
+// golangci-lint v2 layout
+func (s *Server) migrateConfig(name, template, content string, save bool, change storage.Change) (*migrationResult, error) {
+	var source string
+	switch {
+	case name != "":
+		stored, err := s.configStorage.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config: %w", err)
+		}
+		source = "config:" + name
+		content = stored.Content
+	case template != "":
+		data, label, err := s.analyzer.LoadConfigRef("template:"+template)
+		if err != nil {
+			return nil, err
+		}
+		source = label
+		content = string(data)
+	case content != "":
+		source = "content"
+	default:
+		return nil, fmt.Errorf("one of name, template, or content is required")
+	}
+
+	migration, err := lintconfig.Migrate([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate config: %w", err)
+	}
+
+	result := &migrationResult{
+		Source:   source,
+		Content:  string(migration.Content),
+		Changes:  migration.Changes,
+		Warnings: migration.Warnings,
+	}
+
+	if save && name != "" {
+		stored, err := s.configStorage.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config: %w", err)
+		}
+		validation := s.analyzer.ValidateConfig([]byte(result.Content), "config:"+name)
+		if err := s.configStorage.Save(name, result.Content, stored.Description, validation, change); err != nil {
+			return nil, fmt.Errorf("failed to save config: %w", err)
+		}
+		result.Saved = true
+	}
+
+	return result, nil
+}
+
+// configSaveResult is the response of the manage_config upload and update
+// actions
+type configSaveResult struct {
+	Message string `json:"message"`
+	*lintconfig.Validation
+}
+
+// diffRevisions returns a unified diff between two revisions of a stored
+// config. to defaults to the current revision and from to the one before it.
+func (s *Server) diffRevisions(name string, from, to int) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("name is required")
+	}
+	if to == 0 {
+		stored, err := s.configStorage.Get(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get config: %w", err)
+		}
+		to = stored.Revision
+	}
+	if from == 0 {
+		from = to - 1
+	}
+
+	fromRev, err := s.configStorage.GetRevision(name, from)
+	if err != nil {
+		return "", fmt.Errorf("failed to get revision: %w", err)
+	}
+	toRev, err := s.configStorage.GetRevision(name, to)
+	if err != nil {
+		return "", fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	diff := textdiff.Unified(fromRev.Content, toRev.Content,
+		fmt.Sprintf("%s@%d", name, from), fmt.Sprintf("%s@%d", name, to))
+	if diff == "" {
+		return fmt.Sprintf("Revisions %d and %d of %s are identical\n", from, to, name), nil
+	}
+	return diff, nil
+}
+
+// rollbackConfig restores the content of an earlier revision as a new
+// revision, so the rollback itself can be undone
+func (s *Server) rollbackConfig(name string, revision int, change storage.Change) (*configSaveResult, error) {
+	if name == "" || revision == 0 {
+		return nil, fmt.Errorf("name and revision are required")
+	}
+	rev, err := s.configStorage.GetRevision(name, revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	// A deleted config can be restored too; it has no description left
+	var description string
+	if stored, err := s.configStorage.Get(name); err == nil {
+		description = stored.Description
+	}
+	if change.Note == "" {
+		change.Note = fmt.Sprintf("rolled back to revision %d", revision)
+	}
+
+	validation := s.analyzer.ValidateConfig([]byte(rev.Content), "config:"+name)
+	if err := s.configStorage.Save(name, rev.Content, description, validation, change); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+	return &configSaveResult{
+		Message:    fmt.Sprintf("Config '%s' rolled back to revision %d", name, revision),
+		Validation: validation,
+	}, nil
+}
+
+// importConfig stores the golangci-lint config committed to a repository
+// under name, converted to YAML
+func (s *Server) importConfig(path, name, description string, strict bool, change storage.Change) (*configSaveResult, error) {
+	if path == "" || name == "" {
+		return nil, fmt.Errorf("path and name are required")
+	}
+	committed, err := s.analyzer.ReadRepoConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if change.Note == "" {
+		change.Note = "imported from " + committed.File
+	}
+
+	validation := s.analyzer.ValidateConfig(committed.Content, "config:"+name)
+	if !validation.Valid && (strict || s.analyzer.Config().Storage.StrictValidation) {
+		return nil, fmt.Errorf("config %s is invalid: %s", committed.File, strings.Join(validation.Errors, "; "))
+	}
+	if err := s.configStorage.Save(name, string(committed.Content), description, validation, change); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	message := fmt.Sprintf("Config '%s' imported from %s", name, committed.File)
+	if committed.Format != "yaml" {
+		message += fmt.Sprintf(" (converted from %s)", strings.ToUpper(committed.Format))
+	}
+	if !validation.Valid {
+		message += ", but it is invalid"
+	}
+	return &configSaveResult{Message: message, Validation: validation}, nil
+}
+
+// configOrTemplate returns the reference to a stored config or template
+func configOrTemplate(name, template string) (string, error) {
+	switch {
+	case name != "" && template != "":
+		return "", fmt.Errorf("name and template cannot both be set")
+	case name != "":
+		return "config:" + name, nil
+	case template != "":
+		return "template:" + template, nil
+	default:
+		return "", fmt.Errorf("name or template is required")
+	}
+}
+
+// validateConfig validates a stored or inline config. The stored result of
+// a stored config is refreshed, since the configs it extends may have
+// changed since it was saved.
+func (s *Server) validateConfig(name, content string) (*lintconfig.Validation, error) {
+	if name == "" {
+		if content == "" {
+			return nil, fmt.Errorf("name or content is required")
+		}
+		return s.analyzer.ValidateConfig([]byte(content), "content"), nil
+	}
+
+	stored, err := s.configStorage.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	validation := s.analyzer.ValidateConfig([]byte(stored.Content), "config:"+name)
+	if validation.Valid != stored.Valid ||
+		!reflect.DeepEqual(validation.Errors, stored.ValidationErrors) ||
+		!reflect.DeepEqual(validation.Warnings, stored.ValidationWarnings) {
+		if err := s.configStorage.SetValidation(name, validation); err != nil {
+			return nil, fmt.Errorf("failed to save validation result: %w", err)
+		}
+	}
+	return validation, nil
+}
+
+// resolveResult is the response of the manage_config resolve action
+type resolveResult struct {
+	Content string              `json:"content"`
+	Chain   []string            `json:"chain"`   // The config, then each config it extends
+	Origins []lintconfig.Origin `json:"origins"` // Source of every setting in content
+}
+
+// resolveConfig merges a stored or inline config with the configs it
+// extends and reports where each setting came from
+func (s *Server) resolveConfig(name, content string) (*resolveResult, error) {
+	source := "content"
+	switch {
+	case name != "":
+		stored, err := s.configStorage.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config: %w", err)
+		}
+		source = "config:" + name
+		content = stored.Content
+	case content == "":
+		return nil, fmt.Errorf("name or content is required")
+	}
+
+	resolution, err := s.analyzer.ResolveConfig([]byte(content), source)
+	if err != nil {
+		return nil, err
+	}
+	return &resolveResult{
+		Content: string(resolution.Content),
+		Chain:   resolution.Chain,
+		Origins: resolution.Origins,
+	}, nil
+}
+
+// handleDiffConfigs handles the diff_configs tool
+func (s *Server) handleDiffConfigs(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	var args struct {
+		From        string `json:"from"`
+		To          string `json:"to"`
+		FromContent string `json:"from_content"`
+		ToContent   string `json:"to_content"`
+		Format      string `json:"format"`
+	}
+	if err := parseArguments(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	s.logger.Info("Handling diff_configs request",
+		zap.String("from", args.From),
+		zap.String("to", args.To))
+
+	fromData, fromSource, err := s.analyzer.EffectiveConfig(args.From, args.FromContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load from config: %w", err)
+	}
+	toData, toSource, err := s.analyzer.EffectiveConfig(args.To, args.ToContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load to config: %w", err)
+	}
+	if args.FromContent != "" {
+		fromSource = "from_content"
+	}
+	if args.ToContent != "" {
+		toSource = "to_content"
+	}
+
+	diff, err := lintconfig.Compare(fromData, toData, fromSource, toSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare configs: %w", err)
+	}
+
+	text := diff.Text()
+	if args.Format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		text = string(data)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []interface{}{
+			mcp.TextContent{
+				Type: "text",
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// handleExplainConfig handles the explain_config tool
+func (s *Server) handleExplainConfig(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	var args struct {
+		Name    string `json:"name"`
+		Content string `json:"content"`
+		Format  string `json:"format"`
+	}
+	if err := parseArguments(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	s.logger.Info("Handling explain_config request", zap.String("name", args.Name))
+
+	data, source, err := s.analyzer.EffectiveConfig(args.Name, args.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	explanation, err := lintconfig.Explain(data, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain config: %w", err)
+	}
+
+	text := explanation.Markdown()
+	if args.Format == "json" {
+		data, err := json.MarshalIndent(explanation, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal explanation: %w", err)
+		}
+		text = string(data)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []interface{}{
+			mcp.TextContent{
+				Type: "text",
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// handleRecommendConfig handles the recommend_config tool invocation
+func (s *Server) handleRecommendConfig(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	var args struct {
+		ProjectDir string `json:"project_dir"`
+		Target     string `json:"target"`
+		Milestones int    `json:"milestones"`
+		Format     string `json:"format"`
+	}
+	if err := parseArguments(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.ProjectDir == "" {
+		return nil, fmt.Errorf("project_dir is required")
+	}
+	if args.Target == "" {
+		args.Target = "strict"
+	}
+
+	s.logger.Info("Handling recommend_config request",
+		zap.String("project", args.ProjectDir),
+		zap.String("target", args.Target))
+
+	data, source, err := s.analyzer.EffectiveConfig("template:"+args.Target, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load target: %w", err)
+	}
+	result, err := s.analyzer.Analyze(context.Background(), &models.AnalysisRequest{
+		ProjectDir: args.ProjectDir,
+		Standard:   args.Target,
+		Format:     "json",
+		AllIssues:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("analysis failed: %w", err)
+	}
+	if err := checkGolangciRuns(result.Linters); err != nil {
+		return nil, err
+	}
+
+	// The other levels are shown next to each threshold for comparison
+	references := make(map[string][]byte)
+	for _, level := range []string{"strict", "standard", "relaxed"} {
+		if level == args.Target {
+			continue
+		}
+		if data, label, err := s.analyzer.EffectiveConfig("template:"+level, ""); err == nil {
+			references[label] = data
+		}
+	}
+
+	recommendation, err := recommend.Recommend(recommend.Input{
+		Project:    args.ProjectDir,
+		Target:     source,
+		Extends:    args.Target,
+		Config:     data,
+		Result:     result,
+		References: references,
+		Milestones: args.Milestones,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend config: %w", err)
+	}
+
+	text := recommendation.Markdown()
+	if args.Format == "json" {
+		data, err := json.MarshalIndent(recommendation, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal recommendation: %w", err)
+		}
+		text = string(data)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []interface{}{
+			mcp.TextContent{
+				Type: "text",
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+// checkGolangciRuns returns an error unless golangci-lint ran to
+// completion, since a recommendation built on partial results would be
+// too loose
+func checkGolangciRuns(runs []models.LinterRun) error {
+	ran := false
+	for _, run := range runs {
+		if run.Name != "golangci-lint" {
+			continue
+		}
+		if run.Status != "ok" {
+			return fmt.Errorf("golangci-lint did not complete (%s): %s", run.Status, run.Error)
+		}
+		ran = true
+	}
+	if !ran {
+		return fmt.Errorf("golangci-lint is not available; recommendations are based on its results")
+	}
+	return nil
+}
+
 // handleManageTemplates handles the manage_templates tool invocation
 func (s *Server) handleManageTemplates(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	s.logger.Info("Handling manage_templates request")
@@ -470,6 +1246,15 @@ func (s *Server) handleManageTemplates(arguments map[string]interface{}) (*mcp.C
 		},
 	}
 
+	// Describe what each template actually enforces
+	for i := range templates {
+		if description, err := s.templateDescription(templates[i].Name); err == nil {
+			templates[i].Description = description
+		} else {
+			s.logger.Warn("Failed to describe template", zap.String("template", templates[i].Name), zap.Error(err))
+		}
+	}
+
 	data, err := json.MarshalIndent(templates, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal templates: %w", err)
@@ -503,16 +1288,106 @@ func (s *Server) handleGenerateReport(arguments map[string]interface{}) (*mcp.Ca
 func (s *Server) handleBatchAnalyze(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	s.logger.Info("Handling batch_analyze request")
 
+	var args struct {
+		Projects []struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+		} `json:"projects"`
+		Standard  string `json:"standard"`
+		Config    string `json:"config"`
+		ConfigRef string `json:"config_ref"`
+		Format    string `json:"format"`
+	}
+	if err := parseArguments(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if len(args.Projects) == 0 {
+		return nil, fmt.Errorf("projects is required")
+	}
+	if args.Format == "" {
+		args.Format = "json"
+	}
+
+	type projectResult struct {
+		Name   string                 `json:"name"`
+		Path   string                 `json:"path"`
+		Result *models.AnalysisResult `json:"result,omitempty"`
+		Error  string                 `json:"error,omitempty"`
+	}
+
+	// A failing project is reported in place rather than failing the batch
+	results := make([]projectResult, 0, len(args.Projects))
+	for _, p := range args.Projects {
+		req := models.AnalysisRequest{
+			ProjectDir: p.Path,
+			Standard:   args.Standard,
+			Config:     args.Config,
+			ConfigRef:  args.ConfigRef,
+			Format:     args.Format,
+		}
+		entry := projectResult{Name: p.Name, Path: p.Path}
+		result, err := s.analyzer.Analyze(context.Background(), &req)
+		if err != nil {
+			s.logger.Warn("Batch analysis failed for project", zap.String("project", p.Name), zap.Error(err))
+			entry.Error = err.Error()
+		} else {
+			entry.Result = result
+		}
+		results = append(results, entry)
+	}
+
+	var content string
+	switch args.Format {
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to format result: %w", err)
+		}
+		content = string(data)
+	case "markdown":
+		var sb strings.Builder
+		for _, r := range results {
+			fmt.Fprintf(&sb, "# %s (%s)\n\n", r.Name, r.Path)
+			if r.Error != "" {
+				fmt.Fprintf(&sb, "**Error**: %s\n\n", r.Error)
+				continue
+			}
+			sb.WriteString(formatMarkdown(r.Result))
+			sb.WriteString("\n")
+		}
+		content = sb.String()
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", args.Format)
+	}
+
 	return &mcp.CallToolResult{
 		Content: []interface{}{
 			mcp.TextContent{
 				Type: "text",
-				Text: `{"message": "Batch analysis coming soon"}`,
+				Text: content,
 			},
 		},
 	}, nil
 }
 
+// templateDescription summarizes a template from its content and the
+// coverage threshold configured for it
+func (s *Server) templateDescription(name string) (string, error) {
+	data, source, err := s.analyzer.EffectiveConfig("template:"+name, "")
+	if err != nil {
+		return "", err
+	}
+	explanation, err := lintconfig.Explain(data, source)
+	if err != nil {
+		return "", err
+	}
+	description := explanation.Summary()
+	if threshold, ok := s.analyzer.Config().Analyzer.Coverage.Thresholds[name]; ok {
+		description += fmt.Sprintf("; coverage threshold %.0f%%", threshold)
+	}
+	return description, nil
+}
+
 // handleHealthCheck handles the health_check tool invocation
 func (s *Server) handleHealthCheck(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	health := models.HealthStatus{
@@ -539,6 +1414,25 @@ func (s *Server) handleHealthCheck(arguments map[string]interface{}) (*mcp.CallT
 	}, nil
 }
 
+// handleListLinters handles the list_linters tool invocation
+func (s *Server) handleListLinters(arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	s.logger.Info("Handling list_linters request")
+
+	data, err := json.MarshalIndent(s.analyzer.ListLinters(context.Background()), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal linters: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []interface{}{
+			mcp.TextContent{
+				Type: "text",
+				Text: string(data),
+			},
+		},
+	}, nil
+}
+
 // Document management handlers
 
 // handleUploadDocument handles document upload
@@ -640,7 +1534,7 @@ func (s *Server) handleDeleteDocument(arguments map[string]interface{}) (*mcp.Ca
 
 // Serve starts the MCP server
 func (s *Server) Serve() error {
-	s.logger.Info("Starting MCP server", zap.String("mode", s.config.Server.Mode))
+	s.logger.Info("Starting MCP server", zap.String("mode", s.analyzer.Config().Server.Mode))
 
 	if err := server.ServeStdio(s.srv); err != nil {
 		return fmt.Errorf("server error: %w", err)
@@ -689,8 +1583,27 @@ func formatMarkdown(result *models.AnalysisResult) string {
 	md += fmt.Sprintf("- Errors: %d\n", result.Summary.ErrorCount)
 	md += fmt.Sprintf("- Warnings: %d\n", result.Summary.WarningCount)
 	md += fmt.Sprintf("- Files Analyzed: %d\n", result.Summary.FilesAnalyzed)
+	if result.Summary.BaselinedCount > 0 {
+		md += fmt.Sprintf("- Baselined: %d\n", result.Summary.BaselinedCount)
+	}
+	if result.Summary.SuppressedCount > 0 {
+		md += fmt.Sprintf("- Suppressed: %d\n", result.Summary.SuppressedCount)
+	}
+	if cov := result.Summary.Coverage; cov != nil {
+		md += fmt.Sprintf("- Coverage: %.1f%% (threshold %.0f%%, %d uncovered functions)\n", cov.Total, cov.Threshold, cov.UncoveredFunctions)
+	}
 	md += fmt.Sprintf("- Duration: %s\n\n", result.Summary.Duration)
 
+	if result.Status != "success" {
+		md += "## Linter Failures\n\n"
+		for _, run := range result.Linters {
+			if run.Status != "ok" {
+				md += fmt.Sprintf("- **%s**: %s (exit code %d) %s\n", run.Name, run.Status, run.ExitCode, run.Error)
+			}
+		}
+		md += "\n"
+	}
+
 	if len(result.Issues) > 0 {
 		md += "## Issues\n\n"
 		for i, issue := range result.Issues {
@@ -701,8 +1614,25 @@ func formatMarkdown(result *models.AnalysisResult) string {
 			md += fmt.Sprintf("### %d. %s\n", i+1, issue.Message)
 			md += fmt.Sprintf("- **File**: %s:%d:%d\n", issue.File, issue.Line, issue.Column)
 			md += fmt.Sprintf("- **Severity**: %s\n", issue.Severity)
-			md += fmt.Sprintf("- **Category**: %s\n", issue.Category)
-			md += fmt.Sprintf("- **Rule**: %s\n\n", issue.Rule)
+			if issue.Subcategory != "" {
+				md += fmt.Sprintf("- **Category**: %s/%s\n", issue.Category, issue.Subcategory)
+			} else {
+				md += fmt.Sprintf("- **Category**: %s\n", issue.Category)
+			}
+			md += fmt.Sprintf("- **Rule**: %s\n", issue.Rule)
+			if v := issue.Vulnerability; v != nil {
+				md += fmt.Sprintf("- **Vulnerable**: %s@%s, fixed in %s\n", v.Module, v.FoundVersion, v.FixedVersion)
+				if len(v.CallStack) > 0 {
+					md += fmt.Sprintf("- **Call stack**: %s\n", strings.Join(v.CallStack, " -> "))
+				}
+			}
+			for _, r := range issue.Related {
+				md += fmt.Sprintf("- **Related**: %s:%d:%d %s\n", r.File, r.Line, r.Column, r.Message)
+			}
+			for _, fix := range issue.Fixes {
+				md += fmt.Sprintf("- **Fix**: %s\n", fix.Message)
+			}
+			md += "\n"
 		}
 	}
 
@@ -836,6 +1766,14 @@ func (s *Server) handleGitConfig(arguments map[string]interface{}) (*mcp.CallToo
 		return nil, fmt.Errorf("invalid parameters: %w", err)
 	}
 
+	if params.Path != "" {
+		resolved, err := s.analyzer.PathGuard().Resolve("git_config "+params.Action, params.Path)
+		if err != nil {
+			return nil, err
+		}
+		params.Path = resolved
+	}
+
 	s.logger.Info("Git config operation",
 		zap.String("action", params.Action),
 		zap.String("path", params.Path))
@@ -876,6 +1814,11 @@ func (s *Server) handleGitConfig(arguments map[string]interface{}) (*mcp.CallToo
 		if err := json.Unmarshal(configJSON, &gitCfg); err != nil {
 			return nil, fmt.Errorf("invalid config format: %w", err)
 		}
+		if gitCfg.Standards != nil {
+			if err := gitCfg.Standards.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid standards: %w", err)
+			}
+		}
 
 		if err := cm.Save(&gitCfg); err != nil {
 			return nil, fmt.Errorf("failed to save git config: %w", err)
@@ -939,7 +1882,12 @@ func (s *Server) handleGitCheck(arguments map[string]interface{}) (*mcp.CallTool
 
 	s.logger.Info("Git check", zap.String("path", params.Path))
 
-	detector := git.NewGitDetector(params.Path)
+	path, err := s.analyzer.PathGuard().Resolve("git_check", params.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	detector := git.NewGitDetector(path)
 	isRepo := detector.IsGitRepository()
 
 	var result struct {
@@ -0,0 +1,139 @@
+// Package pathguard restricts the files and directories clients may ask the
+// server to read or write to a set of allowed root directories.
+package pathguard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Config lists the allowed roots. Paths outside every root are rejected.
+// Roots apply to every client: requests carry no authenticated user that
+// per-user roots could be chosen by.
+type Config struct {
+	AllowedRoots []string `mapstructure:"allowed_roots"`
+}
+
+// AccessError reports a path outside the allowed roots
+type AccessError struct {
+	Path string
+}
+
+// Error implements the error interface
+func (e *AccessError) Error() string {
+	return fmt.Sprintf("access denied: %s is outside the allowed root directories", e.Path)
+}
+
+// Guard checks paths against the allowed roots
+type Guard struct {
+	roots    []string
+	restrict bool
+	logger   *zap.Logger
+}
+
+// New creates a guard from cfg. With no roots configured, every path is
+// allowed unless restrict is set, in which case every path is rejected.
+func New(cfg Config, restrict bool, logger *zap.Logger) (*Guard, error) {
+	g := &Guard{
+		restrict: restrict || len(cfg.AllowedRoots) > 0,
+		logger:   logger,
+	}
+
+	for _, root := range cfg.AllowedRoots {
+		resolved, err := resolve(root)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed root %s: %w", root, err)
+		}
+		g.roots = append(g.roots, resolved)
+	}
+
+	return g, nil
+}
+
+// Restricted reports whether paths are checked at all
+func (g *Guard) Restricted() bool {
+	return g.restrict
+}
+
+// Resolve checks that path lies inside one of the allowed roots after
+// resolving symlinks, and returns the resolved path. Rejections are logged
+// with op, a short description of the attempted access.
+func (g *Guard) Resolve(op, path string) (string, error) {
+	if !g.restrict {
+		return path, nil
+	}
+
+	resolved, err := resolve(path)
+	if err != nil {
+		g.reject(op, path, "", err)
+		return "", &AccessError{Path: path}
+	}
+
+	for _, root := range g.roots {
+		if within(root, resolved) {
+			return resolved, nil
+		}
+	}
+
+	g.reject(op, path, resolved, nil)
+	return "", &AccessError{Path: path}
+}
+
+// reject writes the audit log entry for a rejected access
+func (g *Guard) reject(op, path, resolved string, err error) {
+	fields := []zap.Field{
+		zap.String("operation", op),
+		zap.String("path", path),
+	}
+	if resolved != "" && resolved != path {
+		fields = append(fields, zap.String("resolved", resolved))
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	g.logger.Warn("Rejected path access", fields...)
+}
+
+// resolve returns the absolute path with symlinks evaluated. Paths that do
+// not exist yet, such as files about to be written, are resolved through
+// their closest existing parent.
+func resolve(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	existing, rest := abs, ""
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+
+	real, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(real, rest), nil
+}
+
+// within reports whether path is root or lies below it
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,76 @@
+package pathguard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestGuard_Resolve(t *testing.T) {
+	base := t.TempDir()
+	allowed := filepath.Join(base, "allowed")
+	outside := filepath.Join(base, "outside")
+	for _, dir := range []string{filepath.Join(allowed, "project"), outside} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+	// A symlink inside the root that points out of it
+	escape := filepath.Join(allowed, "escape")
+	if err := os.Symlink(outside, escape); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	guard, err := New(Config{AllowedRoots: []string{allowed}}, false, zap.NewNop())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		allowed bool
+	}{
+		{"root itself", allowed, true},
+		{"directory inside root", filepath.Join(allowed, "project"), true},
+		{"file not created yet", filepath.Join(allowed, "project", "new", "baseline.json"), true},
+		{"outside every root", outside, false},
+		{"dot-dot traversal", filepath.Join(allowed, "project", "..", "..", "outside"), false},
+		{"symlink out of root", filepath.Join(escape, "main.go"), false},
+		{"sibling with root prefix", allowed + "-other", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := guard.Resolve("test", tt.path)
+			if tt.allowed && err != nil {
+				t.Errorf("Resolve(%q) error = %v, want allowed", tt.path, err)
+			}
+			var accessErr *AccessError
+			if !tt.allowed && !errors.As(err, &accessErr) {
+				t.Errorf("Resolve(%q) error = %v, want an AccessError", tt.path, err)
+			}
+		})
+	}
+}
+
+func TestGuard_Unrestricted(t *testing.T) {
+	guard, err := New(Config{}, false, zap.NewNop())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, err := guard.Resolve("test", "/etc"); err != nil || got != "/etc" {
+		t.Errorf("Resolve() = %q, %v, want /etc allowed", got, err)
+	}
+
+	strict, err := New(Config{}, true, zap.NewNop())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := strict.Resolve("test", t.TempDir()); err == nil {
+		t.Errorf("Resolve() with no roots in restricted mode succeeded, want rejection")
+	}
+}
@@ -0,0 +1,146 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"go-standards-mcp-server/pkg/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validSeverities lists the severities a rule may assign
+var validSeverities = map[string]bool{"error": true, "warning": true, "info": true}
+
+// Rule overrides the severity and/or category of matching issues
+type Rule struct {
+	Linter   string `mapstructure:"linter" json:"linter,omitempty"`     // Linter name, matched against the issue source or rule
+	Rule     string `mapstructure:"rule" json:"rule,omitempty"`         // Rule name or glob (e.g. "SA*", "G104")
+	Message  string `mapstructure:"message" json:"message,omitempty"`   // Regular expression matched against the message
+	Severity string `mapstructure:"severity" json:"severity,omitempty"` // error, warning, info
+	Category string `mapstructure:"category" json:"category,omitempty"`
+}
+
+// Validate checks that the rule is well-formed
+func (r Rule) Validate() error {
+	if r.Linter == "" && r.Rule == "" && r.Message == "" {
+		return fmt.Errorf("rule must match on at least one of linter, rule, or message")
+	}
+	if r.Severity == "" && r.Category == "" {
+		return fmt.Errorf("rule must set severity or category")
+	}
+	if r.Severity != "" && !validSeverities[r.Severity] {
+		return fmt.Errorf("invalid severity: %s (must be error, warning, or info)", r.Severity)
+	}
+	if r.Rule != "" {
+		if _, err := path.Match(r.Rule, ""); err != nil {
+			return fmt.Errorf("invalid rule pattern %q: %w", r.Rule, err)
+		}
+	}
+	if r.Message != "" {
+		if _, err := regexp.Compile(r.Message); err != nil {
+			return fmt.Errorf("invalid message pattern %q: %w", r.Message, err)
+		}
+	}
+	return nil
+}
+
+// compiledRule is a Rule with its message pattern compiled
+type compiledRule struct {
+	rule    Rule
+	message *regexp.Regexp
+}
+
+// matches reports whether the rule applies to the issue
+func (r *compiledRule) matches(issue models.Issue) bool {
+	if r.rule.Linter != "" &&
+		!strings.EqualFold(r.rule.Linter, issue.Source) &&
+		!strings.EqualFold(r.rule.Linter, issue.Rule) {
+		return false
+	}
+	if r.rule.Rule != "" {
+		if ok, _ := path.Match(r.rule.Rule, issue.Rule); !ok {
+			return false
+		}
+	}
+	if r.message != nil && !r.message.MatchString(issue.Message) {
+		return false
+	}
+	return true
+}
+
+// Policy maps issues to severities and categories. Rules are evaluated in
+// order and the first matching rule wins.
+type Policy struct {
+	rules []compiledRule
+}
+
+// New compiles the given rules into a Policy
+func New(rules ...[]Rule) (*Policy, error) {
+	p := &Policy{}
+	for _, set := range rules {
+		for i, rule := range set {
+			if err := rule.Validate(); err != nil {
+				return nil, fmt.Errorf("policy rule %d: %w", i+1, err)
+			}
+
+			compiled := compiledRule{rule: rule}
+			if rule.Message != "" {
+				compiled.message = regexp.MustCompile(rule.Message)
+			}
+			p.rules = append(p.rules, compiled)
+		}
+	}
+	return p, nil
+}
+
+// Parse reads the rules of a policy file, a YAML document with a rules
+// list, and checks that they are well-formed
+func Parse(data []byte) ([]Rule, error) {
+	var file struct {
+		Rules []Rule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if _, err := New(file.Rules); err != nil {
+		return nil, err
+	}
+	return file.Rules, nil
+}
+
+// Empty reports whether the policy has no rules
+func (p *Policy) Empty() bool {
+	return p == nil || len(p.rules) == 0
+}
+
+// Apply rewrites severities and categories in place and returns the number
+// of issues changed
+func (p *Policy) Apply(issues []models.Issue) int {
+	if p.Empty() {
+		return 0
+	}
+
+	changed := 0
+	for i := range issues {
+		for _, r := range p.rules {
+			if !r.matches(issues[i]) {
+				continue
+			}
+			before := issues[i]
+			if r.rule.Severity != "" {
+				issues[i].Severity = r.rule.Severity
+			}
+			if r.rule.Category != "" {
+				issues[i].Category = r.rule.Category
+			}
+			if issues[i].Severity != before.Severity || issues[i].Category != before.Category {
+				changed++
+			}
+			break
+		}
+	}
+	return changed
+}
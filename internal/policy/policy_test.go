@@ -0,0 +1,180 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"go-standards-mcp-server/pkg/models"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []Rule
+		wantErr string
+	}{
+		{"empty policy", nil, ""},
+		{"linter rule", []Rule{{Linter: "gosec", Severity: "error"}}, ""},
+		{"category only", []Rule{{Rule: "SA*", Category: "logic"}}, ""},
+		{"no matcher", []Rule{{Severity: "error"}}, "must match on at least one"},
+		{"no override", []Rule{{Linter: "gosec"}}, "must set severity or category"},
+		{"invalid severity", []Rule{{Linter: "gosec", Severity: "fatal"}}, "invalid severity: fatal"},
+		{"invalid rule glob", []Rule{{Rule: "SA[", Severity: "error"}}, "invalid rule pattern"},
+		{"invalid message regex", []Rule{{Message: "(unclosed", Severity: "error"}}, "invalid message pattern"},
+		{"error names the rule", []Rule{{Linter: "gosec", Severity: "error"}, {Linter: "misspell"}}, "policy rule 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.rules)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("New() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	gosec := models.Issue{Source: "gosec", Rule: "G104", Message: "Errors unhandled", Severity: "warning", Category: "security"}
+	staticcheck := models.Issue{Source: "staticcheck", Rule: "SA4006", Message: "value of x is never used", Severity: "warning", Category: "logic"}
+	golangci := models.Issue{Source: "golangci-lint", Rule: "misspell", Message: "`recieve` is a misspelling of `receive`", Severity: "warning", Category: "style"}
+
+	tests := []struct {
+		name         string
+		rules        [][]Rule
+		issue        models.Issue
+		wantSeverity string
+		wantCategory string
+	}{
+		{
+			name:         "linter matches the source",
+			rules:        [][]Rule{{{Linter: "gosec", Severity: "error"}}},
+			issue:        gosec,
+			wantSeverity: "error",
+			wantCategory: "security",
+		},
+		{
+			name:         "linter matches the rule of golangci-lint issues, ignoring case",
+			rules:        [][]Rule{{{Linter: "MISSPELL", Severity: "info"}}},
+			issue:        golangci,
+			wantSeverity: "info",
+			wantCategory: "style",
+		},
+		{
+			name:         "other linter",
+			rules:        [][]Rule{{{Linter: "gosec", Severity: "error"}}},
+			issue:        staticcheck,
+			wantSeverity: "warning",
+			wantCategory: "logic",
+		},
+		{
+			name:         "rule glob",
+			rules:        [][]Rule{{{Rule: "SA4*", Severity: "error", Category: "bug"}}},
+			issue:        staticcheck,
+			wantSeverity: "error",
+			wantCategory: "bug",
+		},
+		{
+			name:         "rule glob does not match",
+			rules:        [][]Rule{{{Rule: "SA1*", Severity: "error"}}},
+			issue:        staticcheck,
+			wantSeverity: "warning",
+			wantCategory: "logic",
+		},
+		{
+			name:         "message regex",
+			rules:        [][]Rule{{{Message: `^Errors unhandled`, Severity: "info"}}},
+			issue:        gosec,
+			wantSeverity: "info",
+			wantCategory: "security",
+		},
+		{
+			name:         "all matchers must match",
+			rules:        [][]Rule{{{Linter: "gosec", Message: "never used", Severity: "info"}}},
+			issue:        gosec,
+			wantSeverity: "warning",
+			wantCategory: "security",
+		},
+		{
+			name: "first match wins",
+			rules: [][]Rule{{
+				{Rule: "G104", Severity: "info"},
+				{Linter: "gosec", Severity: "error", Category: "audit"},
+			}},
+			issue:        gosec,
+			wantSeverity: "info",
+			wantCategory: "security",
+		},
+		{
+			name: "earlier rule sets win over later ones",
+			rules: [][]Rule{
+				{{Linter: "gosec", Severity: "info"}},  // project
+				{{Linter: "gosec", Severity: "error"}}, // standard
+				{{Rule: "G*", Category: "audit"}},      // global
+			},
+			issue:        gosec,
+			wantSeverity: "info",
+			wantCategory: "security",
+		},
+		{
+			name: "later rule sets apply when earlier ones do not match",
+			rules: [][]Rule{
+				{{Linter: "misspell", Severity: "info"}}, // project
+				nil,                                      // standard
+				{{Linter: "gosec", Severity: "error"}},   // global
+			},
+			issue:        gosec,
+			wantSeverity: "error",
+			wantCategory: "security",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.rules...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			issues := []models.Issue{tt.issue}
+			p.Apply(issues)
+			if issues[0].Severity != tt.wantSeverity {
+				t.Errorf("severity = %s, want %s", issues[0].Severity, tt.wantSeverity)
+			}
+			if issues[0].Category != tt.wantCategory {
+				t.Errorf("category = %s, want %s", issues[0].Category, tt.wantCategory)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{"rules", "rules:\n  - linter: gosec\n    severity: error\n  - rule: SA*\n    category: logic\n", 2, false},
+		{"empty file", "", 0, false},
+		{"invalid rule", "rules:\n  - linter: gosec\n    severity: fatal\n", 0, true},
+		{"not YAML", "rules: [", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(rules) != tt.want {
+				t.Errorf("Parse() = %d rules, want %d", len(rules), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package recommend
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown formats the recommendation as a report with the issue counts
+// and the config of each milestone
+func (r *Recommendation) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Recommended lint config for %s\n\n", r.Project)
+	fmt.Fprintf(&sb, "With %s the project has %s today. ", r.Target, countIssues(r.Issues))
+	fmt.Fprintf(&sb, "The plan starts at %d and reaches the target in %d milestones.\n",
+		r.Milestones[0].Issues, len(r.Milestones)-1)
+
+	sb.WriteString("\n## Linters\n\n")
+	sb.WriteString("| Linter | Issues | Files | Phased in |\n|---|---|---|---|\n")
+	var clean []string
+	for _, c := range r.Linters {
+		if c.Issues == 0 {
+			clean = append(clean, c.Name)
+			continue
+		}
+		fmt.Fprintf(&sb, "| %s | %d | %d | %s |\n", c.Name, c.Issues, c.Files, phase(c))
+	}
+	if len(clean) > 0 {
+		fmt.Fprintf(&sb, "\nNo issues, enabled from the start: %s\n", strings.Join(clean, ", "))
+	}
+
+	if len(r.Thresholds) > 0 {
+		sb.WriteString("\n## Thresholds\n")
+		for _, t := range r.Thresholds {
+			fmt.Fprintf(&sb, "\n### %s\n\n", t.Setting)
+			if t.Worst > 0 {
+				fmt.Fprintf(&sb, "Target %d; the worst value measured is %d.\n\n", t.Target, t.Worst)
+			} else {
+				fmt.Fprintf(&sb, "Target %d; nothing exceeds it today.\n\n", t.Target)
+			}
+			sb.WriteString("| Value | Issues | Used by |\n|---|---|---|\n")
+			for _, o := range t.Options {
+				fmt.Fprintf(&sb, "| %d | %d | %s |\n", o.Value, o.Issues, strings.Join(o.Labels, ", "))
+			}
+		}
+	}
+
+	sb.WriteString("\n## Ratchet Plan\n")
+	for _, m := range r.Milestones {
+		switch {
+		case m.Number == 0:
+			fmt.Fprintf(&sb, "\n### Start: %s\n\n", countIssues(m.Issues))
+		case m.Number == len(r.Milestones)-1:
+			fmt.Fprintf(&sb, "\n### Milestone %d (target): %s\n\n", m.Number, countIssues(m.Issues))
+		default:
+			fmt.Fprintf(&sb, "\n### Milestone %d: %s\n\n", m.Number, countIssues(m.Issues))
+		}
+		if len(m.Enables) > 0 {
+			fmt.Fprintf(&sb, "Enables %s.\n\n", strings.Join(m.Enables, ", "))
+		}
+		fmt.Fprintf(&sb, "```yaml\n%s```\n", m.Config)
+	}
+
+	for _, note := range r.Notes {
+		fmt.Fprintf(&sb, "\nNote: %s\n", note)
+	}
+	return sb.String()
+}
+
+// countIssues formats a number of issues
+func countIssues(n int) string {
+	if n == 1 {
+		return "1 issue"
+	}
+	return fmt.Sprintf("%d issues", n)
+}
+
+// phase says when a linter with issues is phased in
+func phase(c LinterCount) string {
+	switch c.Kind {
+	case KindScheduled:
+		return fmt.Sprintf("milestone %d", c.Milestone)
+	case KindThreshold:
+		return "start, threshold tightened over milestones"
+	case KindFormatter:
+		return "start, fix with golangci-lint fmt"
+	default:
+		return "always"
+	}
+}
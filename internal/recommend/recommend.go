@@ -0,0 +1,505 @@
+// Package recommend proposes a lint config tuned to a project's current
+// issues, and a ratchet plan that tightens it over milestones until it
+// reaches a target template
+package recommend
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"go-standards-mcp-server/internal/lintconfig"
+	"go-standards-mcp-server/pkg/models"
+)
+
+// DefaultMilestones is the number of milestones after the start when none
+// is requested
+const DefaultMilestones = 3
+
+// maxMilestones limits the length of a plan
+const maxMilestones = 10
+
+// golangciSource is the issue source of findings the lint config controls
+const golangciSource = "golangci-lint"
+
+// measure reads the value a threshold setting is compared with from the
+// messages of its linter
+type measure struct {
+	setting   string         // Key below linters.settings, e.g. gocyclo.min-complexity
+	pattern   *regexp.Regexp // The first group is the measured value
+	inclusive bool           // Values equal to the setting are flagged too
+}
+
+// measures lists the thresholds whose issue counts can be worked out from
+// a single analysis
+var measures = []measure{
+	{"cyclop.max-complexity", regexp.MustCompile(`calculated cyclomatic complexity for function \S+ is (\d+)`), false},
+	{"funlen.lines", regexp.MustCompile(`is too long \((\d+) >`), false},
+	{"funlen.statements", regexp.MustCompile(`has too many statements \((\d+) >`), false},
+	{"gocognit.min-complexity", regexp.MustCompile(`cognitive complexity (\d+) of`), false},
+	{"goconst.min-occurrences", regexp.MustCompile(`has (\d+) occurrences`), true},
+	{"gocyclo.min-complexity", regexp.MustCompile(`cyclomatic complexity (\d+) of`), false},
+	{"lll.line-length", regexp.MustCompile(`(?i)line is (\d+) characters`), false},
+	{"nestif.min-complexity", regexp.MustCompile(`complex nested blocks \(complexity: (\d+)\)`), true},
+}
+
+// Input is what a recommendation is worked out from
+type Input struct {
+	Project    string
+	Target     string                 // Source label of the target config, e.g. template:strict
+	Extends    string                 // Name the milestone configs extend, e.g. strict
+	Config     []byte                 // The target config, resolved
+	Result     *models.AnalysisResult // Analysis of the project with the target config
+	References map[string][]byte      // Other configs whose thresholds are shown for comparison, by label
+	Milestones int                    // Milestones after the start; DefaultMilestones if 0
+}
+
+// Recommendation is a tuned starting config and the plan for tightening it
+type Recommendation struct {
+	Project    string         `json:"project"`
+	Target     string         `json:"target"`
+	Issues     int            `json:"issues"` // Issues the target config reports today
+	Linters    []LinterCount  `json:"linters"`
+	Thresholds []Threshold    `json:"thresholds,omitempty"`
+	Milestones []Milestone    `json:"milestones"`
+	Unmanaged  map[string]int `json:"unmanaged,omitempty"` // Issues from tools the lint config does not control, by tool
+	Notes      []string       `json:"notes,omitempty"`
+}
+
+// LinterCount is the number of issues an enabled linter reports today
+type LinterCount struct {
+	Name      string `json:"name"`
+	Issues    int    `json:"issues"`
+	Files     int    `json:"files"`
+	Kind      string `json:"kind"`      // scheduled, threshold, formatter, or fixed
+	Milestone int    `json:"milestone"` // First milestone that runs it in full; 0 is the start
+}
+
+// Linter kinds, which decide how a linter is phased in
+const (
+	KindScheduled = "scheduled" // Disabled until its milestone
+	KindThreshold = "threshold" // Enabled from the start with a loosened threshold
+	KindFormatter = "formatter" // Enabled from the start; its issues are fixed automatically
+	KindFixed     = "fixed"     // Cannot be turned off, such as typecheck
+)
+
+// Threshold is a numeric setting with the issues each value would produce
+type Threshold struct {
+	Setting string   `json:"setting"`
+	Target  int      `json:"target"` // Value in the target config
+	Worst   int      `json:"worst"`  // Highest value measured in the project
+	Options []Option `json:"options"`
+}
+
+// Option is a value of a threshold setting
+type Option struct {
+	Value  int      `json:"value"`
+	Issues int      `json:"issues"`
+	Labels []string `json:"labels,omitempty"` // Configs and milestones that use the value
+}
+
+// Milestone is one step of the ratchet plan
+type Milestone struct {
+	Number   int            `json:"number"` // 0 is the starting config
+	Issues   int            `json:"issues"` // Issues the config reports today
+	Enables  []string       `json:"enables,omitempty"`
+	Settings map[string]int `json:"settings,omitempty"` // Thresholds that differ from the target
+	Config   string         `json:"config"`
+}
+
+// Recommend works out a tuned config and ratchet plan from an analysis of
+// the project with the target config
+func Recommend(in Input) (*Recommendation, error) {
+	steps := in.Milestones
+	if steps == 0 {
+		steps = DefaultMilestones
+	}
+	if steps < 1 || steps > maxMilestones {
+		return nil, fmt.Errorf("milestones must be between 1 and %d", maxMilestones)
+	}
+
+	target, err := lintconfig.Explain(in.Config, in.Target)
+	if err != nil {
+		return nil, err
+	}
+	version, err := lintconfig.Version(in.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recommendation{Project: in.Project, Target: in.Target}
+	enabled := make(map[string]string)
+	for _, l := range target.Linters {
+		enabled[l.Name] = KindScheduled
+	}
+	for _, f := range target.Formatters {
+		enabled[f.Name] = KindFormatter
+	}
+
+	// Thresholds of enabled linters are measured from their messages
+	targetValues := thresholdValues(target)
+	var active []measure
+	for _, m := range measures {
+		linter, _, _ := strings.Cut(m.setting, ".")
+		if _, ok := targetValues[m.setting]; ok && enabled[linter] != "" {
+			active = append(active, m)
+			enabled[linter] = KindThreshold
+		}
+	}
+
+	counts := make(map[string]*LinterCount)
+	files := make(map[string]map[string]bool)
+	measured := make(map[string][]int)
+	unmeasured := 0
+	issues := append(append([]models.Issue(nil), in.Result.Issues...), in.Result.Baselined...)
+	for _, issue := range issues {
+		// Standalone tools that golangci-lint also runs, such as gosec,
+		// count towards the linter of the same name
+		name := issue.Source
+		if issue.Source == golangciSource {
+			name = issue.Rule
+		} else if enabled[name] == "" {
+			if r.Unmanaged == nil {
+				r.Unmanaged = make(map[string]int)
+			}
+			r.Unmanaged[issue.Source]++
+			continue
+		}
+		c, ok := counts[name]
+		if !ok {
+			kind := enabled[name]
+			if kind == "" {
+				kind = KindFixed
+			}
+			c = &LinterCount{Name: name, Kind: kind}
+			counts[name] = c
+			files[name] = make(map[string]bool)
+		}
+		c.Issues++
+		files[name][issue.File] = true
+		r.Issues++
+
+		if c.Kind == KindThreshold {
+			if setting, value, ok := measureIssue(active, name, issue.Message); ok {
+				measured[setting] = append(measured[setting], value)
+			} else {
+				unmeasured++
+			}
+		}
+	}
+	for name := range enabled {
+		if counts[name] == nil {
+			counts[name] = &LinterCount{Name: name, Kind: enabled[name]}
+		}
+	}
+
+	r.scheduleLinters(counts, files, steps)
+	r.planThresholds(active, targetValues, measured, in.References, steps)
+	if err := r.buildMilestones(in.Extends, version, unmeasured, steps); err != nil {
+		return nil, err
+	}
+	r.addNotes(unmeasured)
+	return r, nil
+}
+
+// thresholdValues returns the numeric threshold settings of an explained
+// config, keyed like measure.setting
+func thresholdValues(e *lintconfig.Explanation) map[string]int {
+	values := make(map[string]int)
+	for _, s := range e.Settings {
+		if s.Linter == "" {
+			continue
+		}
+		if v, err := strconv.Atoi(s.Value); err == nil {
+			values[s.Linter+"."+s.Key] = v
+		}
+	}
+	return values
+}
+
+// measureIssue returns the threshold setting an issue of linter breaks and
+// the value measured
+func measureIssue(active []measure, linter, message string) (string, int, bool) {
+	for _, m := range active {
+		if !strings.HasPrefix(m.setting, linter+".") {
+			continue
+		}
+		if match := m.pattern.FindStringSubmatch(message); match != nil {
+			value, err := strconv.Atoi(match[1])
+			if err == nil {
+				return m.setting, value, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// scheduleLinters phases in the linters that report issues, those with the
+// fewest first, so that each milestone fixes a similar share of them
+func (r *Recommendation) scheduleLinters(counts map[string]*LinterCount, files map[string]map[string]bool, steps int) {
+	var scheduled []*LinterCount
+	total := 0
+	for name, c := range counts {
+		c.Files = len(files[name])
+		if c.Kind == KindScheduled && c.Issues > 0 {
+			scheduled = append(scheduled, c)
+			total += c.Issues
+		}
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		if scheduled[i].Issues != scheduled[j].Issues {
+			return scheduled[i].Issues < scheduled[j].Issues
+		}
+		return scheduled[i].Name < scheduled[j].Name
+	})
+
+	cumulative := 0
+	for _, c := range scheduled {
+		cumulative += c.Issues
+		c.Milestone = max(1, int(math.Ceil(float64(cumulative*steps)/float64(total))))
+	}
+
+	for _, c := range counts {
+		r.Linters = append(r.Linters, *c)
+	}
+	sort.Slice(r.Linters, func(i, j int) bool {
+		if r.Linters[i].Issues != r.Linters[j].Issues {
+			return r.Linters[i].Issues > r.Linters[j].Issues
+		}
+		return r.Linters[i].Name < r.Linters[j].Name
+	})
+}
+
+// planThresholds loosens each measured threshold at the start so that it
+// reports nothing, and tightens it in equal steps to the target value
+func (r *Recommendation) planThresholds(active []measure, targetValues map[string]int, measured map[string][]int, references map[string][]byte, steps int) {
+	referenceValues := make(map[string]map[string]int)
+	for label, data := range references {
+		if e, err := lintconfig.Explain(data, label); err == nil {
+			referenceValues[label] = thresholdValues(e)
+		}
+	}
+
+	for _, m := range active {
+		values := measured[m.setting]
+		t := Threshold{Setting: m.setting, Target: targetValues[m.setting]}
+		for _, v := range values {
+			t.Worst = max(t.Worst, v)
+		}
+
+		labels := make(map[int][]string)
+		start := max(t.Target, m.clean(values))
+		for i := 0; i <= steps; i++ {
+			value := start - int(math.Round(float64((start-t.Target)*i)/float64(steps)))
+			labels[value] = append(labels[value], milestoneLabel(i))
+		}
+		labels[t.Target] = append(labels[t.Target], "target")
+		for _, label := range sortedKeys(referenceValues) {
+			if v, ok := referenceValues[label][m.setting]; ok {
+				labels[v] = append(labels[v], label)
+			}
+		}
+
+		for _, value := range sortedKeys(labels) {
+			t.Options = append(t.Options, Option{Value: value, Issues: m.count(values, value), Labels: labels[value]})
+		}
+		sort.Slice(t.Options, func(i, j int) bool { return t.Options[i].Value > t.Options[j].Value })
+		r.Thresholds = append(r.Thresholds, t)
+	}
+}
+
+// clean returns the lowest value of the setting that reports none of the
+// measured values
+func (m measure) clean(values []int) int {
+	lowest := 0
+	for _, v := range values {
+		if m.inclusive {
+			v++
+		}
+		lowest = max(lowest, v)
+	}
+	return lowest
+}
+
+// count returns how many of the measured values a setting would report
+func (m measure) count(values []int, setting int) int {
+	n := 0
+	for _, v := range values {
+		if v > setting || (m.inclusive && v == setting) {
+			n++
+		}
+	}
+	return n
+}
+
+// milestoneLabel names a milestone in threshold options
+func milestoneLabel(i int) string {
+	if i == 0 {
+		return "start"
+	}
+	return fmt.Sprintf("milestone %d", i)
+}
+
+// buildMilestones writes the config of each milestone as a config that
+// extends the target and only lists what it relaxes
+func (r *Recommendation) buildMilestones(extends string, version, unmeasured, steps int) error {
+	fixed := unmeasured
+	for _, c := range r.Linters {
+		if c.Kind == KindFormatter || c.Kind == KindFixed {
+			fixed += c.Issues
+		}
+	}
+
+	for i := 0; i <= steps; i++ {
+		m := Milestone{Number: i, Issues: fixed}
+		var disabled []string
+		for _, c := range r.Linters {
+			if c.Kind != KindScheduled {
+				continue
+			}
+			switch {
+			case c.Milestone == i && i > 0:
+				m.Enables = append(m.Enables, c.Name)
+				m.Issues += c.Issues
+			case c.Milestone > i:
+				disabled = append(disabled, c.Name)
+			default:
+				m.Issues += c.Issues
+			}
+		}
+		for _, t := range r.Thresholds {
+			value := t.Target
+			for _, o := range t.Options {
+				if containsLabel(o.Labels, milestoneLabel(i)) {
+					value = o.Value
+					m.Issues += o.Issues
+					break
+				}
+			}
+			if value != t.Target {
+				if m.Settings == nil {
+					m.Settings = make(map[string]int)
+				}
+				m.Settings[t.Setting] = value
+			}
+		}
+		sort.Strings(m.Enables)
+		sort.Strings(disabled)
+
+		config, err := milestoneConfig(extends, version, disabled, m.Settings)
+		if err != nil {
+			return err
+		}
+		m.Config = config
+		r.Milestones = append(r.Milestones, m)
+	}
+	return nil
+}
+
+// milestoneConfig writes a config that extends the target, disables the
+// linters not yet phased in and loosens thresholds, in the target's layout
+func milestoneConfig(extends string, version int, disabled []string, settings map[string]int) (string, error) {
+	grouped := make(map[string]map[string]int)
+	for setting, value := range settings {
+		linter, key, _ := strings.Cut(setting, ".")
+		if grouped[linter] == nil {
+			grouped[linter] = make(map[string]int)
+		}
+		grouped[linter][key] = value
+	}
+
+	var config struct {
+		Version string `yaml:"version,omitempty"`
+		Extends string `yaml:"extends"`
+		Linters struct {
+			Disable  []string                  `yaml:"disable,omitempty"`
+			Settings map[string]map[string]int `yaml:"settings,omitempty"`
+		} `yaml:"linters,omitempty"`
+		LintersSettings map[string]map[string]int `yaml:"linters-settings,omitempty"`
+	}
+	config.Extends = extends
+	config.Linters.Disable = disabled
+	if version >= 2 {
+		config.Version = "2"
+		config.Linters.Settings = grouped
+	} else if len(grouped) > 0 {
+		config.LintersSettings = grouped
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&config); err != nil {
+		return "", fmt.Errorf("failed to write milestone config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to write milestone config: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// addNotes explains issues the plan cannot phase in
+func (r *Recommendation) addNotes(unmeasured int) {
+	var formatters, fixed []string
+	formatted, broken := 0, 0
+	for _, c := range r.Linters {
+		if c.Issues == 0 {
+			continue
+		}
+		switch c.Kind {
+		case KindFormatter:
+			formatters = append(formatters, c.Name)
+			formatted += c.Issues
+		case KindFixed:
+			fixed = append(fixed, c.Name)
+			broken += c.Issues
+		}
+	}
+	if formatted > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Formatting (%s): %s, counted in every milestone; run golangci-lint fmt before adopting the start config", strings.Join(formatters, ", "), countIssues(formatted)))
+	}
+	if broken > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("%s: %s that the config cannot turn off, counted in every milestone", strings.Join(fixed, ", "), countIssues(broken)))
+	}
+	if unmeasured > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Thresholds: %s whose measured value could not be read from the message, counted in every milestone", countIssues(unmeasured)))
+	}
+	if len(r.Unmanaged) > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Tools the lint config does not control (%s): %s, not part of the plan", strings.Join(sortedKeys(r.Unmanaged), ", "), countIssues(sum(r.Unmanaged))))
+	}
+}
+
+// containsLabel reports whether labels contains label
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// sum adds up the values of a map
+func sum(m map[string]int) int {
+	total := 0
+	for _, v := range m {
+		total += v
+	}
+	return total
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[K int | string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
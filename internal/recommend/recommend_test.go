@@ -0,0 +1,132 @@
+package recommend
+
+import (
+	"reflect"
+	"testing"
+
+	"go-standards-mcp-server/internal/lintconfig"
+	"go-standards-mcp-server/pkg/models"
+)
+
+const target = `linters:
+  enable: [gofmt, errcheck, gosec, revive, gocyclo, lll]
+linters-settings:
+  gocyclo:
+    min-complexity: 5
+  lll:
+    line-length: 100
+`
+
+func TestRecommend(t *testing.T) {
+	issue := func(source, rule, file, message string) models.Issue {
+		return models.Issue{Source: source, Rule: rule, File: file, Message: message}
+	}
+	result := &models.AnalysisResult{
+		Issues: []models.Issue{
+			issue("golangci-lint", "gocyclo", "a.go", "cyclomatic complexity 31 of func `handle` is high (> 5)"),
+			issue("golangci-lint", "gocyclo", "a.go", "cyclomatic complexity 12 of func `parse` is high (> 5)"),
+			issue("golangci-lint", "gocyclo", "b.go", "cyclomatic complexity 8 of func `run` is high (> 5)"),
+			issue("golangci-lint", "lll", "a.go", "The line is 140 characters long, which exceeds the maximum of 100 characters."),
+			issue("golangci-lint", "lll", "b.go", "The line is 105 characters long, which exceeds the maximum of 100 characters."),
+			issue("gosec", "G304", "a.go", "Potential file inclusion via variable"),
+			issue("golangci-lint", "errcheck", "a.go", "Error return value is not checked"),
+			issue("golangci-lint", "errcheck", "b.go", "Error return value is not checked"),
+			issue("golangci-lint", "gofmt", "c.go", "File is not properly formatted"),
+			issue("golangci-lint", "typecheck", "c.go", "undefined: foo"),
+			issue("govulncheck", "GO-2024-0001", "go.mod", "vulnerable module"),
+		},
+	}
+	for i := 0; i < 5; i++ {
+		result.Baselined = append(result.Baselined, issue("golangci-lint", "revive", "d.go", "exported function should have comment"))
+	}
+
+	r, err := Recommend(Input{
+		Project:    "/src/legacy",
+		Target:     "template:strict",
+		Extends:    "strict",
+		Config:     []byte(target),
+		Result:     result,
+		References: map[string][]byte{"template:relaxed": []byte("linters:\n  enable: [gocyclo]\nlinters-settings:\n  gocyclo:\n    min-complexity: 15\n")},
+		Milestones: 2,
+	})
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+
+	if r.Issues != 15 || !reflect.DeepEqual(r.Unmanaged, map[string]int{"govulncheck": 1}) {
+		t.Errorf("issues = %d, unmanaged = %v; want 15 and govulncheck: 1", r.Issues, r.Unmanaged)
+	}
+
+	phases := make(map[string]string)
+	for _, c := range r.Linters {
+		phases[c.Name] = phase(c)
+	}
+	wantPhases := map[string]string{
+		"gosec":     "milestone 1",
+		"errcheck":  "milestone 1",
+		"revive":    "milestone 2",
+		"gocyclo":   "start, threshold tightened over milestones",
+		"gofmt":     "start, fix with golangci-lint fmt",
+		"typecheck": "always",
+	}
+	for name, want := range wantPhases {
+		if phases[name] != want {
+			t.Errorf("%s phased in at %q, want %q", name, phases[name], want)
+		}
+	}
+
+	wantOptions := []Option{
+		{Value: 31, Issues: 0, Labels: []string{"start"}},
+		{Value: 18, Issues: 1, Labels: []string{"milestone 1"}},
+		{Value: 15, Issues: 1, Labels: []string{"template:relaxed"}},
+		{Value: 5, Issues: 3, Labels: []string{"milestone 2", "target"}},
+	}
+	if len(r.Thresholds) != 2 || r.Thresholds[0].Setting != "gocyclo.min-complexity" {
+		t.Fatalf("thresholds = %+v, want gocyclo and lll", r.Thresholds)
+	}
+	if !reflect.DeepEqual(r.Thresholds[0].Options, wantOptions) {
+		t.Errorf("gocyclo options = %+v, want %+v", r.Thresholds[0].Options, wantOptions)
+	}
+
+	var issues []int
+	for _, m := range r.Milestones {
+		issues = append(issues, m.Issues)
+	}
+	if !reflect.DeepEqual(issues, []int{2, 7, 15}) {
+		t.Errorf("milestone issues = %v, want [2 7 15]", issues)
+	}
+
+	wantStart := `extends: strict
+linters:
+  disable:
+    - errcheck
+    - gosec
+    - revive
+linters-settings:
+  gocyclo:
+    min-complexity: 31
+  lll:
+    line-length: 140
+`
+	if r.Milestones[0].Config != wantStart {
+		t.Errorf("start config =\n%s\nwant\n%s", r.Milestones[0].Config, wantStart)
+	}
+
+	// Each milestone is a valid config, and the last one is the target
+	load := func(name string) ([]byte, string, error) { return []byte(target), "template:" + name, nil }
+	for _, m := range r.Milestones {
+		resolution, err := lintconfig.Resolve([]byte(m.Config), "milestone", load)
+		if err != nil {
+			t.Fatalf("milestone %d: %v", m.Number, err)
+		}
+		if v := lintconfig.Validate(resolution.Content); !v.Valid {
+			t.Errorf("milestone %d is invalid: %v", m.Number, v.Errors)
+		}
+		if m.Number == len(r.Milestones)-1 {
+			diff, err := lintconfig.Compare([]byte(target), resolution.Content, "target", "last")
+			if err != nil || diff.Verdict != "identical" {
+				t.Errorf("last milestone differs from the target: %+v, %v", diff, err)
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"go-standards-mcp-server/internal/converter"
+	"go-standards-mcp-server/internal/lintconfig"
 	"go-standards-mcp-server/internal/parser"
 	"go-standards-mcp-server/internal/storage"
 	"go.uber.org/zap"
@@ -29,7 +30,7 @@ func NewDocumentService(logger *zap.Logger) (*DocumentService, error) {
 		return nil, fmt.Errorf("failed to initialize document storage: %w", err)
 	}
 
-	cfgStore, err := storage.NewConfigStorage("./configs/custom")
+	cfgStore, err := storage.NewConfigStorage(storage.DefaultConfigDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize config storage: %w", err)
 	}
@@ -53,13 +54,15 @@ type UploadDocumentRequest struct {
 
 // UploadDocumentResponse represents the response of document upload
 type UploadDocumentResponse struct {
-	DocumentID        string   `json:"document_id"`
-	ConfigName        string   `json:"config_name"`
-	Summary           string   `json:"summary"`
-	ExtractedRules    []string `json:"extracted_rules"`
-	Confidence        float64  `json:"confidence"`
-	Success           bool     `json:"success"`
-	Message           string   `json:"message"`
+	DocumentID       string   `json:"document_id"`
+	ConfigName       string   `json:"config_name"`
+	Summary          string   `json:"summary"`
+	ExtractedRules   []string `json:"extracted_rules"`
+	Confidence       float64  `json:"confidence"`
+	Valid            bool     `json:"valid"` // Whether the generated config passed validation
+	ValidationErrors []string `json:"validation_errors,omitempty"`
+	Success          bool     `json:"success"`
+	Message          string   `json:"message"`
 }
 
 // UploadDocument handles the complete document upload and conversion workflow
@@ -117,8 +120,17 @@ func (s *DocumentService) UploadDocument(ctx context.Context, req *UploadDocumen
 		return nil, fmt.Errorf("failed to save config: %w", err)
 	}
 
-	// 7. 将配置注册到配置管理系统
-	if err := s.cfgStore.Save(req.Name, convResult.Config, req.Description); err != nil {
+	// 7. 校验生成的配置
+	validation := lintconfig.Validate([]byte(convResult.Config))
+	if !validation.Valid {
+		s.logger.Warn("Generated config is invalid",
+			zap.String("config_name", req.Name),
+			zap.Strings("errors", validation.Errors))
+	}
+
+	// 8. 将配置注册到配置管理系统
+	change := storage.Change{Note: "generated from document " + req.FileName}
+	if err := s.cfgStore.Save(req.Name, convResult.Config, req.Description, validation, change); err != nil {
 		s.logger.Warn("Failed to register config", zap.Error(err))
 		// 不中断流程，继续返回结果
 	}
@@ -129,13 +141,15 @@ func (s *DocumentService) UploadDocument(ctx context.Context, req *UploadDocumen
 		zap.Float64("confidence", convResult.Confidence))
 
 	return &UploadDocumentResponse{
-		DocumentID:     metadata.ID,
-		ConfigName:     req.Name,
-		Summary:        convResult.Summary,
-		ExtractedRules: convResult.Rules,
-		Confidence:     convResult.Confidence,
-		Success:        true,
-		Message:        fmt.Sprintf("Document uploaded and converted successfully. Confidence: %.1f%%", convResult.Confidence*100),
+		DocumentID:       metadata.ID,
+		ConfigName:       req.Name,
+		Summary:          convResult.Summary,
+		ExtractedRules:   convResult.Rules,
+		Confidence:       convResult.Confidence,
+		Valid:            validation.Valid,
+		ValidationErrors: validation.Errors,
+		Success:          true,
+		Message:          fmt.Sprintf("Document uploaded and converted successfully. Confidence: %.1f%%", convResult.Confidence*100),
 	}, nil
 }
 
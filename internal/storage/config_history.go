@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historyDir is the directory under the config storage that holds the
+// revisions of every config, one subdirectory per config name
+const historyDir = ".history"
+
+// Revision is one saved version of a stored config
+type Revision struct {
+	Number    int       `json:"number"`
+	Author    string    `json:"author,omitempty"`
+	Note      string    `json:"note,omitempty"` // Change note given when saving
+	Hash      string    `json:"hash"`           // SHA-256 of the content
+	CreatedAt time.Time `json:"created_at"`
+	Content   string    `json:"content,omitempty"`
+}
+
+// Revisions lists the revisions of a config, oldest first, without their
+// content
+func (s *ConfigStorage) Revisions(name string) ([]Revision, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	numbers, err := s.revisionNumbers(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, fmt.Errorf("no revisions found for config: %s", name)
+	}
+
+	revisions := make([]Revision, 0, len(numbers))
+	for _, n := range numbers {
+		rev, err := s.GetRevision(name, n)
+		if err != nil {
+			return nil, err
+		}
+		rev.Content = ""
+		revisions = append(revisions, *rev)
+	}
+	return revisions, nil
+}
+
+// GetRevision returns one revision of a config, including its content
+func (s *ConfigStorage) GetRevision(name string, number int) (*Revision, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.revisionPath(name, number))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("revision %d of config %s not found", number, name)
+		}
+		return nil, fmt.Errorf("failed to read revision: %w", err)
+	}
+
+	var rev Revision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		return nil, fmt.Errorf("failed to parse revision: %w", err)
+	}
+	return &rev, nil
+}
+
+// revisionPath returns the file holding a revision
+func (s *ConfigStorage) revisionPath(name string, number int) string {
+	return filepath.Join(s.baseDir, historyDir, name, strconv.Itoa(number)+".json")
+}
+
+// revisionNumbers returns the revision numbers of a config in ascending
+// order. Revisions survive Delete, so a recreated config continues the
+// numbering.
+func (s *ConfigStorage) revisionNumbers(name string) ([]int, error) {
+	entries, err := os.ReadDir(filepath.Join(s.baseDir, historyDir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	var numbers []int
+	for _, entry := range entries {
+		n, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".json"))
+		if err == nil && strings.HasSuffix(entry.Name(), ".json") {
+			numbers = append(numbers, n)
+		}
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// nextRevision returns the number for a new revision of a config
+func (s *ConfigStorage) nextRevision(name string) (int, error) {
+	numbers, err := s.revisionNumbers(name)
+	if err != nil {
+		return 0, err
+	}
+	if len(numbers) == 0 {
+		return 1, nil
+	}
+	return numbers[len(numbers)-1] + 1, nil
+}
+
+// writeRevision records a revision of a config
+func (s *ConfigStorage) writeRevision(name string, rev *Revision) error {
+	if err := os.MkdirAll(filepath.Join(s.baseDir, historyDir, name), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(rev, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal revision: %w", err)
+	}
+	if err := os.WriteFile(s.revisionPath(name, rev.Number), data, 0644); err != nil {
+		return fmt.Errorf("failed to write revision: %w", err)
+	}
+	return nil
+}
+
+// validateName rejects config names that are empty or would escape the
+// storage directory
+func validateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("config name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid config name: %q", name)
+	}
+	return nil
+}
+
+// contentHash returns the SHA-256 of config content in hex
+func contentHash(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
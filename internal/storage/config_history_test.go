@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigStorage_Revisions(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewConfigStorage(dir)
+	if err != nil {
+		t.Fatalf("NewConfigStorage() error = %v", err)
+	}
+
+	// A config saved before revisions were kept
+	legacy, err := json.Marshal(ConfigMetadata{Name: "team", Content: "run:\n  timeout: 1m\n"})
+	if err != nil {
+		t.Fatalf("Failed to marshal metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "team.json"), legacy, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	saves := []struct {
+		content string
+		change  Change
+	}{
+		{"run:\n  timeout: 2m\n", Change{Author: "alice", Note: "longer timeout"}},
+		{"run:\n  timeout: 3m\n", Change{Author: "bob"}},
+	}
+	for _, save := range saves {
+		if err := s.Save("team", save.content, "", nil, save.change); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	revisions, err := s.Revisions("team")
+	if err != nil {
+		t.Fatalf("Revisions() error = %v", err)
+	}
+	want := []struct {
+		number int
+		author string
+		note   string
+	}{
+		{1, "", "content before revision history was kept"},
+		{2, "alice", "longer timeout"},
+		{3, "bob", ""},
+	}
+	if len(revisions) != len(want) {
+		t.Fatalf("Revisions() returned %d revisions, want %d", len(revisions), len(want))
+	}
+	for i, w := range want {
+		rev := revisions[i]
+		if rev.Number != w.number || rev.Author != w.author || rev.Note != w.note {
+			t.Errorf("revision %d = {%d %q %q}, want {%d %q %q}", i, rev.Number, rev.Author, rev.Note, w.number, w.author, w.note)
+		}
+		if rev.Content != "" {
+			t.Errorf("revision %d has content in listing", i)
+		}
+	}
+
+	first, err := s.GetRevision("team", 1)
+	if err != nil {
+		t.Fatalf("GetRevision() error = %v", err)
+	}
+	if first.Content != "run:\n  timeout: 1m\n" {
+		t.Errorf("GetRevision(1).Content = %q", first.Content)
+	}
+
+	// Rolling back after a delete continues the numbering
+	if err := s.Delete("team"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := s.Save("team", first.Content, "", nil, Change{Note: "rolled back to revision 1"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	current, err := s.Get("team")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if current.Revision != 4 || current.Content != first.Content || current.Hash != first.Hash {
+		t.Errorf("after rollback: revision %d, content %q, hash %s", current.Revision, current.Content, current.Hash)
+	}
+
+	if _, err := s.GetRevision("team", 9); err == nil {
+		t.Error("GetRevision() of a missing revision succeeded")
+	}
+	if _, err := s.Revisions("../team"); err == nil {
+		t.Error("Revisions() accepted a name outside the storage directory")
+	}
+}
@@ -5,9 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
+
+	"go-standards-mcp-server/internal/lintconfig"
 )
 
+// DefaultConfigDir is where the server keeps custom configs
+const DefaultConfigDir = "./configs/custom"
+
 // ConfigMetadata stores metadata about a custom configuration
 type ConfigMetadata struct {
 	Name        string    `json:"name"`
@@ -15,11 +21,18 @@ type ConfigMetadata struct {
 	Content     string    `json:"content"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Revision    int       `json:"revision"` // Current revision number; 0 for configs saved before revisions were kept
+	Hash        string    `json:"hash,omitempty"`
+
+	Valid              bool     `json:"valid"`
+	ValidationErrors   []string `json:"validation_errors,omitempty"`
+	ValidationWarnings []string `json:"validation_warnings,omitempty"`
 }
 
 // ConfigStorage manages custom configuration files
 type ConfigStorage struct {
 	baseDir string
+	mu      sync.Mutex // Serializes saves so revision numbers are unique
 }
 
 // NewConfigStorage creates a new configuration storage
@@ -34,28 +47,99 @@ func NewConfigStorage(baseDir string) (*ConfigStorage, error) {
 	}, nil
 }
 
-// Save saves a configuration
-func (s *ConfigStorage) Save(name, content, description string) error {
-	// Validate name
-	if name == "" {
-		return fmt.Errorf("config name cannot be empty")
+// Change describes who made a change to a stored config and why
+type Change struct {
+	Author string
+	Note   string
+}
+
+// Save saves a configuration together with the result of validating it,
+// recording it as a new revision
+func (s *ConfigStorage) Save(name, content, description string, validation *lintconfig.Validation, change Change) error {
+	if err := validateName(name); err != nil {
+		return err
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
 	metadata := ConfigMetadata{
 		Name:        name,
 		Description: description,
 		Content:     content,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Hash:        contentHash(content),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if validation != nil {
+		metadata.Valid = validation.Valid
+		metadata.ValidationErrors = validation.Errors
+		metadata.ValidationWarnings = validation.Warnings
 	}
 
 	// Check if already exists for created time
-	if existing, err := s.Get(name); err == nil {
+	existing, err := s.Get(name)
+	if err == nil {
 		metadata.CreatedAt = existing.CreatedAt
+		// Configs saved before revisions were kept get their current
+		// content recorded first, so it can still be restored
+		if existing.Revision == 0 {
+			number, err := s.nextRevision(name)
+			if err != nil {
+				return err
+			}
+			if err := s.writeRevision(name, &Revision{
+				Number:    number,
+				Note:      "content before revision history was kept",
+				Hash:      contentHash(existing.Content),
+				CreatedAt: existing.UpdatedAt,
+				Content:   existing.Content,
+			}); err != nil {
+				return err
+			}
+		}
 	}
 
+	revision, err := s.nextRevision(name)
+	if err != nil {
+		return err
+	}
+	metadata.Revision = revision
+	if err := s.writeRevision(name, &Revision{
+		Number:    revision,
+		Author:    change.Author,
+		Note:      change.Note,
+		Hash:      metadata.Hash,
+		CreatedAt: now,
+		Content:   content,
+	}); err != nil {
+		return err
+	}
+
+	return s.writeConfig(&metadata)
+}
+
+// SetValidation replaces the stored validation result of a config without
+// creating a revision
+func (s *ConfigStorage) SetValidation(name string, validation *lintconfig.Validation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metadata, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	metadata.Valid = validation.Valid
+	metadata.ValidationErrors = validation.Errors
+	metadata.ValidationWarnings = validation.Warnings
+	return s.writeConfig(metadata)
+}
+
+// writeConfig writes the metadata and content files of a config
+func (s *ConfigStorage) writeConfig(metadata *ConfigMetadata) error {
 	// Save metadata
-	metaPath := filepath.Join(s.baseDir, name+".json")
+	metaPath := filepath.Join(s.baseDir, metadata.Name+".json")
 	data, err := json.MarshalIndent(metadata, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
@@ -66,8 +150,8 @@ func (s *ConfigStorage) Save(name, content, description string) error {
 	}
 
 	// Save config content
-	configPath := filepath.Join(s.baseDir, name+".yaml")
-	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+	configPath := filepath.Join(s.baseDir, metadata.Name+".yaml")
+	if err := os.WriteFile(configPath, []byte(metadata.Content), 0644); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -130,7 +214,8 @@ func (s *ConfigStorage) List() ([]ConfigMetadata, error) {
 	return configs, nil
 }
 
-// Delete deletes a configuration
+// Delete deletes a configuration. Its revisions are kept, so a deleted
+// config can be restored by rolling back to one of them.
 func (s *ConfigStorage) Delete(name string) error {
 	metaPath := filepath.Join(s.baseDir, name+".json")
 	configPath := filepath.Join(s.baseDir, name+".yaml")
@@ -0,0 +1,298 @@
+package suppression
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-standards-mcp-server/pkg/models"
+)
+
+// Directive prefixes recognized in comments
+const (
+	prefixLine = "//standards:ignore"
+	prefixFile = "//standards:ignore-file"
+)
+
+// Scope is the range of code a directive applies to
+type Scope string
+
+const (
+	ScopeLine     Scope = "line"
+	ScopeFunction Scope = "function"
+	ScopeFile     Scope = "file"
+)
+
+// RuleDirective is the rule reported for invalid or disallowed directives
+const RuleDirective = "standards-directive"
+
+// Policy controls which suppressions are accepted
+type Policy struct {
+	RequireReason       bool     // Directives without "-- reason" are ignored and reported
+	ForbiddenCategories []string // Issue categories that cannot be suppressed (e.g. security)
+}
+
+// Directive is a parsed //standards:ignore comment
+type Directive struct {
+	File      string
+	Line      int // Line of the comment itself
+	Scope     Scope
+	Rules     []string
+	Reason    string
+	StartLine int // First line covered
+	EndLine   int // Last line covered (0 means end of file)
+}
+
+// Matches reports whether the directive covers the given issue
+func (d *Directive) Matches(issue models.Issue) bool {
+	if issue.Line < d.StartLine || (d.EndLine > 0 && issue.Line > d.EndLine) {
+		return false
+	}
+
+	for _, rule := range d.Rules {
+		if rule == "all" ||
+			strings.EqualFold(rule, issue.Rule) ||
+			strings.EqualFold(rule, issue.Source) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the directive as written
+func (d *Directive) String() string {
+	prefix := prefixLine
+	if d.Scope == ScopeFile {
+		prefix = prefixFile
+	}
+	text := fmt.Sprintf("%s %s", prefix, strings.Join(d.Rules, ","))
+	if d.Reason != "" {
+		text += " -- " + d.Reason
+	}
+	return text
+}
+
+// Suppressor applies inline directives to linter issues
+type Suppressor struct {
+	workDir string
+	policy  Policy
+	files   map[string][]*Directive
+}
+
+// NewSuppressor creates a new Suppressor for files under workDir
+func NewSuppressor(workDir string, policy Policy) *Suppressor {
+	return &Suppressor{
+		workDir: workDir,
+		policy:  policy,
+		files:   make(map[string][]*Directive),
+	}
+}
+
+// Apply splits issues into kept and suppressed issues. Directives that
+// violate the policy do not suppress anything and are reported as
+// additional issues so that every exception stays visible.
+func (s *Suppressor) Apply(issues []models.Issue) ([]models.Issue, []models.SuppressedIssue) {
+	kept := make([]models.Issue, 0, len(issues))
+	var suppressed []models.SuppressedIssue
+	reported := make(map[*Directive]bool)
+
+	for _, issue := range issues {
+		directive, violation := s.match(issue)
+		if directive == nil {
+			kept = append(kept, issue)
+			continue
+		}
+
+		if violation != "" {
+			kept = append(kept, issue)
+			if !reported[directive] {
+				reported[directive] = true
+				kept = append(kept, s.violationIssue(directive, violation))
+			}
+			continue
+		}
+
+		suppressed = append(suppressed, models.SuppressedIssue{
+			Issue:     issue,
+			Directive: directive.String(),
+			Scope:     string(directive.Scope),
+			Reason:    directive.Reason,
+			Line:      directive.Line,
+		})
+	}
+
+	return kept, suppressed
+}
+
+// match finds the directive covering the issue. If the directive may not
+// be used for this issue, a non-empty violation message is returned.
+func (s *Suppressor) match(issue models.Issue) (*Directive, string) {
+	if issue.File == "" {
+		return nil, ""
+	}
+
+	for _, d := range s.directives(issue.File) {
+		if !d.Matches(issue) {
+			continue
+		}
+		if s.policy.RequireReason && d.Reason == "" {
+			return d, "suppression requires a justification: add \"-- <reason>\" to the directive"
+		}
+		for _, category := range s.policy.ForbiddenCategories {
+			if strings.EqualFold(category, issue.Category) {
+				return d, fmt.Sprintf("%s issues cannot be suppressed (%s: %s)", issue.Category, issue.Rule, issue.Message)
+			}
+		}
+		return d, ""
+	}
+
+	return nil, ""
+}
+
+// directives returns the parsed directives for a file, parsing on first use
+func (s *Suppressor) directives(file string) []*Directive {
+	if ds, ok := s.files[file]; ok {
+		return ds
+	}
+
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.workDir, path)
+	}
+
+	var ds []*Directive
+	if src, err := os.ReadFile(path); err == nil {
+		ds = Parse(file, src)
+	}
+	s.files[file] = ds
+	return ds
+}
+
+// violationIssue builds the issue reported for a rejected directive
+func (s *Suppressor) violationIssue(d *Directive, message string) models.Issue {
+	return models.Issue{
+		File:     d.File,
+		Line:     d.Line,
+		Severity: "error",
+		Category: "style",
+		Rule:     RuleDirective,
+		Message:  message,
+		Source:   "go-standards",
+		Code:     d.String(),
+	}
+}
+
+// Parse extracts suppression directives from Go source. Function scope is
+// used for directives in a function's doc comment, file scope for
+// ignore-file directives, and line scope otherwise: a trailing directive
+// covers its own line and a directive on its own line covers the next one.
+func Parse(file string, src []byte) []*Directive {
+	fset := token.NewFileSet()
+	// Partial ASTs are still useful, so parse errors are ignored
+	f, _ := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if f == nil {
+		return nil
+	}
+
+	// Map doc comments to the functions they document
+	funcs := make(map[*ast.Comment]*ast.FuncDecl)
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Doc == nil {
+			continue
+		}
+		for _, c := range fn.Doc.List {
+			funcs[c] = fn
+		}
+	}
+
+	lines := strings.Split(string(src), "\n")
+
+	var directives []*Directive
+	for _, group := range f.Comments {
+		for _, c := range group.List {
+			d := parseComment(c.Text)
+			if d == nil {
+				continue
+			}
+
+			pos := fset.Position(c.Slash)
+			d.File = file
+			d.Line = pos.Line
+
+			switch {
+			case d.Scope == ScopeFile:
+				d.StartLine = 1
+			case funcs[c] != nil:
+				fn := funcs[c]
+				d.Scope = ScopeFunction
+				d.StartLine = fset.Position(fn.Pos()).Line
+				d.EndLine = fset.Position(fn.End()).Line
+			case ownLine(lines, pos):
+				d.StartLine = pos.Line + 1
+				d.EndLine = pos.Line + 1
+			default:
+				d.StartLine = pos.Line
+				d.EndLine = pos.Line
+			}
+
+			directives = append(directives, d)
+		}
+	}
+
+	return directives
+}
+
+// parseComment parses a single comment, returning nil if it is not a directive
+func parseComment(text string) *Directive {
+	var d Directive
+	var rest string
+
+	switch {
+	case strings.HasPrefix(text, prefixFile):
+		d.Scope = ScopeFile
+		rest = strings.TrimPrefix(text, prefixFile)
+	case strings.HasPrefix(text, prefixLine):
+		d.Scope = ScopeLine
+		rest = strings.TrimPrefix(text, prefixLine)
+	default:
+		return nil
+	}
+
+	// Require a separator so that e.g. //standards:ignored is not a directive
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil
+	}
+
+	if idx := strings.Index(rest, "--"); idx >= 0 {
+		d.Reason = strings.TrimSpace(rest[idx+2:])
+		rest = rest[:idx]
+	}
+
+	for _, field := range strings.FieldsFunc(rest, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		d.Rules = append(d.Rules, strings.ToLower(field))
+	}
+	if len(d.Rules) == 0 {
+		d.Rules = []string{"all"}
+	}
+
+	return &d
+}
+
+// ownLine reports whether only whitespace precedes the comment on its line
+func ownLine(lines []string, pos token.Position) bool {
+	if pos.Line < 1 || pos.Line > len(lines) {
+		return false
+	}
+	line := lines[pos.Line-1]
+	if pos.Column-1 > len(line) {
+		return false
+	}
+	return strings.TrimSpace(line[:pos.Column-1]) == ""
+}
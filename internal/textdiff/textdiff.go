@@ -0,0 +1,152 @@
+// Package textdiff produces line-based unified diffs of small text files
+// such as configs.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change
+const contextLines = 3
+
+// op is one line of an edit script
+type op struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Unified returns a unified diff turning a into b, labelled with the given
+// file names, or an empty string if they are equal
+func Unified(a, b, fromName, toName string) string {
+	if a == b {
+		return ""
+	}
+
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks(ops) {
+		sb.WriteString(h)
+	}
+	return sb.String()
+}
+
+// splitLines splits text into lines without their newlines
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes an edit script from a longest common subsequence
+func diffLines(a, b []string) []op {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+// hunks groups an edit script into hunks with surrounding context
+func hunks(ops []op) []string {
+	var result []string
+	for start := 0; start < len(ops); {
+		// Find the next change
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are close enough to share context
+		last := first
+		for k := first; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				last = k
+			} else if k-last > 2*contextLines {
+				break
+			}
+		}
+
+		from := max(first-contextLines, start)
+		to := min(last+contextLines+1, len(ops))
+		result = append(result, formatHunk(ops, from, to))
+		start = to
+	}
+	return result
+}
+
+// formatHunk formats ops[from:to] with its header
+func formatHunk(ops []op, from, to int) string {
+	// Line numbers of the hunk's first line in each file
+	aLine, bLine := 1, 1
+	for _, o := range ops[:from] {
+		if o.kind != '+' {
+			aLine++
+		}
+		if o.kind != '-' {
+			bLine++
+		}
+	}
+
+	var aCount, bCount int
+	var body strings.Builder
+	for _, o := range ops[from:to] {
+		if o.kind != '+' {
+			aCount++
+		}
+		if o.kind != '-' {
+			bCount++
+		}
+		body.WriteByte(o.kind)
+		body.WriteString(o.line)
+		body.WriteByte('\n')
+	}
+
+	// An empty range starts at the line before it
+	if aCount == 0 {
+		aLine--
+	}
+	if bCount == 0 {
+		bLine--
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s", aLine, aCount, bLine, bCount, body.String())
+}
@@ -0,0 +1,53 @@
+package textdiff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	a := "linters:\n  enable:\n    - errcheck\n    - gocyclo\n    - govet\nlinters-settings:\n  gocyclo:\n    min-complexity: 10\n"
+	b := "linters:\n  enable:\n    - errcheck\n    - govet\n    - lll\nlinters-settings:\n  gocyclo:\n    min-complexity: 20\n"
+
+	want := `--- config@1
++++ config@2
+@@ -1,8 +1,8 @@
+ linters:
+   enable:
+     - errcheck
+-    - gocyclo
+     - govet
++    - lll
+ linters-settings:
+   gocyclo:
+-    min-complexity: 10
++    min-complexity: 20
+`
+	if got := Unified(a, b, "config@1", "config@2"); got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedSeparateHunks(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	b := "A\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nL\n"
+
+	want := `--- a
++++ b
+@@ -1,4 +1,4 @@
+-a
++A
+ b
+ c
+ d
+@@ -9,4 +9,4 @@
+ i
+ j
+ k
+-l
++L
+`
+	if got := Unified(a, b, "a", "b"); got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+	if got := Unified(a, a, "a", "b"); got != "" {
+		t.Errorf("Unified() of equal texts = %q, want empty", got)
+	}
+}
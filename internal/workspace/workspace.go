@@ -0,0 +1,217 @@
+package workspace
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Module describes a Go module found in a project
+type Module struct {
+	Path   string `json:"path"`    // Module path declared in go.mod
+	Dir    string `json:"dir"`     // Absolute module root directory
+	RelDir string `json:"rel_dir"` // Module root relative to the project root ("." for the root)
+}
+
+// skipDirs lists directory names never searched for nested modules
+var skipDirs = map[string]bool{
+	"vendor":       true,
+	"testdata":     true,
+	"node_modules": true,
+}
+
+// FindModules returns all modules under root. If root contains a go.work
+// file its use directives are authoritative, and a directive that leads
+// outside root is an error; otherwise the tree is searched for go.mod
+// files. An empty result means root is not part of any module below it,
+// and callers should analyze root directly.
+func FindModules(root string) ([]Module, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project root: %w", err)
+	}
+
+	var dirs []string
+	workFile := filepath.Join(absRoot, "go.work")
+	if _, err := os.Stat(workFile); err == nil {
+		dirs, err = parseWorkFile(workFile)
+		if err != nil {
+			return nil, err
+		}
+		// Only the project is analyzed, and only it passed the path guard
+		for _, dir := range dirs {
+			if !within(absRoot, dir) {
+				return nil, fmt.Errorf("go.work uses %s, which is outside the project root", dir)
+			}
+		}
+	} else {
+		dirs, err = findModFiles(absRoot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	modules := make([]Module, 0, len(dirs))
+	for _, dir := range dirs {
+		modPath, err := readModulePath(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(absRoot, dir)
+		if err != nil {
+			rel = dir
+		}
+
+		modules = append(modules, Module{
+			Path:   modPath,
+			Dir:    dir,
+			RelDir: filepath.ToSlash(rel),
+		})
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].RelDir < modules[j].RelDir
+	})
+
+	return modules, nil
+}
+
+// RepoPath converts a module-relative file path to a path relative to the
+// project root
+func (m Module) RepoPath(file string) string {
+	if filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(m.Dir, file); err == nil {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Join(m.RelDir, file))
+}
+
+// findModFiles walks root for directories containing a go.mod file
+func findModFiles(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != root && (skipDirs[name] || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() == "go.mod" {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for modules: %w", err)
+	}
+	return dirs, nil
+}
+
+// parseWorkFile returns the absolute module directories listed in the use
+// directives of a go.work file
+func parseWorkFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+	defer file.Close()
+
+	base := filepath.Dir(path)
+	var dirs []string
+	inBlock := false
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := stripComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var arg string
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			arg = line
+		case line == "use (" || line == "use(":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "use "):
+			arg = strings.TrimSpace(strings.TrimPrefix(line, "use"))
+		default:
+			continue
+		}
+
+		dir := unquote(arg)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, filepath.FromSlash(dir))
+		}
+		dirs = append(dirs, filepath.Clean(dir))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	return dirs, nil
+}
+
+// within reports whether dir lies inside root once symlinks are resolved
+func within(root, dir string) bool {
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// readModulePath returns the module path declared in a go.mod file
+func readModulePath(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := stripComment(scanner.Text())
+		if strings.HasPrefix(line, "module ") || strings.HasPrefix(line, "module\t") {
+			return unquote(strings.TrimSpace(line[len("module"):])), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return "", fmt.Errorf("no module directive in %s", path)
+}
+
+// stripComment removes a trailing // comment and surrounding whitespace
+func stripComment(line string) string {
+	if idx := strings.Index(line, "//"); idx >= 0 {
+		line = line[:idx]
+	}
+	return strings.TrimSpace(line)
+}
+
+// unquote removes Go string quoting if present
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func TestFindModules(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []string // RelDir of each module
+		wantErr bool
+	}{
+		{
+			name: "go.work use directives",
+			files: map[string]string{
+				"go.work":        "go 1.22\n\nuse ./tools\nuse (\n\t./svc // main service\n)\n",
+				"svc/go.mod":     "module example.com/svc\n",
+				"tools/go.mod":   "module \"example.com/tools\"\n",
+				"ignored/go.mod": "module example.com/ignored\n",
+			},
+			want: []string{"svc", "tools"},
+		},
+		{
+			name: "go.work use outside the root",
+			files: map[string]string{
+				"go.work":         "go 1.22\n\nuse (\n\t./svc\n\t../other\n)\n",
+				"svc/go.mod":      "module example.com/svc\n",
+				"../other/go.mod": "module example.com/other\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested go.mod files",
+			files: map[string]string{
+				"go.mod":                "module example.com/root\n",
+				"api/go.mod":            "module example.com/root/api\n",
+				"vendor/x/go.mod":       "module example.com/x\n",
+				"api/testdata/m/go.mod": "module example.com/m\n",
+			},
+			want: []string{".", "api"},
+		},
+		{
+			name:  "no modules",
+			files: map[string]string{"main.go": "package main\n"},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filepath.Join(t.TempDir(), "project")
+			for name, content := range tt.files {
+				writeFile(t, filepath.Join(root, name), content)
+			}
+
+			modules, err := FindModules(root)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindModules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(modules) != len(tt.want) {
+				t.Fatalf("FindModules() = %+v, want %v", modules, tt.want)
+			}
+			for i, module := range modules {
+				if module.RelDir != tt.want[i] {
+					t.Errorf("Module %d RelDir = %s, want %s", i, module.RelDir, tt.want[i])
+				}
+				if module.Path == "" {
+					t.Errorf("Module %d has empty path", i)
+				}
+			}
+		})
+	}
+}
+
+func TestFindModules_SymlinkOutsideRoot(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	writeFile(t, filepath.Join(base, "other", "go.mod"), "module example.com/other\n")
+	writeFile(t, filepath.Join(root, "go.work"), "go 1.22\n\nuse ./linked\n")
+	if err := os.Symlink(filepath.Join(base, "other"), filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	if modules, err := FindModules(root); err == nil {
+		t.Errorf("FindModules() = %+v, want an error for a use directive linking outside the root", modules)
+	}
+}
@@ -0,0 +1,137 @@
+package linters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// MaxStderrExcerpt limits how much tool output is kept in errors and run reports
+const MaxStderrExcerpt = 2048
+
+// RunError describes a linter that could not produce results
+type RunError struct {
+	Linter   string
+	ExitCode int
+	Stderr   string // Excerpt of the linter's stderr output
+	Err      error
+}
+
+// Error implements the error interface
+func (e *RunError) Error() string {
+	msg := fmt.Sprintf("%s failed", e.Linter)
+	if e.ExitCode != 0 {
+		msg += fmt.Sprintf(" (exit code %d)", e.ExitCode)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *RunError) Unwrap() error {
+	return e.Err
+}
+
+// commandResult holds the output of an external command
+type commandResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// runCommand runs an external command in dir and captures its output. A
+// non-zero exit code is not treated as an error, since most linters use it
+// to signal that issues were found; callers decide what it means. If ctx
+// carries a sandbox the command runs inside it, and exceeding a sandbox
+// limit is returned as a *LimitError.
+func runCommand(ctx context.Context, dir, name string, args ...string) (*commandResult, error) {
+	cmd, cleanup, err := Command(ctx, dir, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	result := &commandResult{
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+		ExitCode: cmd.ProcessState.ExitCode(),
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return result, ctxErr
+	}
+	if limitErr := LimitExceeded(ctx, cmd.ProcessState, result.Stderr); limitErr != nil {
+		return result, limitErr
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// newRunError creates a RunError from a command result
+func newRunError(linter string, result *commandResult, err error) *RunError {
+	runErr := &RunError{
+		Linter: linter,
+		Err:    err,
+	}
+	if result != nil {
+		runErr.ExitCode = result.ExitCode
+		runErr.Stderr = Excerpt(result.Stderr, MaxStderrExcerpt)
+	}
+	return runErr
+}
+
+// Excerpt returns the trimmed tail of the output, limited to max bytes
+func Excerpt(output []byte, max int) string {
+	text := strings.TrimSpace(string(output))
+	if len(text) <= max {
+		return text
+	}
+	return "..." + text[len(text)-max:]
+}
+
+// ProbeVersion runs a linter's version command, in the sandbox attached to
+// ctx if there is one, and extracts the version from its output with parse
+func ProbeVersion(ctx context.Context, command []string, parse func(output string) string) (string, error) {
+	if len(command) == 0 {
+		return "", fmt.Errorf("no version command")
+	}
+	result, err := runCommand(ctx, "", command[0], command[1:]...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s version: %w", command[0], err)
+	}
+	// Some tools print their version to stderr
+	version := parse(string(result.Stdout))
+	if version == "" {
+		version = parse(string(result.Stderr))
+	}
+	if version == "" {
+		return "", fmt.Errorf("unrecognized %s version output: %s", command[0], Excerpt(result.Stdout, 200))
+	}
+	return version, nil
+}
+
+// ParseVersion extracts the first version-like token (e.g. 1.55.2) from output
+func ParseVersion(output string) string {
+	for _, field := range strings.Fields(output) {
+		field = strings.TrimPrefix(strings.Trim(field, ",()"), "v")
+		if len(field) > 0 && field[0] >= '0' && field[0] <= '9' && strings.Contains(field, ".") {
+			return field
+		}
+	}
+	return ""
+}
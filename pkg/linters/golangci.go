@@ -1,26 +1,49 @@
 ﻿package linters
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
 	"go-standards-mcp-server/pkg/models"
 	"go.uber.org/zap"
 )
 
+// golangciLintRegistration registers golangci-lint with the registry
+var golangciLintRegistration = Registration{
+	Name:        "golangci-lint",
+	Description: "Meta-linter running the linters enabled in the standard's config",
+	Capabilities: Capabilities{
+		SupportsFixes:  true,
+		NeedsConfig:    true,
+		VersionCommand: []string{"golangci-lint", "--version"},
+	},
+	New: func(logger *zap.Logger, opts Options) (Linter, error) {
+		return NewGolangciLint(logger)
+	},
+}
+
 // GolangciLint implements the golangci-lint linter
 type GolangciLint struct {
-	logger *zap.Logger
+	logger   *zap.Logger
+	taxonomy *Taxonomy
+
+	mu    sync.Mutex
+	major int // Cached major version, 0 until detected
 }
 
 // NewGolangciLint creates a new GolangciLint instance
 func NewGolangciLint(logger *zap.Logger) (*GolangciLint, error) {
 	g := &GolangciLint{
-		logger: logger,
+		logger:   logger,
+		taxonomy: DefaultTaxonomy(),
 	}
 
 	if !g.IsAvailable() {
@@ -30,6 +53,11 @@ func NewGolangciLint(logger *zap.Logger) (*GolangciLint, error) {
 	return g, nil
 }
 
+// SetTaxonomy replaces the taxonomy used to classify issues
+func (g *GolangciLint) SetTaxonomy(t *Taxonomy) {
+	g.taxonomy = t
+}
+
 // Name returns the name of the linter
 func (g *GolangciLint) Name() string {
 	return "golangci-lint"
@@ -41,12 +69,37 @@ func (g *GolangciLint) IsAvailable() bool {
 	return err == nil
 }
 
-// Run executes golangci-lint
+// Run executes golangci-lint. The installed major version decides the
+// flags: v2 removed --out-format and writes JSON to a path instead.
 func (g *GolangciLint) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
-	args := []string{
-		"run",
-		"--out-format=json",
-		"--print-issued-lines=false",
+	major, err := g.MajorVersion(ctx)
+	if err != nil {
+		g.logger.Warn("Failed to detect golangci-lint version, assuming v1", zap.Error(err))
+		major = 1
+	}
+
+	var args []string
+	var jsonPath string
+	if major >= 2 {
+		jsonFile, err := os.CreateTemp("", "golangci-lint-*.json")
+		if err != nil {
+			return nil, &RunError{Linter: g.Name(), Err: fmt.Errorf("failed to create output file: %w", err)}
+		}
+		jsonFile.Close()
+		jsonPath = jsonFile.Name()
+		defer os.Remove(jsonPath)
+
+		args = []string{
+			"run",
+			"--output.json.path=" + jsonPath,
+			"--show-stats=false",
+		}
+	} else {
+		args = []string{
+			"run",
+			"--out-format=json",
+			"--print-issued-lines=false",
+		}
 	}
 
 	if configPath != "" {
@@ -55,46 +108,62 @@ func (g *GolangciLint) Run(ctx context.Context, workDir, configPath string) ([]m
 
 	args = append(args, "./...")
 
-	cmd := exec.CommandContext(ctx, "golangci-lint", args...)
-	cmd.Dir = workDir
-
 	g.logger.Debug("Running golangci-lint",
 		zap.String("workDir", workDir),
 		zap.String("config", configPath),
+		zap.Int("majorVersion", major),
 		zap.Strings("args", args))
 
-	output, err := cmd.CombinedOutput()
-	
-	// golangci-lint returns non-zero exit code when issues are found
-	// We only treat it as error if output parsing fails
-	if err != nil && len(output) == 0 {
-		return nil, fmt.Errorf("golangci-lint failed: %w", err)
-	}
-
-	// Parse JSON output
-	var result GolangciLintResult
-	if len(output) > 0 {
-		if err := json.Unmarshal(output, &result); err != nil {
-			g.logger.Warn("Failed to parse golangci-lint output",
-				zap.Error(err),
-				zap.String("output", string(output)))
-			return []models.Issue{}, nil
+	result, err := runCommand(ctx, workDir, "golangci-lint", args...)
+	if err != nil {
+		return nil, newRunError(g.Name(), result, err)
+	}
+
+	output := result.Stdout
+	if jsonPath != "" {
+		output, err = os.ReadFile(jsonPath)
+		if err != nil {
+			return nil, newRunError(g.Name(), result, fmt.Errorf("failed to read output: %w", err))
+		}
+	}
+
+	// golangci-lint exits with code 1 when issues are found, so the exit
+	// code alone does not mean failure; unparseable output does
+	if len(bytes.TrimSpace(output)) == 0 {
+		if result.ExitCode != 0 {
+			return nil, newRunError(g.Name(), result, fmt.Errorf("no output produced"))
 		}
+		return []models.Issue{}, nil
+	}
+
+	// Decode only the first JSON document, since v1 may print text such as
+	// stats after it on stdout
+	var parsed GolangciLintResult
+	if err := json.NewDecoder(bytes.NewReader(output)).Decode(&parsed); err != nil {
+		g.logger.Warn("Failed to parse golangci-lint output",
+			zap.Error(err),
+			zap.String("output", Excerpt(output, MaxStderrExcerpt)))
+		return nil, newRunError(g.Name(), result, fmt.Errorf("failed to parse output: %w", err))
+	}
+	if parsed.Report.Error != "" {
+		return nil, newRunError(g.Name(), result, fmt.Errorf("%s", parsed.Report.Error))
 	}
 
 	// Convert to our Issue format
-	issues := make([]models.Issue, 0, len(result.Issues))
-	for _, issue := range result.Issues {
+	issues := make([]models.Issue, 0, len(parsed.Issues))
+	for _, issue := range parsed.Issues {
+		class := g.classify(issue.FromLinter, issue.Text)
 		issues = append(issues, models.Issue{
-			File:     g.relativePath(workDir, issue.Pos.Filename),
-			Line:     issue.Pos.Line,
-			Column:   issue.Pos.Column,
-			Severity: g.mapSeverity(issue.Severity),
-			Category: g.mapCategory(issue.FromLinter),
-			Rule:     issue.FromLinter,
-			Message:  issue.Text,
-			Source:   "golangci-lint",
-			Code:     issue.SourceLines,
+			File:        g.relativePath(workDir, issue.Pos.Filename),
+			Line:        issue.Pos.Line,
+			Column:      issue.Pos.Column,
+			Severity:    g.mapSeverity(issue.Severity),
+			Category:    class.Category,
+			Subcategory: class.Subcategory,
+			Rule:        issue.FromLinter,
+			Message:     issue.Text,
+			Source:      "golangci-lint",
+			Code:        strings.Join(issue.SourceLines, "\n"),
 		})
 	}
 
@@ -102,6 +171,34 @@ func (g *GolangciLint) Run(ctx context.Context, workDir, configPath string) ([]m
 	return issues, nil
 }
 
+// MajorVersion returns the major version of the installed golangci-lint,
+// detecting it on first use
+func (g *GolangciLint) MajorVersion(ctx context.Context) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.major > 0 {
+		return g.major, nil
+	}
+
+	version, err := g.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid golangci-lint version %q: %w", version, err)
+	}
+
+	g.major = major
+	return major, nil
+}
+
+// Version returns the installed golangci-lint version
+func (g *GolangciLint) Version(ctx context.Context) (string, error) {
+	return ProbeVersion(ctx, golangciLintRegistration.Capabilities.VersionCommand, ParseVersion)
+}
+
 // relativePath returns a relative path if possible
 func (g *GolangciLint) relativePath(base, target string) string {
 	rel, err := filepath.Rel(base, target)
@@ -123,36 +220,25 @@ func (g *GolangciLint) mapSeverity(severity string) string {
 	}
 }
 
-// mapCategory maps linter names to categories
-func (g *GolangciLint) mapCategory(linter string) string {
-	categoryMap := map[string]string{
-		"gofmt":       "format",
-		"goimports":   "format",
-		"gosec":       "security",
-		"govet":       "logic",
-		"staticcheck": "logic",
-		"errcheck":    "error-handling",
-		"ineffassign": "performance",
-		"unused":      "dead-code",
-		"deadcode":    "dead-code",
-		"varcheck":    "dead-code",
-		"structcheck": "dead-code",
-		"gocyclo":     "complexity",
-		"gocognit":    "complexity",
-		"nestif":      "complexity",
-		"dupl":        "duplication",
-		"goconst":     "maintainability",
+// classify classifies an issue by linter and, for multi-check linters such
+// as gocritic, revive and gosec, by the check named in the message
+func (g *GolangciLint) classify(linter, text string) Classification {
+	check := ""
+	if g.taxonomy.HasChecks(linter) {
+		check = checkFromText(text)
 	}
-
-	if category, ok := categoryMap[linter]; ok {
-		return category
-	}
-	return "other"
+	return g.taxonomy.Classify(linter, check)
 }
 
 // GolangciLintResult represents golangci-lint JSON output
 type GolangciLintResult struct {
 	Issues []GolangciLintIssue `json:"Issues"`
+	Report GolangciLintReport  `json:"Report"`
+}
+
+// GolangciLintReport contains run-level information from golangci-lint
+type GolangciLintReport struct {
+	Error string `json:"Error"`
 }
 
 // GolangciLintIssue represents a single issue from golangci-lint
@@ -160,7 +246,7 @@ type GolangciLintIssue struct {
 	FromLinter  string              `json:"FromLinter"`
 	Text        string              `json:"Text"`
 	Severity    string              `json:"Severity"`
-	SourceLines string              `json:"SourceLines"`
+	SourceLines []string            `json:"SourceLines"`
 	Pos         GolangciLintPosition `json:"Pos"`
 }
 
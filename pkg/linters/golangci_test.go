@@ -0,0 +1,71 @@
+package linters
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// fakeGolangciLint mimics golangci-lint of the given major version. v1
+// prints JSON to stdout when given --out-format=json; v2 writes it to the
+// --output.json.path file and prints text to stdout.
+const fakeGolangciLint = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "golangci-lint has version %[1]d.1.0 built with go1.24"
+	exit 0
+fi
+report='{"Issues":[{"FromLinter":"errcheck","Text":"unchecked error","Severity":"","SourceLines":["f()","g()"],"Pos":{"Filename":"main.go","Line":3,"Column":2}}],"Report":{}}'
+for arg in "$@"; do
+	case "$arg" in
+	--out-format=json)
+		[ %[1]d -eq 1 ] || { echo "unknown flag: --out-format" >&2; exit 3; }
+		echo "$report"
+		echo "1 issues."
+		exit 1 ;;
+	--output.json.path=*)
+		[ %[1]d -eq 2 ] || { echo "unknown flag: --output.json.path" >&2; exit 3; }
+		echo "$report" > "${arg#--output.json.path=}"
+		echo "main.go:3:2: unchecked error (errcheck)"
+		exit 1 ;;
+	esac
+done
+exit 3
+`
+
+func TestGolangciLintVersions(t *testing.T) {
+	for _, major := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", major), func(t *testing.T) {
+			bin := t.TempDir()
+			script := fmt.Sprintf(fakeGolangciLint, major)
+			if err := os.WriteFile(filepath.Join(bin, "golangci-lint"), []byte(script), 0755); err != nil {
+				t.Fatalf("Failed to write fake golangci-lint: %v", err)
+			}
+			t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			g, err := NewGolangciLint(zap.NewNop())
+			if err != nil {
+				t.Fatalf("NewGolangciLint() error = %v", err)
+			}
+
+			got, err := g.MajorVersion(context.Background())
+			if err != nil || got != major {
+				t.Fatalf("MajorVersion() = %d, %v, want %d", got, err, major)
+			}
+
+			issues, err := g.Run(context.Background(), t.TempDir(), "")
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if len(issues) != 1 || issues[0].Rule != "errcheck" || issues[0].Line != 3 {
+				t.Fatalf("Run() = %+v, want one errcheck issue", issues)
+			}
+			if issues[0].Code != "f()\ng()" {
+				t.Errorf("Code = %q, want the source lines joined", issues[0].Code)
+			}
+		})
+	}
+}
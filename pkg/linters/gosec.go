@@ -0,0 +1,194 @@
+package linters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-standards-mcp-server/pkg/models"
+	"go.uber.org/zap"
+)
+
+// gosecRegistration registers gosec with the registry
+var gosecRegistration = Registration{
+	Name:        "gosec",
+	Description: "Security checks with CWE classification",
+	Capabilities: Capabilities{
+		VersionCommand: []string{"gosec", "-version"},
+	},
+	New: func(logger *zap.Logger, opts Options) (Linter, error) {
+		return NewGosec(logger)
+	},
+}
+
+// Gosec implements the standalone gosec security linter
+type Gosec struct {
+	logger   *zap.Logger
+	taxonomy *Taxonomy
+}
+
+// NewGosec creates a new Gosec instance
+func NewGosec(logger *zap.Logger) (*Gosec, error) {
+	g := &Gosec{
+		logger:   logger,
+		taxonomy: DefaultTaxonomy(),
+	}
+
+	if !g.IsAvailable() {
+		return nil, fmt.Errorf("gosec not found in PATH")
+	}
+
+	return g, nil
+}
+
+// SetTaxonomy replaces the taxonomy used to classify issues
+func (g *Gosec) SetTaxonomy(t *Taxonomy) {
+	g.taxonomy = t
+}
+
+// Name returns the name of the linter
+func (g *Gosec) Name() string {
+	return "gosec"
+}
+
+// IsAvailable checks if gosec is available
+func (g *Gosec) IsAvailable() bool {
+	_, err := exec.LookPath("gosec")
+	return err == nil
+}
+
+// Run executes gosec. The golangci-lint config path does not apply.
+func (g *Gosec) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
+	g.logger.Debug("Running gosec", zap.String("workDir", workDir))
+
+	// -no-fail keeps the exit code at 0 when issues are found, so a
+	// non-zero exit always means the scan itself failed
+	result, err := runCommand(ctx, workDir, "gosec", "-fmt", "json", "-no-fail", "./...")
+	if err != nil {
+		return nil, newRunError(g.Name(), result, err)
+	}
+	if result.ExitCode != 0 {
+		return nil, newRunError(g.Name(), result, fmt.Errorf("scan failed"))
+	}
+
+	issues, err := parseGosecOutput(result.Stdout, workDir, g.taxonomy)
+	if err != nil {
+		g.logger.Warn("Failed to parse gosec output",
+			zap.Error(err),
+			zap.String("output", Excerpt(result.Stdout, MaxStderrExcerpt)))
+		return nil, newRunError(g.Name(), result, fmt.Errorf("failed to parse output: %w", err))
+	}
+
+	g.logger.Debug("gosec completed", zap.Int("issues", len(issues)))
+	return issues, nil
+}
+
+// parseGosecOutput converts gosec JSON output into issues. Packages that
+// fail to type-check are reported by gosec as "Golang errors" and become
+// error issues, since their code was not scanned.
+func parseGosecOutput(output []byte, workDir string, taxonomy *Taxonomy) ([]models.Issue, error) {
+	issues := []models.Issue{}
+	if len(bytes.TrimSpace(output)) == 0 {
+		return issues, nil
+	}
+
+	var report GosecResult
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, err
+	}
+
+	for _, finding := range report.Issues {
+		line, _ := strconv.Atoi(strings.SplitN(finding.Line, "-", 2)[0])
+		column, _ := strconv.Atoi(finding.Column)
+
+		class := taxonomy.Classify("gosec", finding.RuleID)
+		issue := models.Issue{
+			File:        relativePath(workDir, finding.File),
+			Line:        line,
+			Column:      column,
+			Severity:    gosecSeverity(finding.Severity),
+			Category:    class.Category,
+			Subcategory: class.Subcategory,
+			Rule:        finding.RuleID,
+			Message:     finding.Details,
+			Source:      "gosec",
+			Code:        finding.Code,
+			Confidence:  strings.ToLower(finding.Confidence),
+		}
+		if finding.CWE.ID != "" {
+			issue.CWE = "CWE-" + finding.CWE.ID
+		}
+		issues = append(issues, issue)
+	}
+
+	files := make([]string, 0, len(report.Errors))
+	for file := range report.Errors {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		for _, e := range report.Errors[file] {
+			issues = append(issues, models.Issue{
+				File:     relativePath(workDir, file),
+				Line:     e.Line,
+				Column:   e.Column,
+				Severity: "error",
+				Category: "build",
+				Rule:     "gosec-build",
+				Message:  fmt.Sprintf("package not scanned: %s", e.Error),
+				Source:   "gosec",
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// gosecSeverity maps gosec severity to our severity levels
+func gosecSeverity(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// GosecResult represents gosec JSON output
+type GosecResult struct {
+	Issues []GosecIssue            `json:"Issues"`
+	Errors map[string][]GosecError `json:"Golang errors"`
+}
+
+// GosecIssue represents a single finding from gosec
+type GosecIssue struct {
+	Severity   string   `json:"severity"`   // LOW, MEDIUM, HIGH
+	Confidence string   `json:"confidence"` // LOW, MEDIUM, HIGH
+	CWE        GosecCWE `json:"cwe"`
+	RuleID     string   `json:"rule_id"`
+	Details    string   `json:"details"`
+	File       string   `json:"file"`
+	Code       string   `json:"code"`
+	Line       string   `json:"line"` // A single line or a range such as "12-14"
+	Column     string   `json:"column"`
+}
+
+// GosecCWE identifies the weakness a gosec rule detects
+type GosecCWE struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// GosecError is a type-checking error that prevented gosec from scanning a file
+type GosecError struct {
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Error  string `json:"error"`
+}
@@ -0,0 +1,64 @@
+package linters
+
+import (
+	"testing"
+)
+
+const gosecFixture = `{
+	"Golang errors": {
+		"/src/app/broken.go": [{"line": 3, "column": 2, "error": "undefined: foo"}]
+	},
+	"Issues": [
+		{
+			"severity": "MEDIUM",
+			"confidence": "HIGH",
+			"cwe": {"id": "22", "url": "https://cwe.mitre.org/data/definitions/22.html"},
+			"rule_id": "G304",
+			"details": "Potential file inclusion via variable",
+			"file": "/src/app/main.go",
+			"code": "os.ReadFile(path)",
+			"line": "12-14",
+			"column": "15"
+		}
+	],
+	"Stats": {"files": 2, "lines": 40, "nosec": 0, "found": 1},
+	"GosecVersion": "2.18.2"
+}`
+
+func TestParseGosecOutput(t *testing.T) {
+	issues, err := parseGosecOutput([]byte(gosecFixture), "/src/app", DefaultTaxonomy())
+	if err != nil {
+		t.Fatalf("parseGosecOutput() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("parseGosecOutput() = %d issues, want 2: %+v", len(issues), issues)
+	}
+
+	finding := issues[0]
+	if finding.File != "main.go" || finding.Line != 12 || finding.Column != 15 {
+		t.Errorf("Position = %s:%d:%d, want main.go:12:15", finding.File, finding.Line, finding.Column)
+	}
+	if finding.Severity != "warning" || finding.Confidence != "high" {
+		t.Errorf("Severity = %s, confidence = %s, want warning, high", finding.Severity, finding.Confidence)
+	}
+	if finding.CWE != "CWE-22" || finding.Rule != "G304" {
+		t.Errorf("CWE = %s, rule = %s, want CWE-22, G304", finding.CWE, finding.Rule)
+	}
+	if finding.Category != "security" || finding.Subcategory != "path-traversal" {
+		t.Errorf("Category = %s/%s, want security/path-traversal", finding.Category, finding.Subcategory)
+	}
+
+	if buildErr := issues[1]; buildErr.Severity != "error" || buildErr.File != "broken.go" {
+		t.Errorf("Build error issue = %+v, want error in broken.go", buildErr)
+	}
+}
+
+func TestParseGosecOutputEmpty(t *testing.T) {
+	issues, err := parseGosecOutput([]byte(`{"Golang errors": {}, "Issues": [], "Stats": {}}`), "/src/app", DefaultTaxonomy())
+	if err != nil {
+		t.Fatalf("parseGosecOutput() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("parseGosecOutput() = %+v, want no issues", issues)
+	}
+}
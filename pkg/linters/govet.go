@@ -1,10 +1,14 @@
 ﻿package linters
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os/exec"
-	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,18 +16,38 @@ import (
 	"go.uber.org/zap"
 )
 
+// goVetRegistration registers go vet with the registry
+var goVetRegistration = Registration{
+	Name:        "govet",
+	Description: "Go toolchain vet checks for suspicious constructs",
+	Capabilities: Capabilities{
+		SupportsFixes:  true,
+		VersionCommand: []string{"go", "env", "GOVERSION"},
+	},
+	New: func(logger *zap.Logger, opts Options) (Linter, error) {
+		return NewGoVet(logger), nil
+	},
+}
+
 // GoVet implements the go vet linter
 type GoVet struct {
-	logger *zap.Logger
+	logger   *zap.Logger
+	taxonomy *Taxonomy
 }
 
 // NewGoVet creates a new GoVet instance
 func NewGoVet(logger *zap.Logger) *GoVet {
 	return &GoVet{
-		logger: logger,
+		logger:   logger,
+		taxonomy: DefaultTaxonomy(),
 	}
 }
 
+// SetTaxonomy replaces the taxonomy used to classify issues
+func (g *GoVet) SetTaxonomy(t *Taxonomy) {
+	g.taxonomy = t
+}
+
 // Name returns the name of the linter
 func (g *GoVet) Name() string {
 	return "govet"
@@ -35,101 +59,250 @@ func (g *GoVet) IsAvailable() bool {
 	return err == nil
 }
 
-// Run executes go vet
+// Run executes go vet with JSON output. Diagnostics are written to stdout
+// as JSON; packages that fail to build are reported as text on stderr.
 func (g *GoVet) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
-	cmd := exec.CommandContext(ctx, "go", "vet", "./...")
-	cmd.Dir = workDir
-
 	g.logger.Debug("Running go vet", zap.String("workDir", workDir))
 
-	output, err := cmd.CombinedOutput()
-	
-	// go vet returns non-zero exit code when issues are found
-	// Parse the output regardless of exit code
-	issues := g.parseOutput(workDir, string(output))
+	result, err := runCommand(ctx, workDir, "go", "vet", "-json", "./...")
+	if err != nil {
+		return nil, newRunError(g.Name(), result, err)
+	}
+
+	issues, err := parseVetJSON(workDir, result.Stdout, g.taxonomy)
+	if err != nil {
+		return nil, newRunError(g.Name(), result, fmt.Errorf("failed to parse output: %w", err))
+	}
+	issues = append(issues, parseVetBuildErrors(workDir, string(result.Stderr))...)
+
+	// A non-zero exit without any diagnostics means go vet could not load
+	// the packages at all (e.g. missing go.mod)
+	if result.ExitCode != 0 && len(issues) == 0 {
+		return nil, newRunError(g.Name(), result, fmt.Errorf("no diagnostics produced"))
+	}
 
 	g.logger.Debug("go vet completed",
 		zap.Int("issues", len(issues)),
-		zap.Error(err))
+		zap.Int("exitCode", result.ExitCode))
 
 	return issues, nil
 }
 
-// parseOutput parses go vet output
-func (g *GoVet) parseOutput(workDir, output string) []models.Issue {
-	if output == "" {
-		return []models.Issue{}
-	}
+// ParseVersion extracts the Go toolchain version running go vet
+func (g *GoVet) ParseVersion(output string) string {
+	return parseGoVersion(output)
+}
+
+// GoVersion returns the version of the go command in PATH (e.g. 1.24.1)
+func GoVersion(ctx context.Context) (string, error) {
+	return ProbeVersion(ctx, goVetRegistration.Capabilities.VersionCommand, parseGoVersion)
+}
+
+// parseGoVersion parses go env GOVERSION output (e.g. go1.24.1)
+func parseGoVersion(output string) string {
+	return strings.TrimPrefix(strings.TrimSpace(output), "go")
+}
 
-	// go vet output format: path/file.go:line:column: message
-	// or: path/file.go:line: message
-	re := regexp.MustCompile(`^(.+?):(\d+):(?:(\d+):)?\s*(.+)$`)
+// vetDiagnostic is a single diagnostic in go vet -json output
+type vetDiagnostic struct {
+	Posn           string `json:"posn"`
+	End            string `json:"end"`
+	Message        string `json:"message"`
+	SuggestedFixes []struct {
+		Message string `json:"message"`
+		Edits   []struct {
+			Filename string `json:"filename"`
+			Start    int    `json:"start"`
+			End      int    `json:"end"`
+			New      string `json:"new"`
+		} `json:"edits"`
+	} `json:"suggested_fixes"`
+	Related []struct {
+		Posn    string `json:"posn"`
+		Message string `json:"message"`
+	} `json:"related"`
+}
+
+// vetError is reported in place of diagnostics when an analyzer fails
+type vetError struct {
+	Error string `json:"error"`
+}
+
+// parseVetJSON parses go vet -json output: one object per package mapping
+// analyzer names to diagnostics, or to an error if the analyzer failed.
+// Older toolchains precede each object with a "# package" line.
+func parseVetJSON(workDir string, output []byte, taxonomy *Taxonomy) ([]models.Issue, error) {
+	var cleaned bytes.Buffer
+	for _, line := range bytes.SplitAfter(output, []byte("\n")) {
+		if !bytes.HasPrefix(line, []byte("# ")) {
+			cleaned.Write(line)
+		}
+	}
 
 	var issues []models.Issue
-	lines := strings.Split(output, "\n")
+	decoder := json.NewDecoder(&cleaned)
+	for {
+		var tree map[string]map[string]json.RawMessage
+		if err := decoder.Decode(&tree); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
+		for _, pkg := range sortedKeys(tree) {
+			analyzers := tree[pkg]
+			for _, analyzer := range sortedKeys(analyzers) {
+				raw := analyzers[analyzer]
+
+				var failure vetError
+				if json.Unmarshal(raw, &failure) == nil && failure.Error != "" {
+					issues = append(issues, models.Issue{
+						Severity: "error",
+						Category: "build",
+						Rule:     analyzer,
+						Message:  fmt.Sprintf("%s: %s", pkg, failure.Error),
+						Source:   "govet",
+					})
+					continue
+				}
+
+				var diagnostics []vetDiagnostic
+				if err := json.Unmarshal(raw, &diagnostics); err != nil {
+					return nil, fmt.Errorf("invalid diagnostics for %s in %s: %w", analyzer, pkg, err)
+				}
+				for _, d := range diagnostics {
+					issues = append(issues, vetIssue(workDir, analyzer, d, taxonomy.Classify("govet", analyzer)))
+				}
+			}
 		}
+	}
 
-		matches := re.FindStringSubmatch(line)
-		if len(matches) < 4 {
-			continue
+	return issues, nil
+}
+
+// vetIssue converts a go vet diagnostic to an issue
+func vetIssue(workDir, analyzer string, d vetDiagnostic, class Classification) models.Issue {
+	file, line, column := parsePosn(d.Posn)
+	issue := models.Issue{
+		File:        relativePath(workDir, file),
+		Line:        line,
+		Column:      column,
+		Severity:    "warning",
+		Category:    class.Category,
+		Subcategory: class.Subcategory,
+		Rule:        analyzer,
+		Message:     d.Message,
+		Source:      "govet",
+	}
+
+	if endFile, endLine, endColumn := parsePosn(d.End); endFile == file && endLine > 0 {
+		issue.EndLine, issue.EndColumn = endLine, endColumn
+	}
+
+	for _, r := range d.Related {
+		relFile, relLine, relColumn := parsePosn(r.Posn)
+		issue.Related = append(issue.Related, models.RelatedInfo{
+			File:    relativePath(workDir, relFile),
+			Line:    relLine,
+			Column:  relColumn,
+			Message: r.Message,
+		})
+	}
+
+	for _, fix := range d.SuggestedFixes {
+		suggested := models.SuggestedFix{Message: fix.Message}
+		for _, e := range fix.Edits {
+			suggested.Edits = append(suggested.Edits, models.TextEdit{
+				File:  relativePath(workDir, e.Filename),
+				Start: e.Start,
+				End:   e.End,
+				New:   e.New,
+			})
 		}
+		issue.Fixes = append(issue.Fixes, suggested)
+	}
+	if len(issue.Fixes) > 0 {
+		issue.Suggestion = issue.Fixes[0].Message
+	}
 
-		file := matches[1]
-		lineNum, _ := strconv.Atoi(matches[2])
-		column := 0
-		message := matches[4]
+	return issue
+}
+
+// parsePosn splits a go/token position (file:line:column or file:line)
+func parsePosn(posn string) (string, int, int) {
+	file, last, ok := cutLastColon(posn)
+	if !ok {
+		return posn, 0, 0
+	}
+	n, err := strconv.Atoi(last)
+	if err != nil {
+		return posn, 0, 0
+	}
 
-		if matches[3] != "" {
-			column, _ = strconv.Atoi(matches[3])
+	if rest, prev, ok := cutLastColon(file); ok {
+		if line, err := strconv.Atoi(prev); err == nil {
+			return rest, line, n
 		}
+	}
+	return file, n, 0
+}
+
+// cutLastColon splits s around its last colon
+func cutLastColon(s string) (string, string, bool) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+1:], true
+}
+
+// vetBuildErrorPattern matches a positioned build error printed by go vet
+var vetBuildErrorPattern = regexp.MustCompile(`^(?:vet: )?(.+?\.go):(\d+)(?::(\d+))?:\s*(.+)$`)
+
+// parseVetBuildErrors reports packages go vet could not build. Each failing
+// package is introduced by a "# package" line followed by its errors.
+func parseVetBuildErrors(workDir, stderr string) []models.Issue {
+	var issues []models.Issue
+	pkg := ""
 
-		// Make path relative
-		relPath, err := filepath.Rel(workDir, file)
-		if err != nil {
-			relPath = file
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "# "):
+			pkg = strings.TrimPrefix(line, "# ")
+			continue
+		case pkg == "":
+			// Not attributed to a package, e.g. go command warnings
+			continue
 		}
 
 		issue := models.Issue{
-			File:     relPath,
-			Line:     lineNum,
-			Column:   column,
-			Severity: "warning",
-			Category: g.categorize(message),
-			Rule:     "govet",
-			Message:  message,
+			Severity: "error",
+			Category: "build",
+			Rule:     "typecheck",
+			Message:  fmt.Sprintf("%s: %s", pkg, strings.TrimPrefix(line, "vet: ")),
 			Source:   "govet",
 		}
-
+		if m := vetBuildErrorPattern.FindStringSubmatch(line); m != nil {
+			issue.File = relativePath(workDir, m[1])
+			issue.Line, _ = strconv.Atoi(m[2])
+			issue.Column, _ = strconv.Atoi(m[3])
+			issue.Message = m[4]
+		}
 		issues = append(issues, issue)
 	}
 
 	return issues
 }
 
-// categorize attempts to categorize the issue based on the message
-func (g *GoVet) categorize(message string) string {
-	message = strings.ToLower(message)
-
-	if strings.Contains(message, "shadow") {
-		return "logic"
-	}
-	if strings.Contains(message, "printf") || strings.Contains(message, "format") {
-		return "format"
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	if strings.Contains(message, "composite literal") {
-		return "style"
-	}
-	if strings.Contains(message, "unreachable") {
-		return "dead-code"
-	}
-	if strings.Contains(message, "nil") {
-		return "error-handling"
-	}
-
-	return "logic"
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,120 @@
+package linters
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const vetJSONOutput = `# example.com/app/a
+{
+	"example.com/app/a": {
+		"assign": [
+			{
+				"posn": "/src/app/a/a.go:14:2",
+				"end": "/src/app/a/a.go:14:7",
+				"message": "self-assignment of x",
+				"suggested_fixes": [
+					{
+						"message": "Remove self-assignment",
+						"edits": [{"filename": "/src/app/a/a.go", "start": 167, "end": 174, "new": ""}]
+					}
+				]
+			}
+		],
+		"copylocks": [
+			{
+				"posn": "/src/app/a/a.go:9:11",
+				"message": "F passes lock by value: sync.Mutex",
+				"related": [{"posn": "/src/app/a/lock.go:3:6", "message": "lock declared here"}]
+			}
+		],
+		"printf": {"error": "analysis skipped due to errors in package"}
+	}
+}
+`
+
+const vetStderr = `# example.com/app/b
+vet: b/b.go:3:12: undefined: undefinedThing
+`
+
+func TestParseVetJSON(t *testing.T) {
+	issues, err := parseVetJSON("/src/app", []byte(vetJSONOutput), DefaultTaxonomy())
+	if err != nil {
+		t.Fatalf("parseVetJSON() error = %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("parseVetJSON() = %+v, want 3 issues", issues)
+	}
+
+	assign := issues[0]
+	if assign.Rule != "assign" || assign.File != "a/a.go" || assign.Line != 14 || assign.Column != 2 || assign.EndColumn != 7 {
+		t.Errorf("assign issue = %+v", assign)
+	}
+	if len(assign.Fixes) != 1 || assign.Fixes[0].Edits[0].File != "a/a.go" || assign.Suggestion != "Remove self-assignment" {
+		t.Errorf("assign fixes = %+v", assign.Fixes)
+	}
+
+	copylocks := issues[1]
+	if copylocks.Category != "concurrency" || len(copylocks.Related) != 1 || copylocks.Related[0].File != "a/lock.go" || copylocks.Related[0].Line != 3 {
+		t.Errorf("copylocks issue = %+v", copylocks)
+	}
+
+	if failed := issues[2]; failed.Rule != "printf" || failed.Severity != "error" || failed.Category != "build" {
+		t.Errorf("analyzer error issue = %+v", failed)
+	}
+}
+
+func TestParseVetBuildErrors(t *testing.T) {
+	issues := parseVetBuildErrors("/src/app", vetStderr)
+	if len(issues) != 1 {
+		t.Fatalf("parseVetBuildErrors() = %+v, want 1 issue", issues)
+	}
+	want := "undefined: undefinedThing"
+	if got := issues[0]; got.File != "b/b.go" || got.Line != 3 || got.Column != 12 || got.Severity != "error" || got.Message != want {
+		t.Errorf("build error issue = %+v", got)
+	}
+}
+
+func TestGoVetRun(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not found in PATH")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/vettest\n\ngo 1.21\n",
+		"a/a.go":  "package a\n\nimport \"fmt\"\n\nfunc F() {\n\tfmt.Printf(\"%d\\n\", \"x\")\n}\n",
+		"b/b.go":  "package b\n\nfunc G() { undefinedThing() }\n",
+		"c/ok.go": "package c\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	issues, err := NewGoVet(zap.NewNop()).Run(context.Background(), dir, "")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	rules := make(map[string]string)
+	for _, issue := range issues {
+		rules[issue.Rule] = issue.File
+	}
+	if rules["printf"] != "a/a.go" {
+		t.Errorf("Run() issues = %+v, want a printf issue in a/a.go", issues)
+	}
+	if rules["typecheck"] != "b/b.go" {
+		t.Errorf("Run() issues = %+v, want a typecheck error in b/b.go", issues)
+	}
+}
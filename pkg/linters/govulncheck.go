@@ -0,0 +1,343 @@
+package linters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go-standards-mcp-server/pkg/models"
+	"go.uber.org/zap"
+)
+
+// govulncheckRegistration registers govulncheck with the registry. It needs
+// the network unless a local vulnerability database is configured.
+var govulncheckRegistration = Registration{
+	Name:        "govulncheck",
+	Description: "Known vulnerabilities in dependencies reachable from the code",
+	Capabilities: Capabilities{
+		NeedsNetwork:   true,
+		VersionCommand: []string{"govulncheck", "-version"},
+	},
+	Configure: func(caps *Capabilities, opts Options) {
+		caps.NeedsNetwork = opts.VulnDBPath == ""
+	},
+	New: func(logger *zap.Logger, opts Options) (Linter, error) {
+		return NewGovulncheck(logger, opts.VulnDBPath)
+	},
+}
+
+// Govulncheck implements dependency vulnerability scanning with govulncheck
+type Govulncheck struct {
+	logger *zap.Logger
+	dbPath string // Local vulnerability database directory; empty uses the public database
+}
+
+// NewGovulncheck creates a new Govulncheck instance. dbPath is a local
+// vulnerability database in the format served by vuln.go.dev, which allows
+// scanning without network access.
+func NewGovulncheck(logger *zap.Logger, dbPath string) (*Govulncheck, error) {
+	g := &Govulncheck{
+		logger: logger,
+	}
+
+	if !g.IsAvailable() {
+		return nil, fmt.Errorf("govulncheck not found in PATH")
+	}
+
+	if dbPath != "" {
+		absPath, err := filepath.Abs(dbPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve vulnerability database path: %w", err)
+		}
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return nil, fmt.Errorf("vulnerability database not found: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("vulnerability database %s is not a directory", absPath)
+		}
+		g.dbPath = absPath
+	}
+
+	return g, nil
+}
+
+// Name returns the name of the linter
+func (g *Govulncheck) Name() string {
+	return "govulncheck"
+}
+
+// IsAvailable checks if govulncheck is available
+func (g *Govulncheck) IsAvailable() bool {
+	_, err := exec.LookPath("govulncheck")
+	return err == nil
+}
+
+// Run executes govulncheck. The lint config path does not apply.
+func (g *Govulncheck) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
+	args := []string{"-json"}
+	if g.dbPath != "" {
+		args = append(args, "-db", "file://"+filepath.ToSlash(g.dbPath))
+	}
+	args = append(args, "./...")
+
+	g.logger.Debug("Running govulncheck",
+		zap.String("workDir", workDir),
+		zap.String("db", g.dbPath))
+
+	result, err := runCommand(ctx, workDir, "govulncheck", args...)
+	if err != nil {
+		return nil, newRunError(g.Name(), result, err)
+	}
+
+	// In JSON mode govulncheck exits 0 when vulnerabilities are found, so a
+	// non-zero exit always means the scan itself failed
+	if result.ExitCode != 0 {
+		return nil, newRunError(g.Name(), result, fmt.Errorf("scan failed"))
+	}
+
+	issues, err := parseGovulncheckOutput(result.Stdout, workDir)
+	if err != nil {
+		return nil, newRunError(g.Name(), result, fmt.Errorf("failed to parse govulncheck output: %w", err))
+	}
+
+	g.logger.Debug("govulncheck completed",
+		zap.Int("issues", len(issues)))
+
+	return issues, nil
+}
+
+// ParseVersion extracts the scanner version from govulncheck -version output
+func (g *Govulncheck) ParseVersion(output string) string {
+	// Output contains "Scanner: govulncheck@v1.1.3" among other lines
+	for _, line := range strings.Split(output, "\n") {
+		if _, version, ok := strings.Cut(line, "govulncheck@"); ok {
+			return strings.TrimPrefix(strings.TrimSpace(version), "v")
+		}
+	}
+	return ParseVersion(output)
+}
+
+// govulncheckMessage is a single message of the govulncheck JSON stream
+type govulncheckMessage struct {
+	OSV     *govulncheckOSV     `json:"osv"`
+	Finding *govulncheckFinding `json:"finding"`
+}
+
+// govulncheckOSV is the subset of an OSV entry used for reporting
+type govulncheckOSV struct {
+	ID               string   `json:"id"`
+	Aliases          []string `json:"aliases"`
+	Summary          string   `json:"summary"`
+	Details          string   `json:"details"`
+	DatabaseSpecific struct {
+		URL string `json:"url"`
+	} `json:"database_specific"`
+}
+
+// govulncheckFinding is a vulnerability that affects the scanned code.
+// Findings are emitted with increasing precision: module, then package,
+// then symbol level when vulnerable code is reachable.
+type govulncheckFinding struct {
+	OSV          string             `json:"osv"`
+	FixedVersion string             `json:"fixed_version"`
+	Trace        []govulncheckFrame `json:"trace"`
+}
+
+// govulncheckFrame is one entry of a finding's trace. The first frame is
+// the vulnerable symbol, the last is the entry point in the scanned code.
+type govulncheckFrame struct {
+	Module   string               `json:"module"`
+	Version  string               `json:"version"`
+	Package  string               `json:"package"`
+	Function string               `json:"function"`
+	Receiver string               `json:"receiver"`
+	Position *govulncheckPosition `json:"position"`
+}
+
+// govulncheckPosition is a source position within a trace frame
+type govulncheckPosition struct {
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+// level returns how precise the finding is: 0 module, 1 package, 2 symbol
+func (f *govulncheckFinding) level() int {
+	if len(f.Trace) == 0 {
+		return 0
+	}
+	switch {
+	case f.Trace[0].Function != "":
+		return 2
+	case f.Trace[0].Package != "":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseGovulncheckOutput converts the govulncheck JSON stream into issues.
+// Reachable vulnerabilities are errors with one issue per call site;
+// vulnerabilities in imported packages or required modules whose code is
+// never called are reported once at lower severity.
+func parseGovulncheckOutput(output []byte, workDir string) ([]models.Issue, error) {
+	entries := make(map[string]*govulncheckOSV)
+	findings := make(map[string][]*govulncheckFinding)
+	var order []string
+
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var msg govulncheckMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		switch {
+		case msg.OSV != nil:
+			entries[msg.OSV.ID] = msg.OSV
+		case msg.Finding != nil:
+			if _, ok := findings[msg.Finding.OSV]; !ok {
+				order = append(order, msg.Finding.OSV)
+			}
+			findings[msg.Finding.OSV] = append(findings[msg.Finding.OSV], msg.Finding)
+		}
+	}
+
+	issues := []models.Issue{}
+	for _, id := range order {
+		// Keep only the most precise findings for each vulnerability
+		best := 0
+		for _, f := range findings[id] {
+			if l := f.level(); l > best {
+				best = l
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, f := range findings[id] {
+			if f.level() != best {
+				continue
+			}
+			issue := govulncheckIssue(entries[id], id, f, best, workDir)
+			key := fmt.Sprintf("%s:%d:%s", issue.File, issue.Line, issue.Vulnerability.Symbol)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			issues = append(issues, issue)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].File < issues[j].File
+	})
+
+	return issues, nil
+}
+
+// govulncheckIssue builds the issue for a single finding
+func govulncheckIssue(entry *govulncheckOSV, id string, f *govulncheckFinding, level int, workDir string) models.Issue {
+	vuln := &models.VulnerabilityInfo{
+		ID:           id,
+		FixedVersion: f.FixedVersion,
+	}
+	summary := id
+	if entry != nil {
+		vuln.Aliases = entry.Aliases
+		vuln.URL = entry.DatabaseSpecific.URL
+		if entry.Summary != "" {
+			summary = entry.Summary
+		}
+	}
+
+	if len(f.Trace) > 0 {
+		vulnerable := f.Trace[0]
+		vuln.Module = vulnerable.Module
+		vuln.FoundVersion = vulnerable.Version
+		vuln.Package = vulnerable.Package
+		vuln.Symbol = frameSymbol(vulnerable)
+	}
+
+	// The call stack runs from the entry point in the scanned code down to
+	// the vulnerable symbol
+	for i := len(f.Trace) - 1; i >= 0; i-- {
+		if f.Trace[i].Function != "" {
+			vuln.CallStack = append(vuln.CallStack, f.Trace[i].Package+"."+frameSymbol(f.Trace[i]))
+		}
+	}
+
+	issue := models.Issue{
+		File:          "go.mod",
+		Category:      "security",
+		Rule:          id,
+		Source:        "govulncheck",
+		Vulnerability: vuln,
+	}
+
+	switch level {
+	case 2:
+		issue.Severity = "error"
+		issue.Message = fmt.Sprintf("%s: %s (%s called)", id, summary, vuln.Symbol)
+		if pos := entryPosition(f.Trace); pos != nil {
+			issue.File = relativePath(workDir, pos.Filename)
+			issue.Line = pos.Line
+			issue.Column = pos.Column
+		}
+	case 1:
+		issue.Severity = "warning"
+		issue.Message = fmt.Sprintf("%s: %s (package %s imported, vulnerable code not called)", id, summary, vuln.Package)
+	default:
+		issue.Severity = "info"
+		issue.Message = fmt.Sprintf("%s: %s (module %s@%s required, vulnerable code not called)", id, summary, vuln.Module, vuln.FoundVersion)
+	}
+
+	if vuln.FixedVersion != "" {
+		issue.Suggestion = fmt.Sprintf("Upgrade %s to %s", vuln.Module, vuln.FixedVersion)
+	} else {
+		issue.Suggestion = fmt.Sprintf("No fixed version of %s is available yet", vuln.Module)
+	}
+
+	return issue
+}
+
+// entryPosition returns the position of the outermost frame in the trace
+// that has one, which is the call site in the scanned code
+func entryPosition(trace []govulncheckFrame) *govulncheckPosition {
+	for i := len(trace) - 1; i >= 0; i-- {
+		if trace[i].Position != nil && trace[i].Position.Filename != "" {
+			return trace[i].Position
+		}
+	}
+	return nil
+}
+
+// frameSymbol returns the function name of a frame, qualified with its receiver
+func frameSymbol(frame govulncheckFrame) string {
+	if frame.Receiver == "" {
+		return frame.Function
+	}
+	return strings.TrimPrefix(frame.Receiver, "*") + "." + frame.Function
+}
+
+// relativePath makes file relative to workDir when possible
+func relativePath(workDir, file string) string {
+	if !filepath.IsAbs(file) {
+		return filepath.ToSlash(file)
+	}
+	if rel, err := filepath.Rel(workDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(file)
+}
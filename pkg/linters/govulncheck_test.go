@@ -0,0 +1,69 @@
+package linters
+
+import (
+	"testing"
+)
+
+// govulncheckFixture is trimmed output of govulncheck -json for a project
+// that calls one vulnerable function and imports another vulnerable package
+const govulncheckFixture = `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck","scanner_version":"v1.1.3","db":"file:///vulndb","scan_level":"symbol"}}
+{"progress":{"message":"Scanning your code and 12 packages across 2 dependent modules for known vulnerabilities..."}}
+{"osv":{"id":"GO-2023-2102","aliases":["CVE-2023-39325"],"summary":"HTTP/2 rapid reset can cause excessive work in net/http","database_specific":{"url":"https://pkg.go.dev/vuln/GO-2023-2102"}}}
+{"osv":{"id":"GO-2022-0969","summary":"Denial of service in golang.org/x/text/language"}}
+{"finding":{"osv":"GO-2023-2102","fixed_version":"v0.17.0","trace":[{"module":"golang.org/x/net","version":"v0.7.0"}]}}
+{"finding":{"osv":"GO-2023-2102","fixed_version":"v0.17.0","trace":[{"module":"golang.org/x/net","version":"v0.7.0","package":"golang.org/x/net/http2"}]}}
+{"finding":{"osv":"GO-2023-2102","fixed_version":"v0.17.0","trace":[{"module":"golang.org/x/net","version":"v0.7.0","package":"golang.org/x/net/http2","function":"ServeConn","receiver":"*Server"},{"module":"example.com/app","package":"example.com/app","function":"main","position":{"filename":"/src/app/main.go","line":12,"column":14}}]}}
+{"finding":{"osv":"GO-2022-0969","fixed_version":"v0.3.8","trace":[{"module":"golang.org/x/text","version":"v0.3.7","package":"golang.org/x/text/language"}]}}
+`
+
+func TestParseGovulncheckOutput(t *testing.T) {
+	issues, err := parseGovulncheckOutput([]byte(govulncheckFixture), "/src/app")
+	if err != nil {
+		t.Fatalf("parseGovulncheckOutput() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("parseGovulncheckOutput() = %d issues, want 2: %+v", len(issues), issues)
+	}
+
+	tests := []struct {
+		id       string
+		file     string
+		line     int
+		severity string
+		symbol   string
+		stack    int
+		fixed    string
+	}{
+		{id: "GO-2022-0969", file: "go.mod", severity: "warning", fixed: "v0.3.8"},
+		{id: "GO-2023-2102", file: "main.go", line: 12, severity: "error", symbol: "Server.ServeConn", stack: 2, fixed: "v0.17.0"},
+	}
+
+	for i, tt := range tests {
+		issue := issues[i]
+		v := issue.Vulnerability
+		if v == nil {
+			t.Fatalf("Issue %d has no vulnerability info", i)
+		}
+		if v.ID != tt.id || issue.Rule != tt.id {
+			t.Errorf("Issue %d ID = %s, want %s", i, v.ID, tt.id)
+		}
+		if issue.File != tt.file || issue.Line != tt.line {
+			t.Errorf("Issue %d position = %s:%d, want %s:%d", i, issue.File, issue.Line, tt.file, tt.line)
+		}
+		if issue.Severity != tt.severity {
+			t.Errorf("Issue %d severity = %s, want %s", i, issue.Severity, tt.severity)
+		}
+		if issue.Category != "security" {
+			t.Errorf("Issue %d category = %s, want security", i, issue.Category)
+		}
+		if v.Symbol != tt.symbol {
+			t.Errorf("Issue %d symbol = %s, want %s", i, v.Symbol, tt.symbol)
+		}
+		if len(v.CallStack) != tt.stack {
+			t.Errorf("Issue %d call stack = %v, want %d frames", i, v.CallStack, tt.stack)
+		}
+		if v.FixedVersion != tt.fixed {
+			t.Errorf("Issue %d fixed version = %s, want %s", i, v.FixedVersion, tt.fixed)
+		}
+	}
+}
@@ -17,3 +17,18 @@ type Linter interface {
 	// IsAvailable checks if the linter is available on the system
 	IsAvailable() bool
 }
+
+// VersionParser is implemented by linters whose version command output
+// needs more than the first version-like token
+type VersionParser interface {
+	// ParseVersion extracts the tool version (e.g. "1.55.2") from the
+	// output of the version command
+	ParseVersion(output string) string
+}
+
+// Classifier is implemented by linters that categorize issues using a
+// taxonomy
+type Classifier interface {
+	// SetTaxonomy replaces the taxonomy used to classify issues
+	SetTaxonomy(t *Taxonomy)
+}
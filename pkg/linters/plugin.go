@@ -0,0 +1,371 @@
+package linters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"go-standards-mcp-server/pkg/models"
+	"go.uber.org/zap"
+)
+
+// Output formats understood by plugin linters
+const (
+	PluginFormatRegex      = "regex"
+	PluginFormatJSON       = "json"
+	PluginFormatCheckstyle = "checkstyle"
+	PluginFormatSARIF      = "sarif"
+)
+
+// PluginSpec defines an external command run as a linter. Arguments are
+// text/template strings rendered with PluginArgs; an argument that is
+// exactly {{.Files}} expands to one argument per Go file.
+type PluginSpec struct {
+	Name        string            `mapstructure:"name" json:"name"`
+	Command     string            `mapstructure:"command" json:"command"`
+	Args        []string          `mapstructure:"args" json:"args,omitempty"`
+	Timeout     time.Duration     `mapstructure:"timeout" json:"timeout,omitempty"`
+	Format      string            `mapstructure:"format" json:"format"`                       // regex, json, checkstyle, sarif
+	Stream      string            `mapstructure:"stream" json:"stream,omitempty"`             // stdout (default) or stderr
+	Pattern     string            `mapstructure:"pattern" json:"pattern,omitempty"`           // Regex with named groups file, line, column, severity, rule, message
+	JSON        JSONFieldMapping  `mapstructure:"json" json:"json,omitempty"`                 // Field mapping for the json format
+	ExitCodes   []int             `mapstructure:"exit_codes" json:"exit_codes,omitempty"`     // Exit codes that mean the run succeeded (default: 0 and 1)
+	Severity    string            `mapstructure:"severity" json:"severity,omitempty"`         // Severity when the output has none (default: warning)
+	SeverityMap map[string]string `mapstructure:"severity_map" json:"severity_map,omitempty"` // Tool severity to error, warning, or info
+	Category    string            `mapstructure:"category" json:"category,omitempty"`
+	VersionArgs []string          `mapstructure:"version_args" json:"version_args,omitempty"` // Arguments that make the command print its version
+}
+
+// JSONFieldMapping locates issue fields in JSON output using dot-separated
+// paths (e.g. "pos.filename")
+type JSONFieldMapping struct {
+	Issues   string `mapstructure:"issues" json:"issues,omitempty"` // Path to the issue array; empty for a top-level array or one object per line
+	File     string `mapstructure:"file" json:"file"`
+	Line     string `mapstructure:"line" json:"line,omitempty"`
+	Column   string `mapstructure:"column" json:"column,omitempty"`
+	Severity string `mapstructure:"severity" json:"severity,omitempty"`
+	Rule     string `mapstructure:"rule" json:"rule,omitempty"`
+	Message  string `mapstructure:"message" json:"message"`
+}
+
+// PluginArgs is the data available to argument templates
+type PluginArgs struct {
+	WorkDir    string
+	ConfigPath string
+	Files      FileList // Go files relative to WorkDir, excluding vendor and testdata
+}
+
+// FileList is a list of files that renders space-separated in templates
+type FileList []string
+
+// String implements fmt.Stringer
+func (f FileList) String() string {
+	return strings.Join(f, " ")
+}
+
+// filesArg is the argument that expands to one argument per file
+const filesArg = "{{.Files}}"
+
+// validSeverities lists the severities plugins may produce
+var validSeverities = map[string]bool{"error": true, "warning": true, "info": true}
+
+// Validate checks that the spec is well-formed
+func (s PluginSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("plugin name is required")
+	}
+	if s.Command == "" {
+		return fmt.Errorf("plugin %s: command is required", s.Name)
+	}
+	if s.Stream != "" && s.Stream != "stdout" && s.Stream != "stderr" {
+		return fmt.Errorf("plugin %s: invalid stream %q (must be stdout or stderr)", s.Name, s.Stream)
+	}
+	if s.Severity != "" && !validSeverities[s.Severity] {
+		return fmt.Errorf("plugin %s: invalid severity %q (must be error, warning, or info)", s.Name, s.Severity)
+	}
+	for from, to := range s.SeverityMap {
+		if !validSeverities[to] {
+			return fmt.Errorf("plugin %s: invalid severity %q for %q in severity_map", s.Name, to, from)
+		}
+	}
+	for _, arg := range s.Args {
+		if _, err := template.New("arg").Parse(arg); err != nil {
+			return fmt.Errorf("plugin %s: invalid argument template %q: %w", s.Name, arg, err)
+		}
+	}
+
+	switch s.Format {
+	case PluginFormatRegex:
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("plugin %s: invalid pattern: %w", s.Name, err)
+		}
+		if re.SubexpIndex("file") < 0 || re.SubexpIndex("message") < 0 {
+			return fmt.Errorf("plugin %s: pattern must have named groups file and message", s.Name)
+		}
+	case PluginFormatJSON:
+		if s.JSON.File == "" || s.JSON.Message == "" {
+			return fmt.Errorf("plugin %s: json mapping must set file and message", s.Name)
+		}
+	case PluginFormatCheckstyle, PluginFormatSARIF:
+	default:
+		return fmt.Errorf("plugin %s: invalid format %q (must be regex, json, checkstyle, or sarif)", s.Name, s.Format)
+	}
+
+	return nil
+}
+
+// PluginRegistration describes a plugin for the linter registry, deriving
+// its capabilities from the argument templates
+func PluginRegistration(spec PluginSpec) Registration {
+	caps := Capabilities{}
+	for _, arg := range spec.Args {
+		caps.FileScoped = caps.FileScoped || strings.Contains(arg, ".Files")
+		caps.NeedsConfig = caps.NeedsConfig || strings.Contains(arg, ".ConfigPath")
+	}
+	if len(spec.VersionArgs) > 0 {
+		caps.VersionCommand = append([]string{spec.Command}, spec.VersionArgs...)
+	}
+
+	return Registration{
+		Name:         spec.Name,
+		Description:  fmt.Sprintf("Plugin running %s", spec.Command),
+		Capabilities: caps,
+		New: func(logger *zap.Logger, opts Options) (Linter, error) {
+			return NewPlugin(logger, spec)
+		},
+	}
+}
+
+// Plugin runs an external command defined in config as a linter
+type Plugin struct {
+	logger  *zap.Logger
+	spec    PluginSpec
+	args    []*template.Template
+	pattern *regexp.Regexp
+}
+
+// NewPlugin creates a new Plugin from its spec
+func NewPlugin(logger *zap.Logger, spec PluginSpec) (*Plugin, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Plugin{
+		logger: logger,
+		spec:   spec,
+	}
+
+	for _, arg := range spec.Args {
+		p.args = append(p.args, template.Must(template.New("arg").Parse(arg)))
+	}
+	if spec.Format == PluginFormatRegex {
+		p.pattern = regexp.MustCompile(spec.Pattern)
+	}
+
+	if !p.IsAvailable() {
+		return nil, fmt.Errorf("%s not found in PATH", spec.Command)
+	}
+
+	return p, nil
+}
+
+// Name returns the name of the linter
+func (p *Plugin) Name() string {
+	return p.spec.Name
+}
+
+// Timeout returns the configured timeout, or zero if none is set
+func (p *Plugin) Timeout() time.Duration {
+	return p.spec.Timeout
+}
+
+// IsAvailable checks if the plugin command is available
+func (p *Plugin) IsAvailable() bool {
+	_, err := exec.LookPath(p.spec.Command)
+	return err == nil
+}
+
+// Run executes the plugin command and parses its output
+func (p *Plugin) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
+	args, err := p.renderArgs(workDir, configPath)
+	if err != nil {
+		return nil, &RunError{Linter: p.Name(), Err: err}
+	}
+
+	p.logger.Debug("Running plugin linter",
+		zap.String("name", p.Name()),
+		zap.String("workDir", workDir),
+		zap.Strings("args", args))
+
+	result, err := runCommand(ctx, workDir, p.spec.Command, args...)
+	if err != nil {
+		return nil, newRunError(p.Name(), result, err)
+	}
+	if !p.successExit(result.ExitCode) {
+		return nil, newRunError(p.Name(), result, fmt.Errorf("unexpected exit code"))
+	}
+
+	output := result.Stdout
+	if p.spec.Stream == "stderr" {
+		output = result.Stderr
+	}
+
+	raw, err := p.parse(output)
+	if err != nil {
+		return nil, newRunError(p.Name(), result, fmt.Errorf("failed to parse output: %w", err))
+	}
+
+	issues := make([]models.Issue, 0, len(raw))
+	for _, r := range raw {
+		issues = append(issues, p.toIssue(workDir, r))
+	}
+
+	p.logger.Debug("Plugin linter completed",
+		zap.String("name", p.Name()),
+		zap.Int("issues", len(issues)))
+
+	return issues, nil
+}
+
+// renderArgs renders the argument templates
+func (p *Plugin) renderArgs(workDir, configPath string) ([]string, error) {
+	data := PluginArgs{
+		WorkDir:    workDir,
+		ConfigPath: configPath,
+	}
+
+	for _, arg := range p.spec.Args {
+		if strings.Contains(arg, ".Files") {
+			files, err := goFiles(workDir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list files: %w", err)
+			}
+			data.Files = files
+			break
+		}
+	}
+
+	var args []string
+	for i, tmpl := range p.args {
+		if p.spec.Args[i] == filesArg {
+			args = append(args, data.Files...)
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render argument %q: %w", p.spec.Args[i], err)
+		}
+		args = append(args, buf.String())
+	}
+
+	return args, nil
+}
+
+// successExit reports whether the exit code means the command ran
+func (p *Plugin) successExit(code int) bool {
+	codes := p.spec.ExitCodes
+	if len(codes) == 0 {
+		// Most linters exit with 1 when they find issues
+		codes = []int{0, 1}
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// parse parses the output in the configured format
+func (p *Plugin) parse(output []byte) ([]rawIssue, error) {
+	switch p.spec.Format {
+	case PluginFormatRegex:
+		return parseRegexOutput(output, p.pattern), nil
+	case PluginFormatJSON:
+		return parseJSONOutput(output, p.spec.JSON)
+	case PluginFormatCheckstyle:
+		return parseCheckstyleOutput(output)
+	case PluginFormatSARIF:
+		return parseSARIFOutput(output)
+	}
+	return nil, fmt.Errorf("unsupported format %q", p.spec.Format)
+}
+
+// toIssue converts a parsed result into an issue
+func (p *Plugin) toIssue(workDir string, r rawIssue) models.Issue {
+	rule := r.Rule
+	if rule == "" {
+		rule = p.Name()
+	}
+	category := p.spec.Category
+	if category == "" {
+		category = "other"
+	}
+
+	return models.Issue{
+		File:     relativePath(workDir, r.File),
+		Line:     r.Line,
+		Column:   r.Column,
+		Severity: p.mapSeverity(r.Severity),
+		Category: category,
+		Rule:     rule,
+		Message:  r.Message,
+		Source:   p.Name(),
+	}
+}
+
+// mapSeverity maps a tool severity to our severity levels
+func (p *Plugin) mapSeverity(severity string) string {
+	if mapped, ok := p.spec.SeverityMap[severity]; ok {
+		return mapped
+	}
+
+	switch strings.ToLower(severity) {
+	case "error", "err", "fatal", "critical", "high":
+		return "error"
+	case "warning", "warn", "medium":
+		return "warning"
+	case "info", "note", "low", "hint", "suggestion":
+		return "info"
+	}
+
+	if p.spec.Severity != "" {
+		return p.spec.Severity
+	}
+	return "warning"
+}
+
+// goFiles lists the Go files under dir, relative to it
+func goFiles(dir string) (FileList, error) {
+	var files FileList
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".go" {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return files, err
+}
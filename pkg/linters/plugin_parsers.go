@@ -0,0 +1,269 @@
+package linters
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// rawIssue is a single result parsed from plugin output, before it is
+// mapped to a models.Issue
+type rawIssue struct {
+	File     string
+	Line     int
+	Column   int
+	Severity string
+	Rule     string
+	Message  string
+}
+
+// parseRegexOutput matches each output line against the pattern. Lines
+// that do not match are ignored.
+func parseRegexOutput(output []byte, pattern *regexp.Regexp) []rawIssue {
+	var issues []rawIssue
+
+	group := func(matches []string, name string) string {
+		if i := pattern.SubexpIndex(name); i >= 0 && i < len(matches) {
+			return strings.TrimSpace(matches[i])
+		}
+		return ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		matches := pattern.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+
+		line, _ := strconv.Atoi(group(matches, "line"))
+		column, _ := strconv.Atoi(group(matches, "column"))
+		issues = append(issues, rawIssue{
+			File:     group(matches, "file"),
+			Line:     line,
+			Column:   column,
+			Severity: group(matches, "severity"),
+			Rule:     group(matches, "rule"),
+			Message:  group(matches, "message"),
+		})
+	}
+
+	return issues
+}
+
+// parseJSONOutput extracts issues from JSON output using the field mapping.
+// The output may be a single document or a stream of documents (JSON lines).
+func parseJSONOutput(output []byte, mapping JSONFieldMapping) ([]rawIssue, error) {
+	var issues []rawIssue
+
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	decoder.UseNumber()
+	for {
+		var doc interface{}
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		items := doc
+		if mapping.Issues != "" {
+			items = lookupPath(doc, mapping.Issues)
+		}
+
+		list, ok := items.([]interface{})
+		if !ok {
+			if items == nil {
+				continue
+			}
+			list = []interface{}{items}
+		}
+
+		for _, item := range list {
+			issues = append(issues, rawIssue{
+				File:     jsonString(lookupPath(item, mapping.File)),
+				Line:     jsonInt(lookupPath(item, mapping.Line)),
+				Column:   jsonInt(lookupPath(item, mapping.Column)),
+				Severity: jsonString(lookupPath(item, mapping.Severity)),
+				Rule:     jsonString(lookupPath(item, mapping.Rule)),
+				Message:  jsonString(lookupPath(item, mapping.Message)),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// lookupPath returns the value at a dot-separated path, or nil. Numeric
+// segments index into arrays.
+func lookupPath(value interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			value = v[i]
+		default:
+			return nil
+		}
+	}
+	return value
+}
+
+// jsonString converts a JSON value to a string
+func jsonString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonInt converts a JSON number or numeric string to an int
+func jsonInt(value interface{}) int {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return int(f)
+		}
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
+
+// checkstyleReport is a Checkstyle XML report
+type checkstyleReport struct {
+	Files []struct {
+		Name   string `xml:"name,attr"`
+		Errors []struct {
+			Line     int    `xml:"line,attr"`
+			Column   int    `xml:"column,attr"`
+			Severity string `xml:"severity,attr"`
+			Message  string `xml:"message,attr"`
+			Source   string `xml:"source,attr"`
+		} `xml:"error"`
+	} `xml:"file"`
+}
+
+// parseCheckstyleOutput parses a Checkstyle XML report
+func parseCheckstyleOutput(output []byte) ([]rawIssue, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(output, &report); err != nil {
+		return nil, err
+	}
+
+	var issues []rawIssue
+	for _, file := range report.Files {
+		for _, e := range file.Errors {
+			issues = append(issues, rawIssue{
+				File:     file.Name,
+				Line:     e.Line,
+				Column:   e.Column,
+				Severity: e.Severity,
+				Rule:     e.Source,
+				Message:  e.Message,
+			})
+		}
+	}
+	return issues, nil
+}
+
+// sarifLog is the subset of a SARIF 2.1.0 log used for reporting
+type sarifLog struct {
+	Runs []struct {
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine   int `json:"startLine"`
+						StartColumn int `json:"startColumn"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// parseSARIFOutput parses a SARIF log. Results without a level default to
+// warning, as specified by SARIF.
+func parseSARIFOutput(output []byte) ([]rawIssue, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(output, &log); err != nil {
+		return nil, err
+	}
+
+	var issues []rawIssue
+	for _, run := range log.Runs {
+		for _, result := range run.Results {
+			issue := rawIssue{
+				Severity: result.Level,
+				Rule:     result.RuleID,
+				Message:  result.Message.Text,
+			}
+			if issue.Severity == "" {
+				issue.Severity = "warning"
+			}
+			if len(result.Locations) > 0 {
+				loc := result.Locations[0].PhysicalLocation
+				issue.File = sarifPath(loc.ArtifactLocation.URI)
+				issue.Line = loc.Region.StartLine
+				issue.Column = loc.Region.StartColumn
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// sarifPath converts a SARIF artifact URI to a file path
+func sarifPath(uri string) string {
+	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+		return u.Path
+	}
+	if unescaped, err := url.PathUnescape(uri); err == nil {
+		return unescaped
+	}
+	return uri
+}
@@ -0,0 +1,168 @@
+package linters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestPluginParsers(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   PluginSpec
+		output string
+		want   []rawIssue
+	}{
+		{
+			name: "regex",
+			spec: PluginSpec{
+				Format:  PluginFormatRegex,
+				Pattern: `^(?P<file>[^:]+):(?P<line>\d+):(?P<column>\d+):\s*(?P<message>.+)$`,
+			},
+			output: "main.go:12:3:\tos.Remove(path)\nnot an issue\n",
+			want:   []rawIssue{{File: "main.go", Line: 12, Column: 3, Message: "os.Remove(path)"}},
+		},
+		{
+			name: "json with mapping",
+			spec: PluginSpec{
+				Format: PluginFormatJSON,
+				JSON: JSONFieldMapping{
+					Issues:   "findings",
+					File:     "location.path",
+					Line:     "location.line",
+					Severity: "level",
+					Rule:     "check",
+					Message:  "text",
+				},
+			},
+			output: `{"findings": [{"location": {"path": "a.go", "line": 4}, "level": "high", "check": "X1", "text": "bad"}]}`,
+			want:   []rawIssue{{File: "a.go", Line: 4, Severity: "high", Rule: "X1", Message: "bad"}},
+		},
+		{
+			name: "json lines",
+			spec: PluginSpec{
+				Format: PluginFormatJSON,
+				JSON:   JSONFieldMapping{File: "file", Line: "line", Message: "msg"},
+			},
+			output: "{\"file\": \"a.go\", \"line\": \"7\", \"msg\": \"one\"}\n{\"file\": \"b.go\", \"line\": 8, \"msg\": \"two\"}\n",
+			want: []rawIssue{
+				{File: "a.go", Line: 7, Message: "one"},
+				{File: "b.go", Line: 8, Message: "two"},
+			},
+		},
+		{
+			name: "checkstyle",
+			spec: PluginSpec{Format: PluginFormatCheckstyle},
+			output: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="pkg/util.go">
+    <error line="9" column="6" severity="warning" message="exported function should have comment" source="exported"/>
+  </file>
+</checkstyle>`,
+			want: []rawIssue{{File: "pkg/util.go", Line: 9, Column: 6, Severity: "warning", Rule: "exported", Message: "exported function should have comment"}},
+		},
+		{
+			name: "sarif",
+			spec: PluginSpec{Format: PluginFormatSARIF},
+			output: `{"version": "2.1.0", "runs": [{"results": [
+				{"ruleId": "R1", "level": "error", "message": {"text": "broken"},
+				 "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file:///src/app/main.go"}, "region": {"startLine": 3, "startColumn": 1}}}]},
+				{"ruleId": "R2", "message": {"text": "no level"}}
+			]}]}`,
+			want: []rawIssue{
+				{File: "/src/app/main.go", Line: 3, Column: 1, Severity: "error", Rule: "R1", Message: "broken"},
+				{Severity: "warning", Rule: "R2", Message: "no level"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Name = "test"
+			tt.spec.Command = "true"
+			p, err := NewPlugin(zap.NewNop(), tt.spec)
+			if err != nil {
+				t.Fatalf("NewPlugin() error = %v", err)
+			}
+
+			got, err := p.parse([]byte(tt.output))
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parse() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Issue %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPluginRun(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "pkg/util.go", "vendor/x/x.go"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("package x\n"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	// Report one issue per file argument, then exit 1 as linters do
+	p, err := NewPlugin(zap.NewNop(), PluginSpec{
+		Name:     "fake",
+		Command:  "sh",
+		Args:     []string{"-c", `for f in "$@"; do echo "$f:1:1: [high] found"; done; exit 1`, "sh", "{{.Files}}"},
+		Format:   PluginFormatRegex,
+		Pattern:  `^(?P<file>[^:]+):(?P<line>\d+):(?P<column>\d+): \[(?P<severity>\w+)\] (?P<message>.+)$`,
+		Category: "style",
+	})
+	if err != nil {
+		t.Fatalf("NewPlugin() error = %v", err)
+	}
+
+	issues, err := p.Run(context.Background(), dir, "")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Run() = %+v, want 2 issues (vendor excluded)", issues)
+	}
+	for _, issue := range issues {
+		if issue.Severity != "error" || issue.Category != "style" || issue.Source != "fake" || issue.Rule != "fake" {
+			t.Errorf("Run() issue = %+v", issue)
+		}
+	}
+}
+
+func TestPluginSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    PluginSpec
+		wantErr bool
+	}{
+		{name: "valid sarif", spec: PluginSpec{Name: "a", Command: "a", Format: PluginFormatSARIF}},
+		{name: "missing command", spec: PluginSpec{Name: "a", Format: PluginFormatSARIF}, wantErr: true},
+		{name: "unknown format", spec: PluginSpec{Name: "a", Command: "a", Format: "xml"}, wantErr: true},
+		{name: "regex without message group", spec: PluginSpec{Name: "a", Command: "a", Format: PluginFormatRegex, Pattern: `(?P<file>.+)`}, wantErr: true},
+		{name: "json without mapping", spec: PluginSpec{Name: "a", Command: "a", Format: PluginFormatJSON}, wantErr: true},
+		{name: "bad template", spec: PluginSpec{Name: "a", Command: "a", Format: PluginFormatSARIF, Args: []string{"{{.Dir"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package linters
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Capabilities describes what a linter supports and what it needs to run
+type Capabilities struct {
+	SupportsFixes  bool     `json:"supports_fixes"`            // Reports suggested fixes
+	FileScoped     bool     `json:"file_scoped"`               // Lints individual files rather than whole packages
+	NeedsConfig    bool     `json:"needs_config"`              // Reads the standard's lint config
+	NeedsNetwork   bool     `json:"needs_network"`             // Contacts network services while running
+	VersionCommand []string `json:"version_command,omitempty"` // Command that prints the tool version
+}
+
+// Options carries the settings linter factories may need
+type Options struct {
+	VulnDBPath string // Local vulnerability database for govulncheck
+}
+
+// Factory creates a linter, returning an error if its tool is unavailable
+type Factory func(logger *zap.Logger, opts Options) (Linter, error)
+
+// Registration describes a linter that can be enabled by name
+type Registration struct {
+	Name         string
+	Description  string
+	Capabilities Capabilities
+	Configure    func(caps *Capabilities, opts Options) // Adjusts the capabilities that depend on the options; may be nil
+	New          Factory
+}
+
+// CapabilitiesFor returns the capabilities of the linter created with opts
+func (r Registration) CapabilitiesFor(opts Options) Capabilities {
+	caps := r.Capabilities
+	if r.Configure != nil {
+		r.Configure(&caps, opts)
+	}
+	return caps
+}
+
+// Registry holds the linters known to the server
+type Registry struct {
+	mu            sync.RWMutex
+	registrations map[string]Registration
+}
+
+// NewRegistry creates a registry containing the built-in linters
+func NewRegistry() *Registry {
+	r := &Registry{registrations: make(map[string]Registration)}
+	for _, reg := range []Registration{
+		golangciLintRegistration,
+		goVetRegistration,
+		staticcheckRegistration,
+		gosecRegistration,
+		govulncheckRegistration,
+	} {
+		r.registrations[reg.Name] = reg
+	}
+	return r
+}
+
+// Register adds a linter to the registry. Names must be unique.
+func (r *Registry) Register(reg Registration) error {
+	if reg.Name == "" || reg.New == nil {
+		return fmt.Errorf("linter registration requires a name and a factory")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.registrations[reg.Name]; exists {
+		return fmt.Errorf("linter %s is already registered", reg.Name)
+	}
+	r.registrations[reg.Name] = reg
+	return nil
+}
+
+// Get returns the registration for a linter
+func (r *Registry) Get(name string) (Registration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reg, ok := r.registrations[name]
+	return reg, ok
+}
+
+// Names returns the registered linter names in sorted order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.registrations))
+	for name := range r.registrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,62 @@
+package linters
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	want := []string{"golangci-lint", "gosec", "govet", "govulncheck", "staticcheck"}
+	if got := r.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	if err := r.Register(PluginRegistration(PluginSpec{Name: "govet", Command: "vet"})); err == nil {
+		t.Error("Register() should reject a name that is already registered")
+	}
+
+	spec := PluginSpec{
+		Name:        "revive",
+		Command:     "revive",
+		Args:        []string{"-config", "{{.ConfigPath}}", "{{.Files}}"},
+		VersionArgs: []string{"-version"},
+	}
+	if err := r.Register(PluginRegistration(spec)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	reg, ok := r.Get("revive")
+	if !ok {
+		t.Fatal("Get() did not find the registered plugin")
+	}
+	wantCaps := Capabilities{FileScoped: true, NeedsConfig: true, VersionCommand: []string{"revive", "-version"}}
+	if !reflect.DeepEqual(reg.Capabilities, wantCaps) {
+		t.Errorf("Capabilities = %+v, want %+v", reg.Capabilities, wantCaps)
+	}
+}
+
+func TestCapabilitiesFor(t *testing.T) {
+	reg, ok := NewRegistry().Get("govulncheck")
+	if !ok {
+		t.Fatal("govulncheck is not registered")
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want bool
+	}{
+		{"online database", Options{}, true},
+		{"offline database", Options{VulnDBPath: "/var/lib/vulndb"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reg.CapabilitiesFor(tt.opts).NeedsNetwork; got != tt.want {
+				t.Errorf("NeedsNetwork = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package linters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sandbox restricts the external commands run by linters. Limits are
+// applied per process with rlimits, the environment is replaced by a
+// minimal one, and each run gets a private HOME, build cache, module cache
+// and TMPDIR. There is no seccomp filter: processes may make any system
+// call their user may.
+type Sandbox struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	CPUTime    time.Duration `mapstructure:"cpu_time"`     // CPU time per process (RLIMIT_CPU)
+	MemoryMB   int           `mapstructure:"memory_mb"`    // Address space per process (RLIMIT_AS)
+	FileSizeMB int           `mapstructure:"file_size_mb"` // Largest file a process may write (RLIMIT_FSIZE)
+	OpenFiles  int           `mapstructure:"open_files"`   // Open file descriptors per process (RLIMIT_NOFILE)
+	GoFlags    string        `mapstructure:"goflags"`      // GOFLAGS for the go command (default: -mod=readonly); -modcacherw is always added
+	PassEnv    []string      `mapstructure:"pass_env"`     // Host environment variables to keep
+	Isolate    bool          `mapstructure:"isolate"`      // Linux only: run in new user, network, IPC and UTS namespaces
+	RootDir    string        `mapstructure:"root_dir"`     // Where private run directories are created (default: system temp dir)
+}
+
+// Validate checks that the limits are usable
+func (s Sandbox) Validate() error {
+	if s.CPUTime < 0 || (s.CPUTime > 0 && s.CPUTime < time.Second) {
+		return fmt.Errorf("cpu_time must be at least 1s")
+	}
+	if s.MemoryMB < 0 || s.FileSizeMB < 0 || s.OpenFiles < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	return nil
+}
+
+// Limits that can be exceeded inside the sandbox
+const (
+	LimitCPU      = "cpu"
+	LimitMemory   = "memory"
+	LimitFileSize = "file-size"
+	LimitTime     = "time"
+)
+
+// LimitError reports a command stopped for exceeding a sandbox limit
+type LimitError struct {
+	Limit string // cpu, memory, file-size or time
+	Value string // Configured limit, e.g. "30s" or "2048 MB"
+}
+
+// Error implements the error interface
+func (e *LimitError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("exceeded the sandbox %s limit", e.Limit)
+	}
+	return fmt.Sprintf("exceeded the sandbox %s limit (%s)", e.Limit, e.Value)
+}
+
+// sandboxKey is the context key for the active sandbox
+type sandboxKey struct{}
+
+// WithSandbox returns a context whose linter commands run in sb
+func WithSandbox(ctx context.Context, sb *Sandbox) context.Context {
+	return context.WithValue(ctx, sandboxKey{}, sb)
+}
+
+// sandboxFrom returns the sandbox attached to ctx, or nil
+func sandboxFrom(ctx context.Context) *Sandbox {
+	sb, _ := ctx.Value(sandboxKey{}).(*Sandbox)
+	if sb == nil || !sb.Enabled {
+		return nil
+	}
+	return sb
+}
+
+// Command returns a command that runs in the sandbox attached to ctx, or a
+// plain command if there is none. The returned cleanup must be called once
+// the command has exited.
+func Command(ctx context.Context, dir, name string, args ...string) (*exec.Cmd, func(), error) {
+	if sb := sandboxFrom(ctx); sb != nil {
+		return sb.command(ctx, dir, name, args...)
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	return cmd, func() {}, nil
+}
+
+// LimitExceeded returns a *LimitError if a command run with Command was
+// stopped by a limit of the sandbox attached to ctx, or nil otherwise
+func LimitExceeded(ctx context.Context, state *os.ProcessState, stderr []byte) error {
+	sb := sandboxFrom(ctx)
+	if sb == nil {
+		return nil
+	}
+	if limitErr := sb.limitError(state, stderr); limitErr != nil {
+		return limitErr
+	}
+	return nil
+}
+
+// command builds the sandboxed command. The command runs through sh so
+// that ulimit can set the rlimits before exec replaces the shell. The
+// returned cleanup removes the private run directory.
+func (s *Sandbox) command(ctx context.Context, dir, name string, args ...string) (*exec.Cmd, func(), error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runDir, err := os.MkdirTemp(s.RootDir, "sandbox-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create sandbox directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(runDir) }
+
+	// The command runs in dir, so the private directories must be absolute
+	if runDir, err = filepath.Abs(runDir); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to resolve sandbox directory: %w", err)
+	}
+
+	for _, sub := range []string{"home", "cache", "tmp"} {
+		if err := os.Mkdir(filepath.Join(runDir, sub), 0700); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to create sandbox directory: %w", err)
+		}
+	}
+
+	script := strings.Join(append(s.ulimits(), `exec "$@"`), " && ")
+	cmd := exec.CommandContext(ctx, "/bin/sh", append([]string{"-c", script, "sandbox", path}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = s.environ(runDir)
+
+	if s.Isolate {
+		if err := isolate(cmd); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+	}
+
+	return cmd, cleanup, nil
+}
+
+// cpuGrace is the number of seconds between the soft and hard CPU limits
+const cpuGrace = 5
+
+// ulimits returns the shell commands that apply the configured limits
+func (s *Sandbox) ulimits() []string {
+	var limits []string
+	if s.CPUTime > 0 {
+		// The soft limit raises SIGXCPU so the cause can be reported; the
+		// hard limit kills processes that ignore it with SIGKILL
+		seconds := int(s.CPUTime / time.Second)
+		limits = append(limits,
+			"ulimit -t "+strconv.Itoa(seconds+cpuGrace),
+			"ulimit -S -t "+strconv.Itoa(seconds))
+	}
+	if s.MemoryMB > 0 {
+		limits = append(limits, "ulimit -v "+strconv.Itoa(s.MemoryMB*1024))
+	}
+	if s.FileSizeMB > 0 {
+		// ulimit -f counts 512-byte blocks in POSIX shells
+		limits = append(limits, "ulimit -f "+strconv.Itoa(s.FileSizeMB*2048))
+	}
+	if s.OpenFiles > 0 {
+		limits = append(limits, "ulimit -n "+strconv.Itoa(s.OpenFiles))
+	}
+	return limits
+}
+
+// environ returns the scrubbed environment for a run. Only PATH and the
+// variables in PassEnv are taken from the host; the go command is kept on
+// the local toolchain. Modules are extracted into a private module cache
+// from the host's download cache, which serves as a read-only proxy, so a
+// run cannot change the modules later runs or the host build with.
+func (s *Sandbox) environ(runDir string) []string {
+	home := filepath.Join(runDir, "home")
+	cache := filepath.Join(runDir, "cache")
+	goflags := s.GoFlags
+	if goflags == "" {
+		goflags = "-mod=readonly"
+	}
+	// The private cache must stay writable so the run directory can be removed
+	goflags += " -modcacherw"
+
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + home,
+		"TMPDIR=" + filepath.Join(runDir, "tmp"),
+		"XDG_CACHE_HOME=" + cache,
+		"GOCACHE=" + filepath.Join(cache, "go-build"),
+		"GOLANGCI_LINT_CACHE=" + filepath.Join(cache, "golangci-lint"),
+		"GOPATH=" + filepath.Join(home, "go"),
+		"GOMODCACHE=" + filepath.Join(cache, "mod"),
+		"GOFLAGS=" + goflags,
+		"GOPROXY=" + moduleProxy(),
+		"GOSUMDB=off",
+		"GOTOOLCHAIN=local",
+		"GOTELEMETRY=off",
+		"CGO_ENABLED=0",
+	}
+	for _, name := range s.PassEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+// moduleProxy returns the GOPROXY of sandboxed runs: the download cache of
+// the host's module cache, which resolves dependencies without network
+// access, or off if there is none
+func moduleProxy() string {
+	dir := os.Getenv("GOMODCACHE")
+	if dir == "" {
+		gopath := filepath.SplitList(build.Default.GOPATH)
+		if len(gopath) == 0 {
+			return "off"
+		}
+		dir = filepath.Join(gopath[0], "pkg", "mod")
+	}
+	download, err := filepath.Abs(filepath.Join(dir, "cache", "download"))
+	if err != nil {
+		return "off"
+	}
+	if info, err := os.Stat(download); err != nil || !info.IsDir() {
+		return "off"
+	}
+	return "file://" + filepath.ToSlash(download)
+}
+
+// limitError explains why a sandboxed command stopped, or returns nil if
+// it did not hit a limit
+func (s *Sandbox) limitError(state *os.ProcessState, stderr []byte) *LimitError {
+	switch signalLimit(state) {
+	case LimitCPU:
+		return &LimitError{Limit: LimitCPU, Value: s.CPUTime.String()}
+	case LimitFileSize:
+		return &LimitError{Limit: LimitFileSize, Value: fmt.Sprintf("%d MB", s.FileSizeMB)}
+	}
+
+	// Exceeding RLIMIT_AS makes allocations fail rather than killing the
+	// process, so it is detected from the runtime's error output
+	if s.MemoryMB > 0 {
+		for _, marker := range []string{"out of memory", "cannot allocate memory"} {
+			if bytes.Contains(stderr, []byte(marker)) {
+				return &LimitError{Limit: LimitMemory, Value: fmt.Sprintf("%d MB", s.MemoryMB)}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package linters
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// isolate runs cmd in new user, network, IPC and UTS namespaces. The user
+// keeps its IDs inside the namespace but gains no privileges outside it,
+// and the command has no network access.
+func isolate(cmd *exec.Cmd) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+		UidMappings: []syscall.SysProcIDMap{
+			{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1},
+		},
+		GidMappings: []syscall.SysProcIDMap{
+			{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1},
+		},
+		GidMappingsEnableSetgroups: false,
+		Pdeathsig:                  syscall.SIGKILL,
+	}
+	return nil
+}
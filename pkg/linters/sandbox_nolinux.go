@@ -0,0 +1,13 @@
+//go:build !linux
+
+package linters
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// isolate is only supported on Linux
+func isolate(cmd *exec.Cmd) error {
+	return fmt.Errorf("sandbox isolation requires Linux namespaces")
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package linters
+
+import (
+	"os"
+)
+
+// signalLimit always returns an empty string, since rlimit signals only
+// exist on Unix systems
+func signalLimit(state *os.ProcessState) string {
+	return ""
+}
@@ -0,0 +1,152 @@
+package linters
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSandboxEnvironment(t *testing.T) {
+	t.Setenv("SANDBOX_TEST_SECRET", "hunter2")
+	t.Setenv("SANDBOX_TEST_PASSED", "ok")
+
+	sb := &Sandbox{Enabled: true, PassEnv: []string{"SANDBOX_TEST_PASSED"}, RootDir: t.TempDir()}
+	ctx := WithSandbox(context.Background(), sb)
+
+	cmd, cleanup, err := Command(ctx, t.TempDir(), "env")
+	if err != nil {
+		t.Fatalf("Command() error = %v", err)
+	}
+	defer cleanup()
+
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("env failed: %v", err)
+	}
+
+	env := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		if name, value, ok := strings.Cut(line, "="); ok {
+			env[name] = value
+		}
+	}
+
+	if _, ok := env["SANDBOX_TEST_SECRET"]; ok {
+		t.Errorf("SANDBOX_TEST_SECRET was passed to the sandbox")
+	}
+	if env["SANDBOX_TEST_PASSED"] != "ok" {
+		t.Errorf("SANDBOX_TEST_PASSED = %q, want ok", env["SANDBOX_TEST_PASSED"])
+	}
+	if home := os.Getenv("HOME"); home != "" && env["HOME"] == home {
+		t.Errorf("HOME = %q, want a private directory", env["HOME"])
+	}
+	for name, want := range map[string]string{"GOTOOLCHAIN": "local", "GOFLAGS": "-mod=readonly -modcacherw"} {
+		if env[name] != want {
+			t.Errorf("%s = %q, want %q", name, env[name], want)
+		}
+	}
+	if !strings.HasPrefix(env["GOMODCACHE"], sb.RootDir) {
+		t.Errorf("GOMODCACHE = %q, want a private directory under %s", env["GOMODCACHE"], sb.RootDir)
+	}
+	if proxy := env["GOPROXY"]; proxy != "off" && !strings.HasPrefix(proxy, "file://") {
+		t.Errorf("GOPROXY = %q, want off or the host's download cache", proxy)
+	}
+}
+
+func TestSandboxModuleCache(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+
+	// A host cache with one module in its download cache
+	host := t.TempDir()
+	download := filepath.Join(host, "cache", "download", "example.com", "dep", "@v")
+	if err := os.MkdirAll(download, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"list":        "v1.0.0\n",
+		"v1.0.0.info": `{"Version":"v1.0.0"}`,
+		"v1.0.0.mod":  "module example.com/dep\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(download, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeZip(t, filepath.Join(download, "v1.0.0.zip"), "example.com/dep@v1.0.0/dep.go", "package dep\n\nconst Answer = 42\n")
+	t.Setenv("GOMODCACHE", host)
+
+	project := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.21\n\nrequire example.com/dep v1.0.0\n",
+		"main.go": "package main\n\nimport \"example.com/dep\"\n\nfunc main() { println(dep.Answer) }\n",
+	} {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sb := &Sandbox{Enabled: true, RootDir: t.TempDir()}
+	cmd, cleanup, err := Command(WithSandbox(context.Background(), sb), project, "go", "mod", "download", "example.com/dep")
+	if err != nil {
+		t.Fatalf("Command() error = %v", err)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		cleanup()
+		t.Fatalf("go mod download failed: %v\n%s", err, output)
+	}
+	cleanup()
+
+	// The module was extracted into the run's own cache, which was removed
+	// with the run directory, not into the host's
+	if _, err := os.Stat(filepath.Join(host, "example.com")); !os.IsNotExist(err) {
+		t.Errorf("module was extracted into the host cache (err = %v)", err)
+	}
+	if entries, _ := os.ReadDir(sb.RootDir); len(entries) > 0 {
+		t.Errorf("run directory was not removed: %v", entries)
+	}
+}
+
+// writeZip writes a zip file holding one file
+func writeZip(t *testing.T, path, name, content string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSandboxCPULimit(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found in PATH")
+	}
+
+	sb := &Sandbox{Enabled: true, CPUTime: time.Second, RootDir: t.TempDir()}
+	ctx, cancel := context.WithTimeout(WithSandbox(context.Background(), sb), 30*time.Second)
+	defer cancel()
+
+	_, err := runCommand(ctx, t.TempDir(), "sh", "-c", "while :; do :; done")
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != LimitCPU {
+		t.Errorf("runCommand() error = %v, want a cpu LimitError", err)
+	}
+}
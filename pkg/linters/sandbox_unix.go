@@ -0,0 +1,27 @@
+//go:build unix
+
+package linters
+
+import (
+	"os"
+	"syscall"
+)
+
+// signalLimit maps the signal that killed a process to the rlimit that
+// raised it
+func signalLimit(state *os.ProcessState) string {
+	if state == nil {
+		return ""
+	}
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return ""
+	}
+	switch status.Signal() {
+	case syscall.SIGXCPU:
+		return LimitCPU
+	case syscall.SIGXFSZ:
+		return LimitFileSize
+	}
+	return ""
+}
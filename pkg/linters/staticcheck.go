@@ -0,0 +1,164 @@
+package linters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go-standards-mcp-server/pkg/models"
+	"go.uber.org/zap"
+)
+
+// staticcheckRegistration registers staticcheck with the registry
+var staticcheckRegistration = Registration{
+	Name:        "staticcheck",
+	Description: "Standalone staticcheck for bugs, simplifications and style",
+	Capabilities: Capabilities{
+		VersionCommand: []string{"staticcheck", "-version"},
+	},
+	New: func(logger *zap.Logger, opts Options) (Linter, error) {
+		return NewStaticcheck(logger)
+	},
+}
+
+// Staticcheck implements the standalone staticcheck linter
+type Staticcheck struct {
+	logger   *zap.Logger
+	taxonomy *Taxonomy
+}
+
+// NewStaticcheck creates a new Staticcheck instance
+func NewStaticcheck(logger *zap.Logger) (*Staticcheck, error) {
+	s := &Staticcheck{
+		logger:   logger,
+		taxonomy: DefaultTaxonomy(),
+	}
+
+	if !s.IsAvailable() {
+		return nil, fmt.Errorf("staticcheck not found in PATH")
+	}
+
+	return s, nil
+}
+
+// SetTaxonomy replaces the taxonomy used to classify issues
+func (s *Staticcheck) SetTaxonomy(t *Taxonomy) {
+	s.taxonomy = t
+}
+
+// Name returns the name of the linter
+func (s *Staticcheck) Name() string {
+	return "staticcheck"
+}
+
+// IsAvailable checks if staticcheck is available
+func (s *Staticcheck) IsAvailable() bool {
+	_, err := exec.LookPath("staticcheck")
+	return err == nil
+}
+
+// Run executes staticcheck. Checks are configured with staticcheck.conf
+// files in the project, so the golangci-lint config path does not apply.
+func (s *Staticcheck) Run(ctx context.Context, workDir, configPath string) ([]models.Issue, error) {
+	s.logger.Debug("Running staticcheck", zap.String("workDir", workDir))
+
+	result, err := runCommand(ctx, workDir, "staticcheck", "-f", "json", "./...")
+	if err != nil {
+		return nil, newRunError(s.Name(), result, err)
+	}
+
+	problems, err := parseStaticcheckOutput(result.Stdout)
+	if err != nil {
+		return nil, newRunError(s.Name(), result, fmt.Errorf("failed to parse output: %w", err))
+	}
+
+	// staticcheck exits with code 1 when problems are found; any other
+	// failure leaves stdout empty
+	if result.ExitCode != 0 && len(problems) == 0 {
+		return nil, newRunError(s.Name(), result, fmt.Errorf("no output produced"))
+	}
+
+	issues := make([]models.Issue, 0, len(problems))
+	for _, p := range problems {
+		class := s.taxonomy.Classify("staticcheck", p.Code)
+		issues = append(issues, models.Issue{
+			File:        s.relativePath(workDir, p.Location.File),
+			Line:        p.Location.Line,
+			Column:      p.Location.Column,
+			Severity:    s.mapSeverity(p.Code),
+			Category:    class.Category,
+			Subcategory: class.Subcategory,
+			Rule:        p.Code,
+			Message:     p.Message,
+			Source:      "staticcheck",
+		})
+	}
+
+	s.logger.Debug("staticcheck completed", zap.Int("issues", len(issues)))
+	return issues, nil
+}
+
+// relativePath returns a relative path if possible
+func (s *Staticcheck) relativePath(base, target string) string {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return target
+	}
+	return rel
+}
+
+// mapSeverity maps a staticcheck check code to our severity levels. Build
+// failures are errors, correctness checks (SA) and unused code are
+// warnings, and simplifications, style checks and quick fixes are info.
+func (s *Staticcheck) mapSeverity(code string) string {
+	switch {
+	case code == "compile":
+		return "error"
+	case strings.HasPrefix(code, "SA"), strings.HasPrefix(code, "U1"):
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// parseStaticcheckOutput parses the stream of JSON objects written by
+// staticcheck -f json
+func parseStaticcheckOutput(output []byte) ([]StaticcheckProblem, error) {
+	var problems []StaticcheckProblem
+
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var p StaticcheckProblem
+		if err := decoder.Decode(&p); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		problems = append(problems, p)
+	}
+
+	return problems, nil
+}
+
+// StaticcheckProblem represents a single problem from staticcheck
+type StaticcheckProblem struct {
+	Code     string              `json:"code"`
+	Severity string              `json:"severity"`
+	Location StaticcheckLocation `json:"location"`
+	End      StaticcheckLocation `json:"end"`
+	Message  string              `json:"message"`
+}
+
+// StaticcheckLocation represents a position in the source code
+type StaticcheckLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
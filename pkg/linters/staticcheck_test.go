@@ -0,0 +1,40 @@
+package linters
+
+import (
+	"testing"
+)
+
+func TestParseStaticcheckOutput(t *testing.T) {
+	output := `{"code":"SA4006","severity":"error","location":{"file":"/src/app/main.go","line":5,"column":2},"end":{"file":"/src/app/main.go","line":5,"column":3},"message":"this value of x is never used"}
+{"code":"ST1003","severity":"error","location":{"file":"/src/app/util.go","line":9,"column":6},"end":{"file":"/src/app/util.go","line":9,"column":12},"message":"func getUrl should be getURL"}
+`
+	problems, err := parseStaticcheckOutput([]byte(output))
+	if err != nil {
+		t.Fatalf("parseStaticcheckOutput() error = %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("parseStaticcheckOutput() = %d problems, want 2", len(problems))
+	}
+
+	s := &Staticcheck{taxonomy: DefaultTaxonomy()}
+	tests := []struct {
+		code     string
+		severity string
+		category string
+	}{
+		{code: "SA4006", severity: "warning", category: "logic"},
+		{code: "ST1003", severity: "info", category: "style"},
+	}
+	for i, tt := range tests {
+		p := problems[i]
+		if p.Code != tt.code || p.Location.Line == 0 {
+			t.Errorf("Problem %d = %+v, want code %s", i, p, tt.code)
+		}
+		if got := s.mapSeverity(p.Code); got != tt.severity {
+			t.Errorf("mapSeverity(%s) = %s, want %s", p.Code, got, tt.severity)
+		}
+		if got := s.taxonomy.Classify("staticcheck", p.Code).Category; got != tt.category {
+			t.Errorf("Classify(%s) = %s, want %s", p.Code, got, tt.category)
+		}
+	}
+}
@@ -0,0 +1,614 @@
+package linters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Classification is the category and optional subcategory of an issue
+type Classification struct {
+	Category    string `json:"category"`
+	Subcategory string `json:"subcategory,omitempty"`
+}
+
+// TaxonomyOverrides customizes the built-in taxonomy. Values are
+// "category" or "category/subcategory". Check keys are "linter/check",
+// where check is a check name (e.g. gocritic/hugeParam) or a code prefix
+// (e.g. staticcheck/SA1, gosec/G4).
+type TaxonomyOverrides struct {
+	Linters map[string]string `mapstructure:"linters" json:"linters,omitempty"`
+	Checks  map[string]string `mapstructure:"checks" json:"checks,omitempty"`
+}
+
+// Validate checks that every override is well-formed
+func (o TaxonomyOverrides) Validate() error {
+	for linter, value := range o.Linters {
+		if _, err := parseClassification(value); err != nil {
+			return fmt.Errorf("taxonomy linter %s: %w", linter, err)
+		}
+	}
+	for key, value := range o.Checks {
+		if linter, check, ok := strings.Cut(key, "/"); !ok || linter == "" || check == "" {
+			return fmt.Errorf("taxonomy check %q: key must be linter/check", key)
+		}
+		if _, err := parseClassification(value); err != nil {
+			return fmt.Errorf("taxonomy check %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Taxonomy classifies issues by linter and, for linters that run many
+// checks, by check name or code prefix. Lookups are case-insensitive.
+type Taxonomy struct {
+	linters map[string]Classification
+	checks  map[string]map[string]Classification // Linter to check or code prefix
+}
+
+// DefaultTaxonomy returns the built-in taxonomy
+func DefaultTaxonomy() *Taxonomy {
+	t := &Taxonomy{
+		linters: make(map[string]Classification),
+		checks:  make(map[string]map[string]Classification),
+	}
+	for linter, value := range linterCategories {
+		t.linters[linter] = mustClassification(value)
+	}
+	for linter, checks := range checkCategories {
+		for check, value := range checks {
+			t.setCheck(linter, check, mustClassification(value))
+		}
+	}
+	return t
+}
+
+// WithOverrides returns a copy of the taxonomy with overrides applied
+func (t *Taxonomy) WithOverrides(o TaxonomyOverrides) (*Taxonomy, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Taxonomy{
+		linters: make(map[string]Classification, len(t.linters)),
+		checks:  make(map[string]map[string]Classification, len(t.checks)),
+	}
+	for linter, class := range t.linters {
+		c.linters[linter] = class
+	}
+	for linter, checks := range t.checks {
+		for check, class := range checks {
+			c.setCheck(linter, check, class)
+		}
+	}
+
+	for linter, value := range o.Linters {
+		c.linters[strings.ToLower(linter)] = mustClassification(value)
+	}
+	for key, value := range o.Checks {
+		linter, check, _ := strings.Cut(key, "/")
+		c.setCheck(linter, check, mustClassification(value))
+	}
+	return c, nil
+}
+
+// Classify returns the classification of an issue from linter. check is
+// the check name or code reported by multi-check linters and may be empty.
+// Unknown linters are classified as "other".
+func (t *Taxonomy) Classify(linter, check string) Classification {
+	linter = strings.ToLower(linter)
+	if checks, ok := t.checks[linter]; ok && check != "" {
+		check = strings.ToLower(check)
+		if class, ok := checks[check]; ok {
+			return class
+		}
+
+		// Fall back to the longest matching code prefix (SA4006 -> SA4)
+		best := ""
+		for prefix := range checks {
+			if len(prefix) > len(best) && strings.HasPrefix(check, prefix) {
+				best = prefix
+			}
+		}
+		if best != "" {
+			return checks[best]
+		}
+	}
+
+	if class, ok := t.linters[linter]; ok {
+		return class
+	}
+	return Classification{Category: "other"}
+}
+
+// HasChecks reports whether the taxonomy classifies linter by check
+func (t *Taxonomy) HasChecks(linter string) bool {
+	_, ok := t.checks[strings.ToLower(linter)]
+	return ok
+}
+
+// setCheck sets the classification of a check
+func (t *Taxonomy) setCheck(linter, check string, class Classification) {
+	linter = strings.ToLower(linter)
+	if t.checks[linter] == nil {
+		t.checks[linter] = make(map[string]Classification)
+	}
+	t.checks[linter][strings.ToLower(check)] = class
+}
+
+// checkFromText extracts the check name that golangci-lint prefixes to
+// messages of multi-check linters, e.g. "hugeParam: cfg is heavy"
+func checkFromText(text string) string {
+	check, _, ok := strings.Cut(text, ": ")
+	if !ok || check == "" || strings.ContainsAny(check, " \t") {
+		return ""
+	}
+	return check
+}
+
+// parseClassification parses "category" or "category/subcategory"
+func parseClassification(value string) (Classification, error) {
+	category, subcategory, _ := strings.Cut(strings.TrimSpace(value), "/")
+	if category == "" {
+		return Classification{}, fmt.Errorf("category is required")
+	}
+	return Classification{Category: category, Subcategory: subcategory}, nil
+}
+
+// mustClassification parses a classification known to be valid
+func mustClassification(value string) Classification {
+	class, err := parseClassification(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid classification %q: %v", value, err))
+	}
+	return class
+}
+
+// linterCategories classifies golangci-lint linters and the standalone
+// tools. Multi-check linters also have entries in checkCategories.
+var linterCategories = map[string]string{
+	// Formatting
+	"gci":       "format",
+	"gofmt":     "format",
+	"gofumpt":   "format",
+	"goimports": "format",
+	"golines":   "format",
+	"swaggo":    "format",
+
+	// Correctness
+	"asasalint":         "logic",
+	"bodyclose":         "logic/resource-leak",
+	"contextcheck":      "logic/context",
+	"containedctx":      "logic/context",
+	"copyloopvar":       "logic/loop-variable",
+	"durationcheck":     "logic",
+	"exhaustive":        "logic/exhaustiveness",
+	"exhaustruct":       "logic/exhaustiveness",
+	"exportloopref":     "logic/loop-variable",
+	"gochecksumtype":    "logic/exhaustiveness",
+	"govet":             "logic",
+	"loggercheck":       "logic/logging",
+	"makezero":          "logic",
+	"musttag":           "logic",
+	"noctx":             "logic/context",
+	"nosprintfhostport": "logic",
+	"protogetter":       "logic",
+	"reassign":          "logic",
+	"spancheck":         "logic/tracing",
+	"sqlclosecheck":     "logic/resource-leak",
+	"staticcheck":       "logic",
+	"typecheck":         "build",
+	"zerologlint":       "logic/logging",
+
+	// Error handling
+	"err113":          "error-handling",
+	"errcheck":        "error-handling/unchecked",
+	"errchkjson":      "error-handling/unchecked",
+	"errname":         "error-handling/naming",
+	"errorlint":       "error-handling/wrapping",
+	"forcetypeassert": "error-handling",
+	"goerr113":        "error-handling",
+	"nilerr":          "error-handling",
+	"nilnesserr":      "error-handling",
+	"nilnil":          "error-handling",
+	"rowserrcheck":    "error-handling/unchecked",
+	"wrapcheck":       "error-handling/wrapping",
+
+	// Security
+	"bidichk":     "security",
+	"gosec":       "security",
+	"govulncheck": "security/vulnerability",
+
+	// Performance
+	"fatcontext":  "performance",
+	"ineffassign": "performance",
+	"mirror":      "performance/allocation",
+	"perfsprint":  "performance/allocation",
+	"prealloc":    "performance/allocation",
+
+	// Dead code
+	"deadcode":     "dead-code",
+	"structcheck":  "dead-code",
+	"unparam":      "dead-code/unused-parameter",
+	"unused":       "dead-code",
+	"varcheck":     "dead-code",
+	"wastedassign": "dead-code",
+
+	// Complexity
+	"cyclop":   "complexity",
+	"funlen":   "complexity/length",
+	"gocognit": "complexity",
+	"gocyclo":  "complexity",
+	"maintidx": "complexity",
+	"nestif":   "complexity/nesting",
+
+	// Duplication
+	"dupl": "duplication",
+
+	// Maintainability
+	"depguard":         "maintainability/dependencies",
+	"forbidigo":        "maintainability",
+	"gochecknoglobals": "maintainability/globals",
+	"gochecknoinits":   "maintainability/globals",
+	"goconst":          "maintainability",
+	"godox":            "maintainability/todo",
+	"gomnd":            "maintainability/magic-numbers",
+	"gomoddirectives":  "maintainability/dependencies",
+	"gomodguard":       "maintainability/dependencies",
+	"iface":            "maintainability/interfaces",
+	"interfacebloat":   "maintainability/interfaces",
+	"ireturn":          "maintainability/interfaces",
+	"mnd":              "maintainability/magic-numbers",
+	"nolintlint":       "maintainability/directives",
+
+	// Testing
+	"ginkgolinter":     "testing",
+	"paralleltest":     "testing/parallel",
+	"testableexamples": "testing",
+	"testifylint":      "testing",
+	"testpackage":      "testing",
+	"thelper":          "testing/helpers",
+	"tparallel":        "testing/parallel",
+	"usetesting":       "testing",
+
+	// Style
+	"asciicheck":               "style",
+	"canonicalheader":          "style",
+	"decorder":                 "style/ordering",
+	"dogsled":                  "style",
+	"dupword":                  "style/spelling",
+	"embeddedstructfieldcheck": "style",
+	"exptostd":                 "style/modernization",
+	"funcorder":                "style/ordering",
+	"gocritic":                 "style",
+	"godot":                    "style/comments",
+	"goheader":                 "style/comments",
+	"goprintffuncname":         "style/naming",
+	"gosimple":                 "style/simplification",
+	"gosmopolitan":             "style",
+	"grouper":                  "style/ordering",
+	"importas":                 "style/imports",
+	"inamedparam":              "style/naming",
+	"intrange":                 "style/modernization",
+	"lll":                      "style/line-length",
+	"misspell":                 "style/spelling",
+	"nakedret":                 "style",
+	"nlreturn":                 "style/whitespace",
+	"nonamedreturns":           "style",
+	"predeclared":              "style/naming",
+	"promlinter":               "style/naming",
+	"recvcheck":                "style",
+	"revive":                   "style",
+	"sloglint":                 "style/logging",
+	"stylecheck":               "style",
+	"tagalign":                 "style/struct-tags",
+	"tagliatelle":              "style/struct-tags",
+	"unconvert":                "style/simplification",
+	"usestdlibvars":            "style",
+	"varnamelen":               "style/naming",
+	"whitespace":               "style/whitespace",
+	"wsl":                      "style/whitespace",
+	"wsl_v5":                   "style/whitespace",
+}
+
+// staticcheckChecks classifies staticcheck codes by prefix. They are shared
+// by the gosimple and stylecheck linters of golangci-lint v1.
+var staticcheckChecks = map[string]string{
+	"SA1":     "logic/api-misuse",
+	"SA2":     "concurrency",
+	"SA3":     "testing",
+	"SA4":     "logic/ineffective-code",
+	"SA5":     "logic/correctness",
+	"SA6":     "performance",
+	"SA9":     "logic/suspicious",
+	"S1":      "style/simplification",
+	"ST1":     "style",
+	"ST1000":  "documentation/package-comment",
+	"ST1020":  "documentation/comments",
+	"ST1021":  "documentation/comments",
+	"ST1022":  "documentation/comments",
+	"ST1003":  "style/naming",
+	"ST1005":  "error-handling/naming",
+	"ST1012":  "error-handling/naming",
+	"QF1":     "style/quickfix",
+	"U1":      "dead-code",
+	"compile": "build",
+}
+
+// checkCategories classifies the checks of multi-check linters
+var checkCategories = map[string]map[string]string{
+	"staticcheck": staticcheckChecks,
+	"gosimple":    staticcheckChecks,
+	"stylecheck":  staticcheckChecks,
+
+	"gosec": {
+		"G1":   "security/general",
+		"G101": "security/hardcoded-credentials",
+		"G102": "security/network",
+		"G103": "security/unsafe",
+		"G104": "error-handling/unchecked",
+		"G106": "security/crypto",
+		"G107": "security/ssrf",
+		"G108": "security/information-exposure",
+		"G109": "security/integer-overflow",
+		"G110": "security/denial-of-service",
+		"G111": "security/path-traversal",
+		"G112": "security/denial-of-service",
+		"G114": "security/denial-of-service",
+		"G115": "security/integer-overflow",
+		"G2":   "security/injection",
+		"G201": "security/sql-injection",
+		"G202": "security/sql-injection",
+		"G203": "security/xss",
+		"G204": "security/command-injection",
+		"G3":   "security/filesystem",
+		"G304": "security/path-traversal",
+		"G305": "security/path-traversal",
+		"G4":   "security/crypto",
+		"G402": "security/tls",
+		"G404": "security/weak-random",
+		"G5":   "security/blocklisted-import",
+		"G6":   "security/memory",
+		"G601": "logic/loop-variable",
+		"G602": "security/bounds",
+	},
+
+	"govet": {
+		"appends":          "logic",
+		"asmdecl":          "logic",
+		"assign":           "logic",
+		"atomic":           "concurrency",
+		"bools":            "logic",
+		"buildtag":         "build",
+		"cgocall":          "logic",
+		"composites":       "style",
+		"copylocks":        "concurrency",
+		"defers":           "logic",
+		"directive":        "build",
+		"errorsas":         "error-handling",
+		"fieldalignment":   "performance/memory",
+		"framepointer":     "logic",
+		"hostport":         "logic",
+		"httpresponse":     "error-handling",
+		"ifaceassert":      "logic",
+		"loopclosure":      "concurrency",
+		"lostcancel":       "concurrency/context",
+		"nilfunc":          "logic",
+		"nilness":          "logic",
+		"printf":           "format/printf",
+		"shadow":           "logic/shadowing",
+		"shift":            "logic",
+		"sigchanyzer":      "concurrency",
+		"slog":             "format/logging",
+		"stdmethods":       "logic",
+		"stdversion":       "build",
+		"stringintconv":    "logic",
+		"structtag":        "style/struct-tags",
+		"testinggoroutine": "testing",
+		"tests":            "testing",
+		"timeformat":       "format",
+		"unmarshal":        "logic",
+		"unreachable":      "dead-code",
+		"unsafeptr":        "security/unsafe",
+		"unusedresult":     "logic",
+		"unusedwrite":      "dead-code",
+		"waitgroup":        "concurrency",
+	},
+
+	"gocritic": {
+		// Diagnostic checks find likely bugs
+		"appendAssign":          "logic/diagnostic",
+		"argOrder":              "logic/diagnostic",
+		"badCall":               "logic/diagnostic",
+		"badCond":               "logic/diagnostic",
+		"badLock":               "concurrency/diagnostic",
+		"badRegexp":             "logic/diagnostic",
+		"badSorting":            "logic/diagnostic",
+		"badSyncOnceFunc":       "concurrency/diagnostic",
+		"builtinShadowDecl":     "logic/diagnostic",
+		"caseOrder":             "logic/diagnostic",
+		"codegenComment":        "logic/diagnostic",
+		"commentedOutCode":      "maintainability/diagnostic",
+		"deferInLoop":           "logic/diagnostic",
+		"deprecatedComment":     "documentation/diagnostic",
+		"dupArg":                "logic/diagnostic",
+		"dupBranchBody":         "logic/diagnostic",
+		"dupCase":               "logic/diagnostic",
+		"dupSubExpr":            "logic/diagnostic",
+		"dynamicFmtString":      "format/diagnostic",
+		"emptyDecl":             "logic/diagnostic",
+		"evalOrder":             "logic/diagnostic",
+		"exitAfterDefer":        "logic/diagnostic",
+		"externalErrorReassign": "error-handling/diagnostic",
+		"filepathJoin":          "logic/diagnostic",
+		"flagDeref":             "logic/diagnostic",
+		"flagName":              "logic/diagnostic",
+		"mapKey":                "logic/diagnostic",
+		"nilValReturn":          "error-handling/diagnostic",
+		"offBy1":                "logic/diagnostic",
+		"rangeAppendAll":        "logic/diagnostic",
+		"regexpPattern":         "logic/diagnostic",
+		"returnAfterHttpError":  "error-handling/diagnostic",
+		"sloppyLen":             "logic/diagnostic",
+		"sloppyReassign":        "error-handling/diagnostic",
+		"sloppyTypeAssert":      "logic/diagnostic",
+		"sortSlice":             "logic/diagnostic",
+		"sprintfQuotedString":   "format/diagnostic",
+		"sqlQuery":              "logic/diagnostic",
+		"syncMapLoadAndDelete":  "concurrency/diagnostic",
+		"truncateCmp":           "logic/diagnostic",
+		"uncheckedInlineErr":    "error-handling/diagnostic",
+		"unnecessaryDefer":      "logic/diagnostic",
+		"weakCond":              "logic/diagnostic",
+
+		// Performance checks
+		"appendCombine":      "performance/gocritic",
+		"equalFold":          "performance/gocritic",
+		"hugeParam":          "performance/gocritic",
+		"indexAlloc":         "performance/gocritic",
+		"preferDecodeRune":   "performance/gocritic",
+		"preferFprint":       "performance/gocritic",
+		"preferStringWriter": "performance/gocritic",
+		"preferWriteByte":    "performance/gocritic",
+		"rangeExprCopy":      "performance/gocritic",
+		"rangeValCopy":       "performance/gocritic",
+		"sliceClear":         "performance/gocritic",
+		"stringXbytes":       "performance/gocritic",
+
+		// Style checks
+		"assignOp":                 "style/gocritic",
+		"boolExprSimplify":         "style/simplification",
+		"builtinShadow":            "style/naming",
+		"captLocal":                "style/naming",
+		"commentFormatting":        "style/comments",
+		"commentedOutImport":       "style/imports",
+		"defaultCaseOrder":         "style/gocritic",
+		"deferUnlambda":            "style/simplification",
+		"docStub":                  "documentation/comments",
+		"dupImport":                "style/imports",
+		"elseif":                   "style/control-flow",
+		"emptyFallthrough":         "style/control-flow",
+		"emptyStringTest":          "style/gocritic",
+		"exposedSyncMutex":         "style/gocritic",
+		"hexLiteral":               "style/gocritic",
+		"httpNoBody":               "style/gocritic",
+		"ifElseChain":              "style/control-flow",
+		"importShadow":             "style/naming",
+		"initClause":               "style/control-flow",
+		"methodExprCall":           "style/gocritic",
+		"nestingReduce":            "complexity/nesting",
+		"newDeref":                 "style/simplification",
+		"octalLiteral":             "style/gocritic",
+		"paramTypeCombine":         "style/gocritic",
+		"preferFilepathJoin":       "style/gocritic",
+		"ptrToRefParam":            "style/gocritic",
+		"redundantSprint":          "style/simplification",
+		"regexpMust":               "style/gocritic",
+		"regexpSimplify":           "style/simplification",
+		"ruleguard":                "style/gocritic",
+		"singleCaseSwitch":         "style/control-flow",
+		"stringConcatSimplify":     "style/simplification",
+		"stringsCompare":           "style/gocritic",
+		"switchTrue":               "style/control-flow",
+		"timeExprSimplify":         "style/simplification",
+		"todoCommentWithoutDetail": "maintainability/todo",
+		"tooManyResultsChecker":    "complexity",
+		"typeAssertChain":          "style/control-flow",
+		"typeDefFirst":             "style/ordering",
+		"typeSwitchVar":            "style/gocritic",
+		"typeUnparen":              "style/simplification",
+		"underef":                  "style/simplification",
+		"unlabelStmt":              "style/control-flow",
+		"unlambda":                 "style/simplification",
+		"unnamedResult":            "style/naming",
+		"unnecessaryBlock":         "style/gocritic",
+		"unslice":                  "style/simplification",
+		"valSwap":                  "style/gocritic",
+		"whyNoLint":                "maintainability/directives",
+		"wrapperFunc":              "style/gocritic",
+		"yodaStyleExpr":            "style/gocritic",
+	},
+
+	"revive": {
+		"add-constant":                    "maintainability/magic-numbers",
+		"argument-limit":                  "complexity",
+		"atomic":                          "concurrency",
+		"banned-characters":               "style",
+		"bare-return":                     "style",
+		"blank-imports":                   "style/imports",
+		"bool-literal-in-expr":            "style/simplification",
+		"call-to-gc":                      "performance",
+		"cognitive-complexity":            "complexity",
+		"comment-spacings":                "style/comments",
+		"comments-density":                "documentation/comments",
+		"confusing-naming":                "maintainability/naming",
+		"confusing-results":               "maintainability/naming",
+		"constant-logical-expr":           "logic",
+		"context-as-argument":             "logic/context",
+		"context-keys-type":               "logic/context",
+		"cyclomatic":                      "complexity",
+		"datarace":                        "concurrency",
+		"deep-exit":                       "logic",
+		"defer":                           "logic",
+		"dot-imports":                     "style/imports",
+		"duplicated-imports":              "style/imports",
+		"early-return":                    "style/control-flow",
+		"empty-block":                     "style",
+		"empty-lines":                     "style/whitespace",
+		"enforce-map-style":               "style",
+		"enforce-repeated-arg-type-style": "style",
+		"enforce-slice-style":             "style",
+		"error-naming":                    "error-handling/naming",
+		"error-return":                    "error-handling",
+		"error-strings":                   "error-handling/naming",
+		"errorf":                          "error-handling",
+		"exported":                        "documentation/exported",
+		"file-header":                     "style/comments",
+		"file-length-limit":               "complexity/length",
+		"filename-format":                 "style/naming",
+		"flag-parameter":                  "maintainability",
+		"function-length":                 "complexity/length",
+		"function-result-limit":           "complexity",
+		"get-return":                      "style/naming",
+		"identical-branches":              "logic",
+		"if-return":                       "style/control-flow",
+		"import-alias-naming":             "style/naming",
+		"import-shadowing":                "style/naming",
+		"imports-blocklist":               "maintainability/dependencies",
+		"increment-decrement":             "style",
+		"indent-error-flow":               "style/control-flow",
+		"line-length-limit":               "style/line-length",
+		"max-control-nesting":             "complexity/nesting",
+		"max-public-structs":              "maintainability",
+		"modifies-parameter":              "logic",
+		"modifies-value-receiver":         "logic",
+		"nested-structs":                  "style",
+		"optimize-operands-order":         "performance",
+		"package-comments":                "documentation/package-comment",
+		"range":                           "style/simplification",
+		"range-val-address":               "logic/loop-variable",
+		"range-val-in-closure":            "logic/loop-variable",
+		"receiver-naming":                 "style/naming",
+		"redefines-builtin-id":            "logic",
+		"redundant-import-alias":          "style/imports",
+		"string-format":                   "format",
+		"string-of-int":                   "logic",
+		"struct-tag":                      "logic",
+		"superfluous-else":                "style/control-flow",
+		"time-equal":                      "logic",
+		"time-naming":                     "style/naming",
+		"unchecked-type-assertion":        "error-handling",
+		"unconditional-recursion":         "logic",
+		"unexported-naming":               "style/naming",
+		"unexported-return":               "style",
+		"unhandled-error":                 "error-handling/unchecked",
+		"unnecessary-stmt":                "style/simplification",
+		"unreachable-code":                "dead-code",
+		"unused-parameter":                "dead-code/unused-parameter",
+		"unused-receiver":                 "dead-code/unused-parameter",
+		"use-any":                         "style/modernization",
+		"useless-break":                   "style",
+		"var-declaration":                 "style",
+		"var-naming":                      "style/naming",
+		"waitgroup-by-value":              "concurrency",
+	},
+}
@@ -0,0 +1,69 @@
+package linters
+
+import (
+	"testing"
+)
+
+func TestTaxonomyClassify(t *testing.T) {
+	tests := []struct {
+		linter string
+		text   string
+		want   Classification
+	}{
+		{linter: "bodyclose", text: "response body must be closed", want: Classification{"logic", "resource-leak"}},
+		{linter: "errorlint", text: "non-wrapping format verb for fmt.Errorf", want: Classification{"error-handling", "wrapping"}},
+		{linter: "gocritic", text: "hugeParam: cfg is heavy (240 bytes); consider passing it by pointer", want: Classification{"performance", "gocritic"}},
+		{linter: "gocritic", text: "ifElseChain: rewrite if-else to switch statement", want: Classification{"style", "control-flow"}},
+		{linter: "gocritic", text: "newCheck: not in the taxonomy yet", want: Classification{"style", ""}},
+		{linter: "revive", text: "exported: exported function Foo should have comment or be unexported", want: Classification{"documentation", "exported"}},
+		{linter: "staticcheck", text: "SA4006: this value of x is never used", want: Classification{"logic", "ineffective-code"}},
+		{linter: "stylecheck", text: "ST1003: func getUrl should be getURL", want: Classification{"style", "naming"}},
+		{linter: "gosec", text: "G204: Subprocess launched with variable", want: Classification{"security", "command-injection"}},
+		{linter: "gosec", text: "G306: Expect WriteFile permissions to be 0600 or less", want: Classification{"security", "filesystem"}},
+		{linter: "govet", text: "printf: fmt.Printf format %d has arg x of wrong type", want: Classification{"format", "printf"}},
+		{linter: "somenewlinter", text: "something", want: Classification{"other", ""}},
+	}
+
+	taxonomy := DefaultTaxonomy()
+	g := &GolangciLint{taxonomy: taxonomy}
+	for _, tt := range tests {
+		t.Run(tt.linter+"/"+tt.text, func(t *testing.T) {
+			if got := g.classify(tt.linter, tt.text); got != tt.want {
+				t.Errorf("classify() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaxonomyOverrides(t *testing.T) {
+	taxonomy, err := DefaultTaxonomy().WithOverrides(TaxonomyOverrides{
+		Linters: map[string]string{"lll": "format"},
+		Checks:  map[string]string{"gocritic/hugeparam": "performance/memory", "staticcheck/SA4": "dead-code"},
+	})
+	if err != nil {
+		t.Fatalf("WithOverrides() error = %v", err)
+	}
+
+	if got := taxonomy.Classify("lll", ""); got != (Classification{Category: "format"}) {
+		t.Errorf("lll = %+v, want format", got)
+	}
+	if got := taxonomy.Classify("gocritic", "hugeParam"); got != (Classification{"performance", "memory"}) {
+		t.Errorf("gocritic/hugeParam = %+v, want performance/memory", got)
+	}
+	if got := taxonomy.Classify("staticcheck", "SA4006"); got.Category != "dead-code" {
+		t.Errorf("staticcheck/SA4006 = %+v, want dead-code", got)
+	}
+	if got := DefaultTaxonomy().Classify("lll", ""); got.Category != "style" {
+		t.Errorf("overrides changed the default taxonomy: lll = %+v", got)
+	}
+
+	invalid := []TaxonomyOverrides{
+		{Linters: map[string]string{"lll": ""}},
+		{Checks: map[string]string{"hugeParam": "performance"}},
+	}
+	for _, o := range invalid {
+		if _, err := DefaultTaxonomy().WithOverrides(o); err == nil {
+			t.Errorf("WithOverrides(%+v) should fail", o)
+		}
+	}
+}
@@ -4,13 +4,20 @@ import "time"
 
 // AnalysisRequest represents a code analysis request
 type AnalysisRequest struct {
-	Code       string                 `json:"code,omitempty"`       // Code snippet to analyze
-	FilePath   string                 `json:"file_path,omitempty"`  // Path to file
+	Code       string                 `json:"code,omitempty"`        // Code snippet to analyze
+	FilePath   string                 `json:"file_path,omitempty"`   // Path to file
 	ProjectDir string                 `json:"project_dir,omitempty"` // Path to project directory
-	Standard   string                 `json:"standard"`             // strict, standard, relaxed, or custom
-	Config     string                 `json:"config,omitempty"`     // Custom config content
-	Format     string                 `json:"format"`               // json, markdown, html, pdf
-	Options    map[string]interface{} `json:"options,omitempty"`    // Additional options
+	Standard   string                 `json:"standard"`              // strict, standard, relaxed, or custom; empty uses the project's .go-standards.json
+	Config     string                 `json:"config,omitempty"`      // Custom config content
+	ConfigRef  string                 `json:"config_ref,omitempty"`  // Stored config name, template name, or lint config path
+	Format     string                 `json:"format"`                // json, markdown, html, pdf
+	Options    map[string]interface{} `json:"options,omitempty"`     // Additional options
+
+	Baseline       string `json:"baseline,omitempty"`        // Path to baseline file (default: <project_dir>/.go-standards-baseline.json)
+	UpdateBaseline bool   `json:"update_baseline,omitempty"` // Snapshot current issues into the baseline file
+	Coverage       bool   `json:"coverage,omitempty"`        // Run tests and enforce the standard's coverage threshold
+	CoverProfile   string `json:"cover_profile,omitempty"`   // Existing coverage profile to use instead of running tests
+	AllIssues      bool   `json:"all_issues,omitempty"`      // Report every issue: lift golangci-lint's issue caps and skip the baseline and suppressions
 }
 
 // AnalysisResult represents the result of code analysis
@@ -21,43 +28,156 @@ type AnalysisResult struct {
 	Summary     Summary                `json:"summary"`
 	Metadata    Metadata               `json:"metadata"`
 	Suggestions []Suggestion           `json:"suggestions,omitempty"`
+	Baselined   []Issue                `json:"baselined,omitempty"` // Known issues suppressed by the baseline
+	Suppressed  []SuppressedIssue      `json:"suppressed,omitempty"` // Issues suppressed by inline directives
+	Linters     []LinterRun            `json:"linters"`              // Outcome of each linter run
 	CreatedAt   time.Time              `json:"created_at"`
 }
 
 // Issue represents a single code issue
 type Issue struct {
-	File       string   `json:"file"`
-	Line       int      `json:"line"`
-	Column     int      `json:"column"`
-	Severity   string   `json:"severity"` // error, warning, info
-	Category   string   `json:"category"` // format, logic, security, performance, etc.
-	Rule       string   `json:"rule"`
-	Message    string   `json:"message"`
-	Source     string   `json:"source"` // golangci-lint, staticcheck, etc.
-	Code       string   `json:"code,omitempty"`
-	Suggestion string   `json:"suggestion,omitempty"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	Severity    string `json:"severity"`              // error, warning, info
+	Category    string `json:"category"`              // format, logic, security, performance, etc.
+	Subcategory string `json:"subcategory,omitempty"` // Finer classification within the category, e.g. naming
+	Rule        string `json:"rule"`
+	Message     string `json:"message"`
+	Source      string `json:"source"` // golangci-lint, staticcheck, etc.
+	Code        string `json:"code,omitempty"`
+	Suggestion  string `json:"suggestion,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"` // Stable identifier used for baseline matching
+	Module      string `json:"module,omitempty"`      // Module path, for multi-module projects
+	ModuleFile  string `json:"module_file,omitempty"` // File path relative to the module root
+
+	EndLine   int            `json:"end_line,omitempty"`   // End of the reported range, when the linter provides it
+	EndColumn int            `json:"end_column,omitempty"` // End column of the reported range
+	Related   []RelatedInfo  `json:"related,omitempty"`    // Other locations relevant to the issue
+	Fixes     []SuggestedFix `json:"fixes,omitempty"`      // Machine-applicable fixes proposed by the linter
+
+	CWE           string             `json:"cwe,omitempty"`           // Weakness ID for security findings, e.g. CWE-22
+	Confidence    string             `json:"confidence,omitempty"`    // Linter's confidence in the finding: low, medium, high
+	Vulnerability *VulnerabilityInfo `json:"vulnerability,omitempty"` // Set for dependency vulnerability findings
+}
+
+// RelatedInfo points at another location relevant to an issue
+type RelatedInfo struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// SuggestedFix is a fix proposed by a linter, made of text edits
+type SuggestedFix struct {
+	Message string     `json:"message"`
+	Edits   []TextEdit `json:"edits"`
+}
+
+// TextEdit replaces the bytes [Start, End) of File with New
+type TextEdit struct {
+	File  string `json:"file"`
+	Start int    `json:"start"` // Byte offset
+	End   int    `json:"end"`   // Byte offset
+	New   string `json:"new"`
+}
+
+// VulnerabilityInfo describes a known vulnerability affecting a dependency
+type VulnerabilityInfo struct {
+	ID           string   `json:"id"`                // OSV ID, e.g. GO-2023-1234
+	Aliases      []string `json:"aliases,omitempty"` // CVE and GHSA IDs
+	Module       string   `json:"module"`
+	Package      string   `json:"package,omitempty"`
+	Symbol       string   `json:"symbol,omitempty"`     // Vulnerable function, if it is called
+	CallStack    []string `json:"call_stack,omitempty"` // From the entry point in the analyzed code to the vulnerable symbol
+	FoundVersion string   `json:"found_version,omitempty"`
+	FixedVersion string   `json:"fixed_version,omitempty"`
+	URL          string   `json:"url,omitempty"`
+}
+
+// LinterRun records the outcome of a single linter execution
+type LinterRun struct {
+	Name     string        `json:"name"`
+	Module   string        `json:"module,omitempty"` // Module the linter ran in, for multi-module projects
+	Status   string        `json:"status"`           // ok, failed, timeout
+	Version  string        `json:"version,omitempty"`
+	ExitCode int           `json:"exit_code"`
+	Duration time.Duration `json:"duration"`
+	Issues   int           `json:"issues"`
+	Error    string        `json:"error,omitempty"`
+	Stderr   string        `json:"stderr,omitempty"`   // Excerpt of stderr output
+	Limit    string        `json:"limit,omitempty"`    // Sandbox limit that stopped the run: cpu, memory, file-size, time
+	Standard string        `json:"standard,omitempty"` // Standard of the run when directory overrides are in effect
+}
+
+// SuppressedIssue is an issue silenced by a //standards:ignore directive
+type SuppressedIssue struct {
+	Issue     Issue  `json:"issue"`
+	Directive string `json:"directive"`        // Directive text as written
+	Scope     string `json:"scope"`            // line, function, or file
+	Reason    string `json:"reason,omitempty"` // Justification given after "--"
+	Line      int    `json:"line"`             // Line of the directive
 }
 
 // Summary provides statistics about the analysis
 type Summary struct {
-	TotalIssues    int            `json:"total_issues"`
-	ErrorCount     int            `json:"error_count"`
-	WarningCount   int            `json:"warning_count"`
-	InfoCount      int            `json:"info_count"`
-	FilesAnalyzed  int            `json:"files_analyzed"`
-	LinesAnalyzed  int            `json:"lines_analyzed"`
-	Duration       time.Duration  `json:"duration"`
-	Score          float64        `json:"score"` // 0-100
-	CategoryCounts map[string]int `json:"category_counts"`
+	TotalIssues     int              `json:"total_issues"`
+	ErrorCount      int              `json:"error_count"`
+	WarningCount    int              `json:"warning_count"`
+	InfoCount       int              `json:"info_count"`
+	FilesAnalyzed   int              `json:"files_analyzed"`
+	LinesAnalyzed   int              `json:"lines_analyzed"`
+	Duration        time.Duration    `json:"duration"`
+	Score           float64          `json:"score"` // 0-100
+	CategoryCounts  map[string]int   `json:"category_counts"`
+	BaselinedCount  int              `json:"baselined_count"`    // Issues matched by the baseline, excluded from counts and score
+	SuppressedCount int              `json:"suppressed_count"`   // Issues suppressed by inline directives
+	Modules         []ModuleSummary  `json:"modules,omitempty"`  // Per-module breakdown
+	Coverage        *CoverageSummary `json:"coverage,omitempty"` // Test coverage, if the coverage stage ran
+}
+
+// CoverageSummary provides test coverage metrics
+type CoverageSummary struct {
+	Total              float64           `json:"total"`               // Statement coverage in percent
+	Threshold          float64           `json:"threshold,omitempty"` // Required coverage for the standard
+	Passed             bool              `json:"passed"`
+	Statements         int               `json:"statements"`
+	Covered            int               `json:"covered"`
+	UncoveredFunctions int               `json:"uncovered_functions"`
+	Packages           []PackageCoverage `json:"packages"`
+}
+
+// PackageCoverage provides coverage metrics for a single package
+type PackageCoverage struct {
+	Path       string  `json:"path"`
+	Coverage   float64 `json:"coverage"`
+	Statements int     `json:"statements"`
+	Covered    int     `json:"covered"`
+}
+
+// ModuleSummary provides statistics for a single module
+type ModuleSummary struct {
+	Path         string  `json:"path"`
+	Dir          string  `json:"dir"` // Relative to the project root
+	TotalIssues  int     `json:"total_issues"`
+	ErrorCount   int     `json:"error_count"`
+	WarningCount int     `json:"warning_count"`
+	InfoCount    int     `json:"info_count"`
+	Score        float64 `json:"score"`
 }
 
 // Metadata contains analysis metadata
 type Metadata struct {
 	Standard      string            `json:"standard"`
 	ToolsUsed     []string          `json:"tools_used"`
-	ConfigHash    string            `json:"config_hash"`
+	ConfigHash    string            `json:"config_hash"`              // SHA-256 of the config the linters ran with
+	ConfigSources []string          `json:"config_sources,omitempty"` // Config chain, with stored configs as config:name@revision
 	GoVersion     string            `json:"go_version"`
 	ServerVersion string            `json:"server_version"`
+	Baseline      string            `json:"baseline,omitempty"`          // Baseline file used, if any
+	Project       string            `json:"project_standards,omitempty"` // .go-standards.json the standards came from, if any
+	Overrides     []string          `json:"overrides,omitempty"`         // Directory overrides applied, as "dir: standard"
 	Options       map[string]string `json:"options,omitempty"`
 }
 